@@ -8,13 +8,16 @@ import (
 	"github.com/sharifsharifzoda/project-management-system/configs"
 	"github.com/sharifsharifzoda/project-management-system/db"
 	"github.com/sharifsharifzoda/project-management-system/logging"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
 	"github.com/sharifsharifzoda/project-management-system/pkg/handler"
+	"github.com/sharifsharifzoda/project-management-system/pkg/mailer"
 	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
 	"github.com/sharifsharifzoda/project-management-system/pkg/service"
 	"github.com/spf13/viper"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func main() {
@@ -36,16 +39,49 @@ func main() {
 	}
 	cfg.Password = os.Getenv("DB_PASSWORD")
 
+	var jwtCfg configs.JWTConfig
+	if err := viper.UnmarshalKey("jwt", &jwtCfg); err != nil {
+		logger.Fatalf("Couldn't unmarshal the jwt config into struct. error is %v", err.Error())
+	}
+
+	keys, err := service.LoadKeySet(jwtCfg)
+	if err != nil {
+		logger.Fatalf("failed to load jwt keys. error is %v", err.Error())
+	}
+
+	var emailCfg configs.EmailConfig
+	if err := viper.UnmarshalKey("email", &emailCfg); err != nil {
+		logger.Fatalf("Couldn't unmarshal the email config into struct. error is %v", err.Error())
+	}
+	emailCfg.SMTP.Password = os.Getenv("SMTP_PASSWORD")
+
+	var deadlineCfg configs.DeadlineConfig
+	if err := viper.UnmarshalKey("deadlines", &deadlineCfg); err != nil {
+		logger.Fatalf("Couldn't unmarshal the deadlines config into struct. error is %v", err.Error())
+	}
+
+	var mail mailer.Mailer = mailer.NewLogMailer(logger)
+	if emailCfg.SMTP.Host != "" {
+		mail = mailer.NewSMTPMailer(emailCfg.SMTP)
+	}
+
 	conn := db.GetDBConnection(cfg)
 
 	db.Init(conn)
 
 	//---------- Dependency injection-----------
 	newRepository := repository.NewRepository(conn)
-	newService := service.NewService(newRepository, logger)
-	newHandler := handler.NewHandler(newService.Auth, newService.User, newService.Project, newService.Task)
+	events := broker.NewMemory()
+	newService := service.NewService(newRepository, keys, mail, emailCfg, deadlineCfg, events, logger)
+	newHandler := handler.NewHandler(newService)
 	//--------------------------------------------
 
+	ctx, cancel := context.WithCancel(context.Background())
+	go newService.Recurrence.Run(ctx, time.Minute)
+	go newService.Notification.Run(ctx, time.Minute)
+	go newService.Webhook.Run(ctx, 10*time.Second)
+	go newService.Deadline.Run(ctx, time.Minute)
+
 	server := new(project_management_system.Server)
 	go func() {
 		if err := server.Run(os.Getenv("PORT"), newHandler.InitRoutes()); err != nil {
@@ -58,6 +94,8 @@ func main() {
 	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
 	<-ch
 
+	cancel()
+	events.Close()
 	db.Close(conn)
 
 	fmt.Println("server is shutting down")
@@ -8,3 +8,40 @@ type DatabaseConnConfig struct {
 	DbName   string
 	SSLMode  string
 }
+
+type JWTKeyConfig struct {
+	Kid  string `mapstructure:"kid"`
+	Path string `mapstructure:"path"`
+}
+
+type JWTConfig struct {
+	SigningKey       JWTKeyConfig   `mapstructure:"signing_key"`
+	VerificationKeys []JWTKeyConfig `mapstructure:"verification_keys"`
+}
+
+// SMTPConfig points at the server emails are sent through. The password is
+// read from the SMTP_PASSWORD environment variable.
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"-"`
+	From     string `mapstructure:"from"`
+}
+
+// EmailConfig configures the notification emails. Daily digests go out at
+// DigestHour, UTC.
+type EmailConfig struct {
+	SMTP       SMTPConfig `mapstructure:"smtp"`
+	DigestHour int        `mapstructure:"digest_hour"`
+}
+
+// DeadlineConfig sets when the deadline scheduler acts. Reminders are the
+// hours before a deadline that whoever works on the task, or manages the
+// project, is reminded of it. Escalations are the hours after it that the
+// controller of the task and the manager of its project, or the manager and
+// the admins of an overdue project, are told.
+type DeadlineConfig struct {
+	Reminders   []int `mapstructure:"reminders"`
+	Escalations []int `mapstructure:"escalations"`
+}
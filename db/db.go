@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"github.com/sharifsharifzoda/project-management-system/configs"
 	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/utils"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"log"
+	"strings"
 )
 
 func GetDBConnection(cfg configs.DatabaseConnConfig) *gorm.DB {
@@ -35,11 +37,23 @@ func Close(db *gorm.DB) {
 }
 
 func Init(db *gorm.DB) {
-	err := db.AutoMigrate(&models.User{}, &models.Project{}, &models.ProjectParticipant{}, &models.Task{})
+	err := db.AutoMigrate(&models.User{}, &models.Organization{}, &models.OrganizationMember{},
+		&models.Department{}, &models.DepartmentMember{}, &models.Team{}, &models.TeamMember{}, &models.Project{},
+		&models.ProjectParticipant{}, &models.ProjectTeam{}, &models.Task{}, &models.TaskAssignee{}, &models.Label{},
+		&models.TaskLabel{}, &models.CustomField{}, &models.BoardColumn{}, &models.Sprint{}, &models.Milestone{},
+		&models.Worklog{}, &models.Recurrence{}, &models.ChecklistItem{}, &models.TaskTransfer{}, &models.ImpersonationLog{},
+		&models.ProjectInvite{}, &models.ProjectTemplate{}, &models.AuditEntry{}, &models.Activity{},
+		&models.TaskComment{}, &models.Notification{}, &models.NotificationPreference{}, &models.Webhook{},
+		&models.WebhookDelivery{})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	initDefaultOrganization(db)
+	migrateDepartments(db)
+	activeKeyIndex(db, &models.Department{}, "idx_department_key")
+	activeKeyIndex(db, &models.Team{}, "idx_team_key")
+
 	var superuser = models.User{
 		Firstname: "Sharif",
 		Lastname:  "Sharifzoda",
@@ -57,3 +71,118 @@ func Init(db *gorm.DB) {
 		log.Println("Error is: ", err.Error())
 	}
 }
+
+// initDefaultOrganization moves the data created before organizations existed
+// into a "default" one. It only runs when that organization is first created.
+func initDefaultOrganization(db *gorm.DB) {
+	var org = models.Organization{Name: "Default", Slug: "default"}
+	tx := db.Where(models.Organization{Slug: org.Slug}).FirstOrCreate(&org)
+	if tx.Error != nil {
+		log.Fatal("failed to create the default organization. Error is: ", tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Project{}).Where("organization_id IS NULL").
+			Update("organization_id", org.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Task{}).Where("organization_id IS NULL").
+			Update("organization_id", org.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`INSERT INTO organization_members (organization_id, user_id, role, created_at)
+			SELECT ?, id, CASE WHEN role = 'superuser' THEN 'owner' ELSE 'member' END, now() FROM users`,
+			org.ID).Error
+	})
+	if err != nil {
+		log.Fatal("failed to move existing data into the default organization. Error is: ", err.Error())
+	}
+}
+
+// migrateDepartments turns the free-text department of projects into
+// department records. Names that differ only by case, spaces or punctuation
+// end up in the same department. The old column is dropped afterwards.
+func migrateDepartments(db *gorm.DB) {
+	if !db.Migrator().HasColumn(&models.Project{}, "department") {
+		return
+	}
+
+	type legacyDepartment struct {
+		OrganizationId int
+		Department     string
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var legacy []legacyDepartment
+		err := tx.Table("projects").Distinct("organization_id", "department").
+			Where("department IS NOT NULL AND department <> ''").Scan(&legacy).Error
+		if err != nil {
+			return err
+		}
+
+		for _, l := range legacy {
+			key := utils.NormalizeName(l.Department)
+			if key == "" {
+				continue
+			}
+
+			dep := models.Department{
+				OrganizationId: l.OrganizationId,
+				Name:           strings.TrimSpace(l.Department),
+				Key:            key,
+			}
+			err := tx.Where(models.Department{OrganizationId: l.OrganizationId, Key: key, IsActive: true}).
+				FirstOrCreate(&dep).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Table("projects").Where("organization_id = ? AND department = ?", l.OrganizationId, l.Department).
+				Update("department_id", dep.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Migrator().DropColumn(&models.Project{}, "department")
+	})
+	if err != nil {
+		log.Fatal("failed to move project departments into departments. Error is: ", err.Error())
+	}
+}
+
+// activeKeyIndex limits the unique key index of the model to its active
+// records, so that a deleted one doesn't keep its name taken. The index was
+// made over all of them before, and AutoMigrate leaves an existing index as
+// it is.
+func activeKeyIndex(db *gorm.DB, model any, name string) {
+	var partial bool
+	err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_indexes WHERE indexname = ? AND indexdef LIKE '% WHERE %')",
+		name).Scan(&partial).Error
+	if err != nil {
+		log.Fatal("failed to check the index ", name, ". Error is: ", err.Error())
+	}
+
+	if partial {
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasIndex(model, name) {
+			if err := tx.Migrator().DropIndex(model, name); err != nil {
+				return err
+			}
+		}
+
+		return tx.Migrator().CreateIndex(model, name)
+	})
+	if err != nil {
+		log.Fatal("failed to limit the index ", name, " to active records. Error is: ", err.Error())
+	}
+}
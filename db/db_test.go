@@ -0,0 +1,123 @@
+package db
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"sync"
+	"testing"
+)
+
+// The migration tests run against the Postgres database in
+// TEST_DATABASE_DSN and are skipped without one. Every test works in a
+// transaction that is rolled back when it ends.
+var (
+	initOnce    sync.Once
+	testConn    *gorm.DB
+	testConnErr error
+)
+
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	initOnce.Do(func() {
+		testConn, testConnErr = gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+		if testConnErr == nil {
+			Init(testConn)
+		}
+	})
+	if testConnErr != nil {
+		t.Fatal(testConnErr)
+	}
+
+	tx := testConn.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+
+	return tx
+}
+
+func TestMigrateDepartments(t *testing.T) {
+	tx := testDB(t)
+
+	org := models.Organization{Name: "Migration", Slug: "migration-test"}
+	assert.NoError(t, tx.Create(&org).Error)
+
+	manager := models.User{Firstname: "Test", Lastname: "Manager", Email: "migration-test@example.com", Password: "x"}
+	assert.NoError(t, tx.Create(&manager).Error)
+
+	deleted := models.Department{OrganizationId: org.ID, Name: "HR", Key: "hr"}
+	assert.NoError(t, tx.Create(&deleted).Error)
+	assert.NoError(t, tx.Model(&deleted).Update("is_active", false).Error)
+
+	assert.NoError(t, tx.Exec("ALTER TABLE projects ADD COLUMN department text").Error)
+
+	projects := map[string]string{"it": "IT", "dotted": "I.T.", "spaced": " it ", "hr": "HR", "none": "",
+		"blank": " . "}
+	ids := map[string]int{}
+	for name, department := range projects {
+		var id int
+		err := tx.Raw("INSERT INTO projects (organization_id, name, description, manager_id, deadline, department) "+
+			"VALUES (?, ?, '', ?, now(), ?) RETURNING id", org.ID, name, manager.ID, department).Scan(&id).Error
+		assert.NoError(t, err)
+		ids[name] = id
+	}
+
+	migrateDepartments(tx)
+
+	assert.False(t, tx.Migrator().HasColumn(&models.Project{}, "department"))
+
+	var departments models.Departments
+	assert.NoError(t, tx.Where("organization_id = ? AND is_active = ?", org.ID, true).Order("key").
+		Find(&departments).Error)
+	if assert.Len(t, departments, 2) {
+		assert.Equal(t, "hr", departments[0].Key)
+		assert.NotEqual(t, deleted.ID, departments[0].ID)
+		assert.Equal(t, "it", departments[1].Key)
+	}
+
+	departmentOf := func(project string) *int {
+		var p models.Project
+		assert.NoError(t, tx.Select("department_id").First(&p, ids[project]).Error)
+		return p.DepartmentId
+	}
+
+	for _, project := range []string{"it", "dotted", "spaced"} {
+		assert.Equal(t, &departments[1].ID, departmentOf(project), project)
+	}
+	assert.Equal(t, &departments[0].ID, departmentOf("hr"))
+	assert.Nil(t, departmentOf("none"))
+	assert.Nil(t, departmentOf("blank"))
+}
+
+func TestActiveKeyIndex(t *testing.T) {
+	tx := testDB(t)
+
+	// The index as it was made before it was limited to active departments.
+	assert.NoError(t, tx.Migrator().DropIndex(&models.Department{}, "idx_department_key"))
+	assert.NoError(t, tx.Exec("CREATE UNIQUE INDEX idx_department_key ON departments (organization_id, key)").Error)
+
+	activeKeyIndex(tx, &models.Department{}, "idx_department_key")
+
+	var definition string
+	assert.NoError(t, tx.Raw("SELECT indexdef FROM pg_indexes WHERE indexname = ?", "idx_department_key").
+		Scan(&definition).Error)
+	assert.Contains(t, definition, "WHERE is_active")
+
+	org := models.Organization{Name: "Index", Slug: "index-test"}
+	assert.NoError(t, tx.Create(&org).Error)
+
+	old := models.Department{OrganizationId: org.ID, Name: "IT", Key: "it"}
+	assert.NoError(t, tx.Create(&old).Error)
+	assert.NoError(t, tx.Model(&old).Update("is_active", false).Error)
+
+	assert.NoError(t, tx.Create(&models.Department{OrganizationId: org.ID, Name: "IT", Key: "it"}).Error)
+	assert.Error(t, tx.Create(&models.Department{OrganizationId: org.ID, Name: "I.T.", Key: "it"}).Error)
+}
@@ -3,8 +3,8 @@ module github.com/sharifsharifzoda/project-management-system
 go 1.20
 
 require (
-	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/gin-gonic/gin v1.9.1
+	github.com/golang-jwt/jwt/v5 v5.1.0
 	github.com/golang/mock v1.4.4
 	github.com/joho/godotenv v1.5.1
 	github.com/sirupsen/logrus v1.9.3
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"gorm.io/gorm"
 	"time"
 )
@@ -19,21 +22,32 @@ type User struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+const (
+	StatusNotStarted = "Not started"
+	StatusInProgress = "In progress"
+	StatusDone       = "Done"
+)
+
 type Project struct {
-	ID          int       `json:"id" gorm:"serial;primaryKey"`
-	Name        string    `json:"name" gorm:"not null"`
-	Description string    `json:"description" gorm:"not null"`
-	Department  string    `json:"department" gorm:"not null"`
-	ManagerID   int       `json:"-" gorm:"manager_id"`
-	ManagerName string    `json:"manager_name" gorm:"-"`
-	Status      string    `json:"status" gorm:"not null;default:'Not started'"`
-	StartDate   string    `json:"start_date,omitempty" gorm:"type:timestamp;not null;default: now()"`
-	Deadline    string    `json:"deadline" gorm:"type:timestamp;not null"`
-	IsActive    bool      `json:"-" gorm:"not null;default: true"`
-	CreatedAt   time.Time `json:"-" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"-" gorm:"autoUpdateTime"`
-	DeletedAt   time.Time `json:"-" gorm:"index"`
-	User        User      `json:"-" gorm:"foreignKey:ManagerID"`
+	ID             int          `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int          `json:"-" gorm:"index"`
+	Name           string       `json:"name" gorm:"not null"`
+	Description    string       `json:"description" gorm:"not null"`
+	DepartmentId   *int         `json:"department_id,omitempty" gorm:"index"`
+	DepartmentName string       `json:"department" gorm:"-"`
+	ManagerID      int          `json:"-" gorm:"manager_id"`
+	ManagerName    string       `json:"manager_name" gorm:"-"`
+	Status         string       `json:"status" gorm:"not null;default:'Not started'"`
+	StartDate      string       `json:"start_date,omitempty" gorm:"type:timestamp;not null;default: now()"`
+	Deadline       string       `json:"deadline" gorm:"type:timestamp;not null"`
+	IsOverdue      bool         `json:"is_overdue" gorm:"not null;default:false"`
+	IsActive       bool         `json:"-" gorm:"not null;default: true"`
+	CreatedAt      time.Time    `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `json:"-" gorm:"autoUpdateTime"`
+	DeletedAt      time.Time    `json:"-" gorm:"index"`
+	User           User         `json:"-" gorm:"foreignKey:ManagerID"`
+	Organization   Organization `json:"-" gorm:"foreignKey:OrganizationId"`
+	Department     *Department  `json:"-" gorm:"foreignKey:DepartmentId"`
 }
 
 type Projects []Project
@@ -47,24 +61,1031 @@ type ProjectParticipant struct {
 	Project       Project `json:"-" gorm:"foreignKey:ProjectId"`
 }
 
+// UserProject is a project the user takes part in, with their role in it.
+// ID is their participant record, which the projects they only take part in
+// through a team don't have.
+type UserProject struct {
+	ID            *int   `json:"id,omitempty"`
+	ParticipantId int    `json:"participant_id"`
+	Role          string `json:"role"`
+	ProjectId     int    `json:"project_id"`
+}
+
 type Task struct {
-	ID           int       `json:"id" gorm:"serial;primaryKey"`
-	Title        string    `json:"title" gorm:"not null"`
-	Description  string    `json:"description" gorm:"not null"`
-	ControllerId int       `json:"-" gorm:"controller_id"`
-	ExecutorId   int       `json:"-" gorm:"executor_id"`
-	ExecutorName string    `json:"executor_name" gorm:"-"`
-	Status       string    `json:"status" gorm:"not null;default:'Not started'"`
-	ProjectId    int       `json:"-" gorm:"project_id"`
-	ProjectName  string    `json:"project_name" gorm:"-"`
-	Deadline     string    `json:"deadline" gorm:"type:timestamp;not null"`
-	IsActive     bool      `json:"-" gorm:"not null;default: true"`
-	CreatedAt    time.Time `json:"-" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `json:"-" gorm:"autoUpdateTime"`
-	DeletedAt    time.Time `json:"-" gorm:"index"`
-	Controller   User      `json:"-" gorm:"foreignKey:ControllerId"`
-	Executor     User      `json:"-" gorm:"foreignKey:ExecutorId"`
-	Project      Project   `json:"-" gorm:"foreignKey:ProjectId"`
+	ID                int          `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId    int          `json:"-" gorm:"index"`
+	Title             string       `json:"title" gorm:"not null"`
+	Description       string       `json:"description" gorm:"not null"`
+	ControllerId      int          `json:"-" gorm:"controller_id"`
+	ExecutorId        *int         `json:"-" gorm:"executor_id"`
+	ExecutorName      string       `json:"executor_name" gorm:"-"`
+	TeamId            *int         `json:"team_id,omitempty" gorm:"index"`
+	TeamName          string       `json:"team_name,omitempty" gorm:"-"`
+	Roles             []string     `json:"roles,omitempty" gorm:"-"`
+	Labels            []Label      `json:"labels,omitempty" gorm:"-"`
+	CustomFields      JSONMap      `json:"custom_fields" gorm:"type:jsonb;not null;default:'{}'"`
+	Status            string       `json:"status" gorm:"not null;default:'Not started'"`
+	Priority          string       `json:"priority" gorm:"not null;default:'medium';index"`
+	Rank              string       `json:"rank,omitempty" gorm:"not null;default:'';index"`
+	SprintId          *int         `json:"sprint_id,omitempty" gorm:"index"`
+	MilestoneId       *int         `json:"milestone_id,omitempty" gorm:"index"`
+	RecurrenceId      *int         `json:"recurrence_id,omitempty" gorm:"index"`
+	OriginalEstimate  *int         `json:"original_estimate,omitempty"`
+	RemainingEstimate *int         `json:"remaining_estimate,omitempty"`
+	TimeSpent         int          `json:"time_spent" gorm:"-"`
+	ChecklistDone     int          `json:"checklist_done" gorm:"-"`
+	ChecklistTotal    int          `json:"checklist_total" gorm:"-"`
+	ProjectId         int          `json:"-" gorm:"project_id"`
+	ProjectName       string       `json:"project_name" gorm:"-"`
+	Deadline          string       `json:"deadline" gorm:"type:timestamp;not null"`
+	IsOverdue         bool         `json:"is_overdue" gorm:"not null;default:false"`
+	IsActive          bool         `json:"-" gorm:"not null;default: true"`
+	CreatedAt         time.Time    `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time    `json:"-" gorm:"autoUpdateTime"`
+	DeletedAt         time.Time    `json:"-" gorm:"index"`
+	Controller        User         `json:"-" gorm:"foreignKey:ControllerId"`
+	Executor          *User        `json:"-" gorm:"foreignKey:ExecutorId"`
+	Sprint            *Sprint      `json:"-" gorm:"foreignKey:SprintId"`
+	Milestone         *Milestone   `json:"-" gorm:"foreignKey:MilestoneId"`
+	Recurrence        *Recurrence  `json:"-" gorm:"foreignKey:RecurrenceId"`
+	Team              *Team        `json:"-" gorm:"foreignKey:TeamId"`
+	Project           Project      `json:"-" gorm:"foreignKey:ProjectId"`
+	Organization      Organization `json:"-" gorm:"foreignKey:OrganizationId"`
 }
 
 type Tasks []Task
+
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// BulkTaskUpdate applies the same changes to many tasks. Empty values leave
+// a task as it is; LabelIds replaces the labels only when SetLabels is true.
+// With DryRun the changes are checked but not saved. Tasks moved to another
+// project take along what Transfer says.
+type BulkTaskUpdate struct {
+	TaskIds    []int
+	Status     string
+	ExecutorId *int
+	TeamId     *int
+	ProjectId  int
+	SetLabels  bool
+	LabelIds   []int
+	Delete     bool
+	DryRun     bool
+	Transfer   TransferOptions
+}
+
+// TaskChange is what a bulk update does to one task: the columns to set, its
+// new labels when SetLabels is true, or soft-deleting it. A task moved to
+// another project also has the Transfer to record and what it takes along.
+// Place puts a task that changes column at the bottom of its new one.
+type TaskChange struct {
+	TaskId    int
+	Columns   map[string]any
+	SetLabels bool
+	LabelIds  []int
+	Delete    bool
+	Place     bool
+	Transfer  *TaskTransfer
+	Options   TransferOptions
+}
+
+type BulkTaskResult struct {
+	TaskId int    `json:"task_id"`
+	Ok     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkTaskResults reports every task of a bulk update. Nothing is applied
+// unless every task can be updated.
+type BulkTaskResults struct {
+	DryRun  bool             `json:"dry_run"`
+	Applied bool             `json:"applied"`
+	Results []BulkTaskResult `json:"results"`
+}
+
+const (
+	TransferMove = "move"
+	TransferCopy = "copy"
+)
+
+// TransferOptions is what a task takes along when it is moved or copied to
+// another project.
+type TransferOptions struct {
+	Checklist bool
+	Comments  bool
+}
+
+// TaskTransfer records a task moved or copied to another project. A moved
+// task keeps its id, so its worklogs and other history stay with it; a copy
+// is a new task, CopyId, and the original is left untouched.
+type TaskTransfer struct {
+	ID            int       `json:"id" gorm:"serial;primaryKey"`
+	TaskId        int       `json:"task_id" gorm:"not null;index"`
+	CopyId        *int      `json:"copy_id,omitempty" gorm:"index"`
+	Kind          string    `json:"kind" gorm:"not null"`
+	FromProjectId int       `json:"from_project_id" gorm:"not null"`
+	ToProjectId   int       `json:"to_project_id" gorm:"not null"`
+	ActorId       int       `json:"actor_id" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Task          Task      `json:"-" gorm:"foreignKey:TaskId"`
+	Actor         User      `json:"-" gorm:"foreignKey:ActorId"`
+}
+
+// TaskFilter narrows down the list of tasks. Zero values mean no filtering.
+// Fields holds raw custom field values by key, the service turns them into
+// FieldsContain once it knows the field types.
+type TaskFilter struct {
+	Priority      string
+	LabelId       int
+	ProjectId     int
+	Overdue       bool
+	Fields        map[string]string
+	FieldsContain JSONMap
+	SortField     string
+	SortCast      string
+	SortDesc      bool
+}
+
+const (
+	FieldText         = "text"
+	FieldNumber       = "number"
+	FieldDate         = "date"
+	FieldSingleSelect = "single_select"
+	FieldMultiSelect  = "multi_select"
+	FieldUser         = "user"
+)
+
+// CustomField defines an extra field of the tasks of a project. Values are
+// kept in Task.CustomFields under the field's key.
+type CustomField struct {
+	ID        int        `json:"id" gorm:"serial;primaryKey"`
+	ProjectId int        `json:"project_id" gorm:"not null;uniqueIndex:idx_custom_field_key"`
+	Name      string     `json:"name" gorm:"not null"`
+	Key       string     `json:"key" gorm:"not null;uniqueIndex:idx_custom_field_key"`
+	Type      string     `json:"type" gorm:"not null"`
+	Options   StringList `json:"options,omitempty" gorm:"type:jsonb;not null;default:'[]'"`
+	Required  bool       `json:"required" gorm:"not null;default:false"`
+	CreatedAt time.Time  `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"-" gorm:"autoUpdateTime"`
+	Project   Project    `json:"-" gorm:"foreignKey:ProjectId"`
+}
+
+type CustomFields []CustomField
+
+// BoardColumn is a status column of the project's kanban board. A zero
+// WipLimit means the column has no limit.
+type BoardColumn struct {
+	ID        int     `json:"id" gorm:"serial;primaryKey"`
+	ProjectId int     `json:"project_id" gorm:"not null;uniqueIndex:idx_board_column"`
+	Status    string  `json:"status" gorm:"not null;uniqueIndex:idx_board_column"`
+	Position  int     `json:"position" gorm:"not null;default:0"`
+	WipLimit  int     `json:"wip_limit" gorm:"not null;default:0"`
+	Tasks     Tasks   `json:"tasks" gorm:"-"`
+	Project   Project `json:"-" gorm:"foreignKey:ProjectId"`
+}
+
+type Board struct {
+	ProjectId int           `json:"project_id"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+const (
+	SprintPlanned   = "planned"
+	SprintActive    = "active"
+	SprintCompleted = "completed"
+)
+
+// Sprint is an iteration of a project. A project has at most one active
+// sprint. The task counts are fixed when the sprint starts and completes, so
+// velocity doesn't change when tasks are edited afterwards.
+type Sprint struct {
+	ID             int        `json:"id" gorm:"serial;primaryKey"`
+	ProjectId      int        `json:"project_id" gorm:"not null;index;uniqueIndex:idx_active_sprint,where:status = 'active'"`
+	Name           string     `json:"name" gorm:"not null"`
+	Goal           string     `json:"goal"`
+	StartDate      time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate        time.Time  `json:"end_date" gorm:"type:date;not null"`
+	Status         string     `json:"status" gorm:"not null;default:'planned'"`
+	CommittedTasks int        `json:"committed_tasks" gorm:"not null;default:0"`
+	CompletedTasks int        `json:"completed_tasks" gorm:"not null;default:0"`
+	CarriedOver    int        `json:"carried_over" gorm:"not null;default:0"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	Tasks          Tasks      `json:"tasks,omitempty" gorm:"-"`
+	CreatedAt      time.Time  `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"-" gorm:"autoUpdateTime"`
+	Project        Project    `json:"-" gorm:"foreignKey:ProjectId"`
+}
+
+type Sprints []Sprint
+
+// Velocity lists the completed sprints of a project, the latest first, with
+// the average number of tasks completed over the last few of them.
+type Velocity struct {
+	ProjectId int     `json:"project_id"`
+	Average   float64 `json:"average"`
+	Sprints   Sprints `json:"sprints"`
+}
+
+// Milestone groups tasks of a project under a due date. The progress fields
+// are computed from the milestone's active tasks when it is read. A milestone
+// is at risk when any of its open tasks is due after the milestone itself.
+type Milestone struct {
+	ID              int       `json:"id" gorm:"serial;primaryKey"`
+	ProjectId       int       `json:"project_id" gorm:"not null;index"`
+	Name            string    `json:"name" gorm:"not null"`
+	Description     string    `json:"description"`
+	DueDate         time.Time `json:"due_date" gorm:"type:date;not null"`
+	TotalTasks      int64     `json:"total_tasks" gorm:"-"`
+	DoneTasks       int64     `json:"done_tasks" gorm:"-"`
+	PercentComplete int       `json:"percent_complete" gorm:"-"`
+	AtRisk          bool      `json:"at_risk" gorm:"-"`
+	Tasks           Tasks     `json:"tasks,omitempty" gorm:"-"`
+	CreatedAt       time.Time `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"-" gorm:"autoUpdateTime"`
+	Project         Project   `json:"-" gorm:"foreignKey:ProjectId"`
+}
+
+type Milestones []Milestone
+
+// Worklog is time a user spent on a task. Like the task estimates it is in
+// minutes, and logging it lowers the task's remaining estimate. A worklog
+// without EndedAt is a running timer; a user has at most one of those.
+type Worklog struct {
+	ID        int        `json:"id" gorm:"serial;primaryKey"`
+	TaskId    int        `json:"task_id" gorm:"not null;index"`
+	UserId    int        `json:"user_id" gorm:"not null;index;uniqueIndex:idx_running_timer,where:ended_at IS NULL"`
+	Firstname string     `json:"firstname,omitempty" gorm:"-"`
+	StartedAt time.Time  `json:"started_at" gorm:"not null;index"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Minutes   int        `json:"minutes" gorm:"not null;default:0"`
+	Note      string     `json:"note"`
+	CreatedAt time.Time  `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"-" gorm:"autoUpdateTime"`
+	Task      Task       `json:"-" gorm:"foreignKey:TaskId"`
+	User      User       `json:"-" gorm:"foreignKey:UserId"`
+}
+
+type Worklogs []Worklog
+
+// TimesheetFilter narrows down a timesheet. Zero ids mean every user or
+// project of the organization.
+type TimesheetFilter struct {
+	OrganizationId int
+	UserId         int
+	ProjectId      int
+	From           time.Time
+	To             time.Time
+}
+
+// TimesheetEntry is the time a user logged on a project during the week
+// starting on Monday Week.
+type TimesheetEntry struct {
+	UserId      int       `json:"user_id"`
+	Firstname   string    `json:"firstname"`
+	Lastname    string    `json:"lastname"`
+	ProjectId   int       `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	Week        time.Time `json:"week"`
+	Minutes     int64     `json:"minutes"`
+}
+
+type Timesheet struct {
+	From    time.Time        `json:"from"`
+	To      time.Time        `json:"to"`
+	Total   int64            `json:"total_minutes"`
+	Entries []TimesheetEntry `json:"entries"`
+}
+
+const (
+	RecurrenceOnSchedule = "on_schedule"
+	RecurrenceOnComplete = "on_complete"
+)
+
+// Recurrence repeats a task by an RRULE. Every occurrence is a task of its
+// own pointing back here; the next one is made from LastTaskId, either once
+// that task is done or once its deadline passes. StartAt anchors the rule and
+// Occurrences counts the tasks made since, for COUNT.
+type Recurrence struct {
+	ID             int       `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int       `json:"-" gorm:"not null;index"`
+	Rule           string    `json:"rule" gorm:"not null"`
+	Mode           string    `json:"mode" gorm:"not null;default:'on_schedule'"`
+	StartAt        time.Time `json:"start_at" gorm:"not null"`
+	LastTaskId     int       `json:"last_task_id" gorm:"not null"`
+	LastDeadline   time.Time `json:"last_deadline" gorm:"not null"`
+	Occurrences    int       `json:"occurrences" gorm:"not null;default:1"`
+	IsActive       bool      `json:"is_active" gorm:"not null;default:true;index"`
+	CreatedAt      time.Time `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"-" gorm:"autoUpdateTime"`
+}
+
+// ChecklistItem is a step of a task. Items are ordered by Rank the same way
+// board columns order their tasks.
+type ChecklistItem struct {
+	ID        int       `json:"id" gorm:"serial;primaryKey"`
+	TaskId    int       `json:"task_id" gorm:"not null;index"`
+	Title     string    `json:"title" gorm:"not null"`
+	IsChecked bool      `json:"is_checked" gorm:"not null;default:false"`
+	Rank      string    `json:"-" gorm:"not null"`
+	CreatedAt time.Time `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"-" gorm:"autoUpdateTime"`
+	Task      Task      `json:"-" gorm:"foreignKey:TaskId"`
+}
+
+type ChecklistItems []ChecklistItem
+
+// ChecklistMove places an item between two others, like TaskMove.
+type ChecklistMove struct {
+	TaskId int
+	ItemId int
+	PrevId int
+	NextId int
+}
+
+// TaskMove places a task in a column between two of its tasks. PrevId is the
+// task that ends up right above, NextId the one right below; either can be
+// zero.
+type TaskMove struct {
+	ProjectId int
+	TaskId    int
+	Status    string
+	PrevId    int
+	NextId    int
+}
+
+type Label struct {
+	ID        int       `json:"id" gorm:"serial;primaryKey"`
+	ProjectId int       `json:"project_id" gorm:"not null;uniqueIndex:idx_label_key"`
+	Name      string    `json:"name" gorm:"not null"`
+	Key       string    `json:"-" gorm:"not null;uniqueIndex:idx_label_key"`
+	Color     string    `json:"color" gorm:"not null"`
+	CreatedAt time.Time `json:"-" gorm:"autoCreateTime"`
+	Project   Project   `json:"-" gorm:"foreignKey:ProjectId"`
+}
+
+type Labels []Label
+
+type TaskLabel struct {
+	TaskId  int   `json:"task_id" gorm:"primaryKey"`
+	LabelId int   `json:"label_id" gorm:"primaryKey;index"`
+	Task    Task  `json:"-" gorm:"foreignKey:TaskId"`
+	Label   Label `json:"-" gorm:"foreignKey:LabelId"`
+}
+
+const (
+	TaskRoleAssignee = "assignee"
+	TaskRoleReviewer = "reviewer"
+	TaskRoleWatcher  = "watcher"
+)
+
+// TaskAssignee links additional users to a task. Watchers are only kept
+// informed, they are not responsible for the task.
+type TaskAssignee struct {
+	ID        int       `json:"id" gorm:"serial;primaryKey"`
+	TaskId    int       `json:"task_id" gorm:"not null;uniqueIndex:idx_task_assignee"`
+	UserId    int       `json:"user_id" gorm:"not null;uniqueIndex:idx_task_assignee;index"`
+	Role      string    `json:"role" gorm:"not null;default:'assignee'"`
+	Firstname string    `json:"firstname,omitempty" gorm:"-"`
+	Lastname  string    `json:"lastname,omitempty" gorm:"-"`
+	CreatedAt time.Time `json:"-" gorm:"autoCreateTime"`
+	Task      Task      `json:"-" gorm:"foreignKey:TaskId"`
+	User      User      `json:"-" gorm:"foreignKey:UserId"`
+}
+
+// JSONMap is stored in jsonb columns.
+type JSONMap map[string]any
+
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+func (m *JSONMap) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*m = JSONMap{}
+		return nil
+	default:
+		return errors.New("unsupported type for JSONMap")
+	}
+
+	return json.Unmarshal(data, m)
+}
+
+// StringList is stored in jsonb columns.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+
+	b, err := json.Marshal(l)
+	return string(b), err
+}
+
+func (l *StringList) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = StringList{}
+		return nil
+	default:
+		return errors.New("unsupported type for StringList")
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+type Organization struct {
+	ID        int       `json:"id" gorm:"serial;primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"not null;unique"`
+	Settings  JSONMap   `json:"settings" gorm:"type:jsonb;not null;default:'{}'"`
+	Role      string    `json:"role,omitempty" gorm:"-"`
+	IsActive  bool      `json:"-" gorm:"not null;default:true"`
+	CreatedAt time.Time `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"-" gorm:"autoUpdateTime"`
+}
+
+type Organizations []Organization
+
+type OrganizationMember struct {
+	ID             int          `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int          `json:"organization_id" gorm:"not null;uniqueIndex:idx_organization_member"`
+	UserId         int          `json:"user_id" gorm:"not null;uniqueIndex:idx_organization_member"`
+	Role           string       `json:"role" gorm:"not null;default:'member'"`
+	Firstname      string       `json:"firstname,omitempty" gorm:"-"`
+	Lastname       string       `json:"lastname,omitempty" gorm:"-"`
+	Email          string       `json:"email,omitempty" gorm:"-"`
+	CreatedAt      time.Time    `json:"-" gorm:"autoCreateTime"`
+	Organization   Organization `json:"-" gorm:"foreignKey:OrganizationId"`
+	User           User         `json:"-" gorm:"foreignKey:UserId"`
+}
+
+type Department struct {
+	ID             int          `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int          `json:"-" gorm:"not null;uniqueIndex:idx_department_key"`
+	Name           string       `json:"name" gorm:"not null"`
+	Key            string       `json:"-" gorm:"not null;uniqueIndex:idx_department_key,where:is_active"`
+	HeadId         *int         `json:"head_id,omitempty"`
+	HeadName       string       `json:"head_name,omitempty" gorm:"-"`
+	IsActive       bool         `json:"-" gorm:"not null;default:true"`
+	CreatedAt      time.Time    `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `json:"-" gorm:"autoUpdateTime"`
+	Organization   Organization `json:"-" gorm:"foreignKey:OrganizationId"`
+	Head           *User        `json:"-" gorm:"foreignKey:HeadId"`
+}
+
+type Departments []Department
+
+type DepartmentMember struct {
+	ID           int        `json:"id" gorm:"serial;primaryKey"`
+	DepartmentId int        `json:"department_id" gorm:"not null;uniqueIndex:idx_department_member"`
+	UserId       int        `json:"user_id" gorm:"not null;uniqueIndex:idx_department_member"`
+	Firstname    string     `json:"firstname,omitempty" gorm:"-"`
+	Lastname     string     `json:"lastname,omitempty" gorm:"-"`
+	CreatedAt    time.Time  `json:"-" gorm:"autoCreateTime"`
+	Department   Department `json:"-" gorm:"foreignKey:DepartmentId"`
+	User         User       `json:"-" gorm:"foreignKey:UserId"`
+}
+
+type DepartmentStats struct {
+	DepartmentId int              `json:"department_id"`
+	Members      int64            `json:"members"`
+	Projects     map[string]int64 `json:"projects"`
+	Tasks        map[string]int64 `json:"tasks"`
+	OverdueTasks int64            `json:"overdue_tasks"`
+}
+
+type Team struct {
+	ID             int          `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int          `json:"-" gorm:"not null;uniqueIndex:idx_team_key"`
+	Name           string       `json:"name" gorm:"not null"`
+	Key            string       `json:"-" gorm:"not null;uniqueIndex:idx_team_key,where:is_active"`
+	Description    string       `json:"description"`
+	IsActive       bool         `json:"-" gorm:"not null;default:true"`
+	CreatedAt      time.Time    `json:"-" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `json:"-" gorm:"autoUpdateTime"`
+	Organization   Organization `json:"-" gorm:"foreignKey:OrganizationId"`
+}
+
+type Teams []Team
+
+type TeamMember struct {
+	ID        int       `json:"id" gorm:"serial;primaryKey"`
+	TeamId    int       `json:"team_id" gorm:"not null;uniqueIndex:idx_team_member"`
+	UserId    int       `json:"user_id" gorm:"not null;uniqueIndex:idx_team_member"`
+	Firstname string    `json:"firstname,omitempty" gorm:"-"`
+	Lastname  string    `json:"lastname,omitempty" gorm:"-"`
+	CreatedAt time.Time `json:"-" gorm:"autoCreateTime"`
+	Team      Team      `json:"-" gorm:"foreignKey:TeamId"`
+	User      User      `json:"-" gorm:"foreignKey:UserId"`
+}
+
+// ProjectTeam makes every member of a team a participant of the project with
+// the given role.
+type ProjectTeam struct {
+	ID        int     `json:"id" gorm:"serial;primaryKey"`
+	ProjectId int     `json:"project_id" gorm:"not null;uniqueIndex:idx_project_team"`
+	TeamId    int     `json:"team_id" gorm:"not null;uniqueIndex:idx_project_team"`
+	TeamName  string  `json:"team_name,omitempty" gorm:"-"`
+	Role      string  `json:"role" gorm:"not null;default:'participant'"`
+	Project   Project `json:"-" gorm:"foreignKey:ProjectId"`
+	Team      Team    `json:"-" gorm:"foreignKey:TeamId"`
+}
+
+type ProjectInvite struct {
+	ID          int        `json:"id" gorm:"serial;primaryKey"`
+	ProjectId   int        `json:"project_id" gorm:"not null;index"`
+	Email       string     `json:"email" gorm:"not null"`
+	Role        string     `json:"role" gorm:"not null;default:'participant'"`
+	InvitedBy   int        `json:"invited_by" gorm:"not null"`
+	Status      string     `json:"status" gorm:"not null;default:'pending'"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	ProjectName string     `json:"-" gorm:"-"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"-" gorm:"autoUpdateTime"`
+	Project     Project    `json:"-" gorm:"foreignKey:ProjectId"`
+	Inviter     User       `json:"-" gorm:"foreignKey:InvitedBy"`
+}
+
+type ProjectInvites []ProjectInvite
+
+// InviteInfo is what the invite link shows before the invite is accepted.
+type InviteInfo struct {
+	Project    string    `json:"project"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	HasAccount bool      `json:"has_account"`
+}
+
+// ProjectTemplate is a reusable project structure. Its dates are offsets in
+// days from the start of the project made from it.
+type ProjectTemplate struct {
+	ID             int             `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int             `json:"-" gorm:"not null;index"`
+	Name           string          `json:"name" gorm:"not null"`
+	Description    string          `json:"description" gorm:"not null"`
+	DurationDays   int             `json:"duration_days" gorm:"not null"`
+	Content        TemplateContent `json:"content" gorm:"type:jsonb;not null"`
+	CreatedBy      int             `json:"created_by" gorm:"not null"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	Organization   Organization    `json:"-" gorm:"foreignKey:OrganizationId"`
+	User           User            `json:"-" gorm:"foreignKey:CreatedBy"`
+}
+
+type ProjectTemplates []ProjectTemplate
+
+// TemplateProject is what creating a project from a template made.
+type TemplateProject struct {
+	ProjectId      int
+	ParticipantIds []int
+	TaskIds        []int
+}
+
+type TemplateContent struct {
+	Columns      []TemplateColumn      `json:"columns"`
+	Fields       []TemplateField       `json:"fields"`
+	Labels       []TemplateLabel       `json:"labels"`
+	Participants []TemplateParticipant `json:"participants"`
+	Teams        []TemplateTeam        `json:"teams"`
+	Milestones   []TemplateMilestone   `json:"milestones"`
+	Tasks        []TemplateTask        `json:"tasks"`
+}
+
+type TemplateColumn struct {
+	Status   string `json:"status"`
+	Position int    `json:"position"`
+	WipLimit int    `json:"wip_limit"`
+}
+
+type TemplateField struct {
+	Name     string     `json:"name"`
+	Key      string     `json:"key"`
+	Type     string     `json:"type"`
+	Options  StringList `json:"options,omitempty"`
+	Required bool       `json:"required"`
+}
+
+type TemplateLabel struct {
+	Name  string `json:"name"`
+	Key   string `json:"key"`
+	Color string `json:"color"`
+}
+
+type TemplateParticipant struct {
+	UserId int    `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type TemplateTeam struct {
+	TeamId int    `json:"team_id"`
+	Role   string `json:"role"`
+}
+
+type TemplateMilestone struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	DueDay      int    `json:"due_day"`
+}
+
+// TemplateTask refers to its labels by key and to its milestone by its
+// index in TemplateContent.Milestones. Tasks are kept in board order.
+type TemplateTask struct {
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	ExecutorId       *int       `json:"executor_id,omitempty"`
+	TeamId           *int       `json:"team_id,omitempty"`
+	Status           string     `json:"status"`
+	Priority         string     `json:"priority"`
+	CustomFields     JSONMap    `json:"custom_fields,omitempty"`
+	OriginalEstimate *int       `json:"original_estimate,omitempty"`
+	DeadlineDay      int        `json:"deadline_day"`
+	Milestone        *int       `json:"milestone,omitempty"`
+	Labels           StringList `json:"labels,omitempty"`
+	Checklist        StringList `json:"checklist,omitempty"`
+}
+
+func (c TemplateContent) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *TemplateContent) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for TemplateContent")
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// ProjectSnapshot is what a template is made of, as read from a project.
+type ProjectSnapshot struct {
+	Project      Project
+	Columns      []BoardColumn
+	Fields       CustomFields
+	Labels       Labels
+	Participants []ProjectParticipant
+	Teams        []ProjectTeam
+	Milestones   Milestones
+	Tasks        Tasks
+	TaskLabels   []TaskLabel
+	Checklist    ChecklistItems
+}
+
+// Identity is who a token acts for and when it stops being valid.
+type Identity struct {
+	UserID         int
+	Role           string
+	ImpersonatorID int
+	ExpiresAt      time.Time
+}
+
+type ImpersonationLog struct {
+	ID             int       `json:"id" gorm:"serial;primaryKey"`
+	ImpersonatorId int       `json:"impersonator_id" gorm:"not null;index"`
+	UserId         int       `json:"user_id" gorm:"not null"`
+	Action         string    `json:"action" gorm:"not null"`
+	Method         string    `json:"method"`
+	Path           string    `json:"path"`
+	StatusCode     int       `json:"status_code"`
+	IP             string    `json:"ip"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Impersonator   User      `json:"-" gorm:"foreignKey:ImpersonatorId"`
+	User           User      `json:"-" gorm:"foreignKey:UserId"`
+}
+
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+)
+
+const (
+	EntityUser        = "user"
+	EntityProject     = "project"
+	EntityTask        = "task"
+	EntityParticipant = "participant"
+	EntityMember      = "member"
+)
+
+// OverdueMark is a task or a project whose overdue mark the deadline
+// scheduler set or cleared.
+type OverdueMark struct {
+	EntityType     string
+	ID             int
+	OrganizationId int
+	IsOverdue      bool
+}
+
+// AuditSource is who makes a change and from where. It is empty for the
+// changes the scheduler makes.
+type AuditSource struct {
+	OrganizationId *int
+	ActorId        *int
+	ImpersonatorId *int
+	IP             string
+	RequestId      string
+}
+
+// AuditEntry records a change to an entity. Changes maps each field that
+// changed to its old and new value, {"from": ..., "to": ...}. ActorId is
+// empty for anonymous requests such as signing up and for the scheduler.
+type AuditEntry struct {
+	ID             int       `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId *int      `json:"organization_id,omitempty" gorm:"index"`
+	ActorId        *int      `json:"actor_id,omitempty" gorm:"index"`
+	ImpersonatorId *int      `json:"impersonator_id,omitempty"`
+	Action         string    `json:"action" gorm:"not null"`
+	EntityType     string    `json:"entity_type" gorm:"not null;index:idx_audit_entity"`
+	EntityId       int       `json:"entity_id" gorm:"not null;index:idx_audit_entity"`
+	Changes        JSONMap   `json:"changes" gorm:"type:jsonb;not null;default:'{}'"`
+	IP             string    `json:"ip"`
+	RequestId      string    `json:"request_id" gorm:"index"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+type AuditEntries []AuditEntry
+
+// AuditPage is a page of the audit log. NextBeforeId is set while there may
+// be older entries.
+type AuditPage struct {
+	Entries      AuditEntries `json:"entries"`
+	NextBeforeId *int         `json:"next_before_id,omitempty"`
+}
+
+// AuditFilter narrows down the audit log. Zero values mean no filtering;
+// BeforeId pages backwards from the newest entries. To is inclusive.
+type AuditFilter struct {
+	OrganizationId int
+	ActorId        int
+	EntityType     string
+	EntityId       int
+	Action         string
+	RequestId      string
+	From           time.Time
+	To             time.Time
+	BeforeId       int
+	Limit          int
+}
+
+const (
+	ActivityTaskCreated        = "task_created"
+	ActivityTaskUpdated        = "task_updated"
+	ActivityTaskStatusChanged  = "task_status_changed"
+	ActivityTaskAssigned       = "task_assigned"
+	ActivityTaskUnassigned     = "task_unassigned"
+	ActivityTaskClaimed        = "task_claimed"
+	ActivityTaskMoved          = "task_moved"
+	ActivityTaskCopied         = "task_copied"
+	ActivityTaskDeleted        = "task_deleted"
+	ActivityTaskRestored       = "task_restored"
+	ActivityTaskCommented      = "task_commented"
+	ActivityProjectCreated     = "project_created"
+	ActivityProjectUpdated     = "project_updated"
+	ActivityProjectDeleted     = "project_deleted"
+	ActivityProjectRestored    = "project_restored"
+	ActivityParticipantAdded   = "participant_added"
+	ActivityParticipantJoined  = "participant_joined"
+	ActivityParticipantRemoved = "participant_removed"
+)
+
+// Activity is an entry of a project's activity feed. Data keeps what the
+// message is made of as it was at the time, like the title of the task and
+// the name of the project. UserId is the user the activity is about, such
+// as the new assignee of a task.
+type Activity struct {
+	ID             int       `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int       `json:"-" gorm:"not null;index"`
+	ProjectId      int       `json:"project_id" gorm:"not null;index"`
+	ActorId        int       `json:"actor_id" gorm:"not null;index"`
+	ActorName      string    `json:"actor_name" gorm:"-"`
+	Verb           string    `json:"verb" gorm:"not null"`
+	TaskId         *int      `json:"task_id,omitempty" gorm:"index"`
+	UserId         *int      `json:"user_id,omitempty" gorm:"index"`
+	UserName       string    `json:"user_name,omitempty" gorm:"-"`
+	Data           JSONMap   `json:"data" gorm:"type:jsonb;not null;default:'{}'"`
+	Message        string    `json:"message" gorm:"-"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Project        Project   `json:"-" gorm:"foreignKey:ProjectId"`
+	Actor          User      `json:"-" gorm:"foreignKey:ActorId"`
+}
+
+type Activities []Activity
+
+// ActivityPage is a page of a feed. NextBeforeId is set while there may be
+// older activities.
+type ActivityPage struct {
+	Activities   Activities `json:"activities"`
+	NextBeforeId *int       `json:"next_before_id,omitempty"`
+}
+
+// ActivityFilter selects a feed: the project's one, or, for a user, the one
+// of every project they take part in together with what happened to them.
+type ActivityFilter struct {
+	OrganizationId int
+	ProjectId      int
+	UserId         int
+	ActorId        int
+	BeforeId       int
+	Limit          int
+}
+
+// TaskComment is a comment on a task. Mentions are the users the comment
+// mentions; they are notified rather than stored.
+type TaskComment struct {
+	ID         int       `json:"id" gorm:"serial;primaryKey"`
+	TaskId     int       `json:"task_id" gorm:"not null;index"`
+	AuthorId   int       `json:"author_id" gorm:"not null"`
+	AuthorName string    `json:"author_name" gorm:"-"`
+	Body       string    `json:"body" gorm:"not null"`
+	Mentions   []int     `json:"mentions,omitempty" gorm:"-"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Task       Task      `json:"-" gorm:"foreignKey:TaskId"`
+	Author     User      `json:"-" gorm:"foreignKey:AuthorId"`
+}
+
+type TaskComments []TaskComment
+
+const (
+	NotificationAssigned      = "task_assigned"
+	NotificationStatusChanged = "task_status_changed"
+	NotificationCommented     = "task_commented"
+	NotificationMentioned     = "mentioned"
+	NotificationDeadline      = "deadline_approaching"
+	NotificationOverdue       = "overdue"
+	NotificationInvited       = "project_invite"
+)
+
+// Notification is an entry of a user's inbox. Data keeps what the message is
+// made of, like the title of the task. A notification with a Key is added
+// at most once per user, however many times it is triggered. EmailStatus
+// tracks whether it still has to be emailed; EmailLeasedUntil keeps other
+// senders off it while one is emailing it.
+type Notification struct {
+	ID               int        `json:"id" gorm:"serial;primaryKey"`
+	UserId           int        `json:"-" gorm:"not null;index;uniqueIndex:idx_notification_key"`
+	Type             string     `json:"type" gorm:"not null"`
+	ActorId          *int       `json:"actor_id,omitempty"`
+	ActorName        string     `json:"actor_name,omitempty" gorm:"-"`
+	TaskId           *int       `json:"task_id,omitempty" gorm:"index"`
+	ProjectId        *int       `json:"project_id,omitempty"`
+	Data             JSONMap    `json:"data" gorm:"type:jsonb;not null;default:'{}'"`
+	Message          string     `json:"message" gorm:"-"`
+	Key              *string    `json:"-" gorm:"uniqueIndex:idx_notification_key"`
+	ReadAt           *time.Time `json:"read_at,omitempty"`
+	EmailStatus      string     `json:"-" gorm:"not null;default:'pending';index"`
+	EmailLeasedUntil *time.Time `json:"-"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	User             User       `json:"-" gorm:"foreignKey:UserId"`
+}
+
+type Notifications []Notification
+
+// NotificationPage is a page of an inbox with the number of its unread
+// notifications. NextBeforeId is set while there may be older ones.
+type NotificationPage struct {
+	Notifications Notifications `json:"notifications"`
+	Unread        int64         `json:"unread"`
+	NextBeforeId  *int          `json:"next_before_id,omitempty"`
+}
+
+const (
+	EmailPending = "pending"
+	EmailSent    = "sent"
+	EmailSkipped = "skipped"
+	EmailExpired = "expired"
+)
+
+const (
+	EmailInstant = "instant"
+	EmailDigest  = "digest"
+	EmailOff     = "off"
+)
+
+// NotificationPreference is how a user wants to be emailed about one type of
+// notification: one email each, in the daily digest, or not at all.
+type NotificationPreference struct {
+	UserId int    `json:"-" gorm:"primaryKey"`
+	Type   string `json:"type" gorm:"primaryKey"`
+	Email  string `json:"email" gorm:"not null"`
+	User   User   `json:"-" gorm:"foreignKey:UserId"`
+}
+
+// PendingEmail is a notification still to be emailed, with its recipient.
+type PendingEmail struct {
+	Notification
+	Email     string
+	Firstname string
+}
+
+type NotificationFilter struct {
+	UserId     int
+	UnreadOnly bool
+	BeforeId   int
+	Limit      int
+}
+
+// Webhook subscribes a URL to the activities of an organization, or of one
+// of its projects, whose verbs are among its Events; with no Events it gets
+// them all. Deliveries are signed with its Secret, shown only when it is
+// created.
+type Webhook struct {
+	ID             int          `json:"id" gorm:"serial;primaryKey"`
+	OrganizationId int          `json:"-" gorm:"not null;index"`
+	ProjectId      *int         `json:"project_id,omitempty" gorm:"index"`
+	URL            string       `json:"url" gorm:"not null"`
+	Secret         string       `json:"secret,omitempty" gorm:"not null"`
+	Events         StringList   `json:"events" gorm:"type:jsonb;not null;default:'[]'"`
+	IsActive       bool         `json:"is_active" gorm:"not null;default:true"`
+	CreatedBy      int          `json:"created_by" gorm:"not null"`
+	CreatedAt      time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+	Organization   Organization `json:"-" gorm:"foreignKey:OrganizationId"`
+	Project        Project      `json:"-" gorm:"foreignKey:ProjectId"`
+}
+
+type Webhooks []Webhook
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event queued for a webhook. A pending delivery is
+// tried at NextAttemptAt until it is delivered or runs out of attempts.
+type WebhookDelivery struct {
+	ID             int        `json:"id" gorm:"serial;primaryKey"`
+	WebhookId      int        `json:"webhook_id" gorm:"not null;index"`
+	Event          string     `json:"event" gorm:"not null"`
+	Payload        JSONMap    `json:"payload" gorm:"type:jsonb;not null;default:'{}'"`
+	Status         string     `json:"status" gorm:"not null;default:'pending'"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"not null;index"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	Webhook        Webhook    `json:"-" gorm:"foreignKey:WebhookId"`
+}
+
+type WebhookDeliveries []WebhookDelivery
+
+// DueDelivery is a delivery to be tried now, with where it goes to.
+type DueDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// WebhookDeliveryPage is a page of the delivery log of a webhook.
+// NextBeforeId is set while there may be older deliveries.
+type WebhookDeliveryPage struct {
+	Deliveries   WebhookDeliveries `json:"deliveries"`
+	NextBeforeId *int              `json:"next_before_id,omitempty"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
@@ -0,0 +1,44 @@
+package broker
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"time"
+)
+
+// Event is a change made in an organization, as it is pushed to the clients
+// streaming it. It holds nothing that can't be sent as JSON, so that it can
+// be passed between instances.
+type Event struct {
+	ID             int            `json:"id"`
+	Type           string         `json:"type"`
+	OrganizationId int            `json:"organization_id"`
+	ProjectId      int            `json:"project_id"`
+	TaskId         *int           `json:"task_id,omitempty"`
+	ActorId        int            `json:"actor_id"`
+	UserId         *int           `json:"user_id,omitempty"`
+	Data           models.JSONMap `json:"data"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// Broker hands the events published in an organization to its subscribers.
+//
+// Memory does so within one instance. To run several, a broker can send
+// what is published through Postgres with NOTIFY and pass what it LISTENs to
+// on to a Memory of its own for the subscribers of its instance.
+type Broker interface {
+	Publish(event Event) error
+	Subscribe(orgId int) *Subscription
+	// Close ends every subscription; the subscribers are expected to stop.
+	Close()
+}
+
+// Subscription receives the events of an organization on C until it is
+// closed, by its subscriber or by the broker.
+type Subscription struct {
+	C     <-chan Event
+	close func()
+}
+
+func (s *Subscription) Close() {
+	s.close()
+}
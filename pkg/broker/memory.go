@@ -0,0 +1,87 @@
+package broker
+
+import "sync"
+
+// subscriberBuffer is how many events a subscriber may fall behind by.
+const subscriberBuffer = 64
+
+// Memory is a Broker for a single instance. Publishing never waits on the
+// subscribers: one that falls subscriberBuffer events behind has its
+// subscription closed, and is expected to load what it missed once it
+// subscribes again.
+type Memory struct {
+	mu          sync.Mutex
+	subscribers map[int]map[*Subscription]chan Event
+	closed      bool
+}
+
+func NewMemory() *Memory {
+	return &Memory{subscribers: make(map[int]map[*Subscription]chan Event)}
+}
+
+func (m *Memory) Publish(event Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for subscription, ch := range m.subscribers[event.OrganizationId] {
+		select {
+		case ch <- event:
+		default:
+			m.remove(event.OrganizationId, subscription)
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) Subscribe(orgId int) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+	subscription := &Subscription{C: ch}
+	subscription.close = func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.remove(orgId, subscription)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		close(ch)
+		return subscription
+	}
+
+	if m.subscribers[orgId] == nil {
+		m.subscribers[orgId] = make(map[*Subscription]chan Event)
+	}
+	m.subscribers[orgId][subscription] = ch
+
+	return subscription
+}
+
+func (m *Memory) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	for orgId, subscriptions := range m.subscribers {
+		for subscription := range subscriptions {
+			m.remove(orgId, subscription)
+		}
+	}
+}
+
+// remove closes the subscription unless it is closed already. It is called
+// with mu held.
+func (m *Memory) remove(orgId int, subscription *Subscription) {
+	ch, ok := m.subscribers[orgId][subscription]
+	if !ok {
+		return
+	}
+
+	close(ch)
+	delete(m.subscribers[orgId], subscription)
+	if len(m.subscribers[orgId]) == 0 {
+		delete(m.subscribers, orgId)
+	}
+}
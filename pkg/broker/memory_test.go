@@ -0,0 +1,84 @@
+package broker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// received drains what the subscription has been sent so far and tells
+// whether it is still open.
+func received(s *Subscription) ([]int, bool) {
+	var ids []int
+	for {
+		select {
+		case event, ok := <-s.C:
+			if !ok {
+				return ids, false
+			}
+			ids = append(ids, event.ID)
+		default:
+			return ids, true
+		}
+	}
+}
+
+func TestMemory(t *testing.T) {
+	t.Run("events go to the subscribers of their organization", func(t *testing.T) {
+		m := NewMemory()
+		first, second, other := m.Subscribe(1), m.Subscribe(1), m.Subscribe(2)
+
+		_ = m.Publish(Event{ID: 10, OrganizationId: 1})
+		_ = m.Publish(Event{ID: 11, OrganizationId: 2})
+		_ = m.Publish(Event{ID: 12, OrganizationId: 1})
+
+		ids, open := received(first)
+		assert.Equal(t, []int{10, 12}, ids)
+		assert.True(t, open)
+
+		ids, _ = received(second)
+		assert.Equal(t, []int{10, 12}, ids)
+
+		ids, _ = received(other)
+		assert.Equal(t, []int{11}, ids)
+	})
+
+	t.Run("closed subscriptions get nothing more", func(t *testing.T) {
+		m := NewMemory()
+		s := m.Subscribe(1)
+
+		s.Close()
+		s.Close()
+		_ = m.Publish(Event{ID: 10, OrganizationId: 1})
+
+		ids, open := received(s)
+		assert.Empty(t, ids)
+		assert.False(t, open)
+	})
+
+	t.Run("slow subscribers are dropped", func(t *testing.T) {
+		m := NewMemory()
+		slow := m.Subscribe(1)
+
+		for i := 0; i <= subscriberBuffer; i++ {
+			_ = m.Publish(Event{ID: i, OrganizationId: 1})
+		}
+
+		ids, open := received(slow)
+		assert.Len(t, ids, subscriberBuffer)
+		assert.False(t, open)
+	})
+
+	t.Run("closing the broker ends every subscription", func(t *testing.T) {
+		m := NewMemory()
+		before := m.Subscribe(1)
+
+		m.Close()
+		after := m.Subscribe(2)
+
+		_, open := received(before)
+		assert.False(t, open)
+
+		_, open = received(after)
+		assert.False(t, open)
+	})
+}
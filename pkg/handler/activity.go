@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"strconv"
+)
+
+// activityFilter reads the paging and the actor to narrow the feed down to.
+func activityFilter(c *gin.Context, orgId int) (models.ActivityFilter, bool) {
+	filter := models.ActivityFilter{OrganizationId: orgId}
+
+	ints := map[string]*int{
+		"actor_id":  &filter.ActorId,
+		"before_id": &filter.BeforeId,
+		"limit":     &filter.Limit,
+	}
+	for name, value := range ints {
+		if v := c.Query(name); v != "" {
+			var err error
+			*value, err = strconv.Atoi(v)
+			if err != nil {
+				c.JSON(400, map[string]any{
+					"error": "invalid type of param",
+				})
+				return models.ActivityFilter{}, false
+			}
+		}
+	}
+
+	return filter, true
+}
+
+func (h *Handler) getProjectActivity(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	filter, ok := activityFilter(c, orgId)
+	if !ok {
+		return
+	}
+	filter.ProjectId = projectId
+
+	page, err := h.Activity.GetProjectActivity(userId, filter)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, page)
+}
+
+func (h *Handler) getUserActivity(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	filter, ok := activityFilter(c, orgId)
+	if !ok {
+		return
+	}
+	filter.UserId = userId
+
+	page, err := h.Activity.GetUserActivity(filter)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, page)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (h *Handler) impersonate(c *gin.Context) {
+	adminId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to impersonate users",
+		})
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	token, err := h.Auth.Impersonate(adminId, userId, c.ClientIP())
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Writer.Header().Set("Authorization", token)
+
+	c.JSON(200, map[string]any{
+		"msg":          "impersonating",
+		"user_id":      userId,
+		"impersonator": adminId,
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// auditContext carries who makes the request and from where to the audit
+// log, where the services record the changes it makes.
+func auditContext(c *gin.Context) context.Context {
+	source := models.AuditSource{IP: c.ClientIP(), RequestId: getRequestId(c)}
+
+	if userId, err := getUserId(c); err == nil {
+		source.ActorId = &userId
+	}
+
+	if impersonatorId := getImpersonatorId(c); impersonatorId != 0 {
+		source.ImpersonatorId = &impersonatorId
+	}
+
+	if orgId, err := getOrganizationId(c); err == nil {
+		source.OrganizationId = &orgId
+	}
+
+	return service.WithAuditSource(c.Request.Context(), source)
+}
+
+func (h *Handler) getAuditLog(c *gin.Context) {
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to see the audit log",
+		})
+		return
+	}
+
+	filter := models.AuditFilter{
+		EntityType: c.Query("entity_type"),
+		Action:     c.Query("action"),
+		RequestId:  c.Query("request_id"),
+	}
+
+	ints := map[string]*int{
+		"organization_id": &filter.OrganizationId,
+		"actor_id":        &filter.ActorId,
+		"entity_id":       &filter.EntityId,
+		"before_id":       &filter.BeforeId,
+		"limit":           &filter.Limit,
+	}
+	for name, value := range ints {
+		if v := c.Query(name); v != "" {
+			*value, err = strconv.Atoi(v)
+			if err != nil {
+				c.JSON(400, map[string]any{
+					"error": "invalid type of param",
+				})
+				return
+			}
+		}
+	}
+
+	dates := map[string]*time.Time{"from": &filter.From, "to": &filter.To}
+	for name, value := range dates {
+		if v := c.Query(name); v != "" {
+			*value, err = time.Parse("2006-01-02", v)
+			if err != nil {
+				c.JSON(400, map[string]any{
+					"error": "invalid date format, expected YYYY-MM-DD",
+				})
+				return
+			}
+		}
+	}
+
+	page, err := h.Audit.GetEntries(filter)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, page)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRequestId(t *testing.T) {
+	generated := regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	testTable := []struct {
+		name   string
+		header string
+		kept   bool
+	}{
+		{
+			name:   "Kept",
+			header: "req-1.A_b",
+			kept:   true,
+		},
+		{
+			name:   "Longest kept",
+			header: strings.Repeat("a", 64),
+			kept:   true,
+		},
+		{
+			name: "Missing",
+		},
+		{
+			name:   "Too long",
+			header: strings.Repeat("a", 65),
+		},
+		{
+			name:   "Spaces",
+			header: "req 1",
+		},
+		{
+			name:   "Markup",
+			header: "<script>",
+		},
+		{
+			name:   "Not ASCII",
+			header: "запрос",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.Use(requestId)
+			r.GET("/", func(c *gin.Context) {
+				c.String(200, getRequestId(c))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			if testCase.header != "" {
+				req.Header.Set("X-Request-Id", testCase.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			id := w.Header().Get("X-Request-Id")
+			assert.Equal(t, id, w.Body.String())
+			if testCase.kept {
+				assert.Equal(t, testCase.header, id)
+			} else {
+				assert.Regexp(t, generated, id)
+			}
+		})
+	}
+}
@@ -51,7 +51,7 @@ func (h *Handler) signUp(c *gin.Context) {
 		return
 	}
 
-	id, err := h.Auth.CreateUser(&user)
+	id, err := h.Auth.CreateUser(auditContext(c), &user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "failed to create user",
@@ -108,3 +108,8 @@ func (h *Handler) signIn(c *gin.Context) {
 		"msg": "signed in",
 	})
 }
+
+func (h *Handler) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.Auth.JWKS())
+}
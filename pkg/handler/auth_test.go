@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
 	mock_service "github.com/sharifsharifzoda/project-management-system/pkg/service/mocks"
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
@@ -36,7 +37,7 @@ func TestHandler_signUp(t *testing.T) {
 			mockBehavior: func(s *mock_service.MockAuthorization, user models.User) {
 				s.EXPECT().ValidateUser(user).Return(nil).AnyTimes()
 				s.EXPECT().IsEmailUsed(user.Email).Return(false).AnyTimes()
-				s.EXPECT().CreateUser(&user).Return(1, nil).AnyTimes()
+				s.EXPECT().CreateUser(gomock.Any(), &user).Return(1, nil).AnyTimes()
 			},
 			expectedStatusCode:   201,
 			expectedResponseBody: `{"id":1}`,
@@ -77,9 +78,7 @@ func TestHandler_signUp(t *testing.T) {
 			auth := mock_service.NewMockAuthorization(c)
 			testCase.mockBehavior(auth, testCase.inputUser)
 
-			//services := &service.Service{Auth: auth}
-			handler := NewHandler(auth, nil, nil, nil)
-			//handler := Handler{services.Auth, services.Todo}
+			handler := NewHandler(&service.Service{Auth: auth})
 
 			gin.SetMode(gin.ReleaseMode)
 			r := gin.New()
@@ -160,7 +159,7 @@ func TestHandler_signIn(t *testing.T) {
 			auth := mock_service.NewMockAuthorization(c)
 			testCase.mockBehavior(auth, testCase.inputUser)
 
-			handler := NewHandler(auth, nil, nil, nil)
+			handler := NewHandler(&service.Service{Auth: auth})
 
 			gin.SetMode(gin.ReleaseMode)
 			r := gin.New()
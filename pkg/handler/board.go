@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type columnIn struct {
+	Status   string `json:"status" binding:"required"`
+	Position int    `json:"position"`
+	WipLimit int    `json:"wip_limit"`
+}
+
+type moveIn struct {
+	TaskId int    `json:"task_id" binding:"required"`
+	Status string `json:"status" binding:"required"`
+	PrevId int    `json:"prev_id"`
+	NextId int    `json:"next_id"`
+}
+
+func (h *Handler) getBoard(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the board of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	board, err := h.Board.GetBoard(orgId, managerId, projectId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"board": board,
+	})
+}
+
+func (h *Handler) createColumn(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the board of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data columnIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Board.CreateColumn(orgId, managerId, models.BoardColumn{
+		ProjectId: projectId,
+		Status:    data.Status,
+		Position:  data.Position,
+		WipLimit:  data.WipLimit,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) updateColumn(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the board of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	columnId, err := strconv.Atoi(c.Param("columnId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data columnIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Board.UpdateColumn(orgId, managerId, models.BoardColumn{
+		ID:        columnId,
+		ProjectId: projectId,
+		Status:    data.Status,
+		Position:  data.Position,
+		WipLimit:  data.WipLimit,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "column updated successfully",
+	})
+}
+
+func (h *Handler) deleteColumn(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the board of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	columnId, err := strconv.Atoi(c.Param("columnId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Board.DeleteColumn(orgId, managerId, projectId, columnId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "column deleted successfully",
+	})
+}
+
+func (h *Handler) moveTask(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to move tasks on the board",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data moveIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Board.MoveTask(auditContext(c), orgId, managerId, models.TaskMove{
+		ProjectId: projectId,
+		TaskId:    data.TaskId,
+		Status:    data.Status,
+		PrevId:    data.PrevId,
+		NextId:    data.NextId,
+	})
+	if errors.Is(err, repository.ErrWipLimit) {
+		c.JSON(http.StatusConflict, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "task moved successfully",
+	})
+}
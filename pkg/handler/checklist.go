@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"strconv"
+	"strings"
+)
+
+type checklistItemIn struct {
+	Title string `json:"title" binding:"required"`
+}
+
+type checklistItemUpdateIn struct {
+	Title     *string `json:"title"`
+	IsChecked *bool   `json:"is_checked"`
+}
+
+type checklistMoveIn struct {
+	PrevId int `json:"prev_id"`
+	NextId int `json:"next_id"`
+}
+
+func (h *Handler) createChecklistItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the checklist of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data checklistItemIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Checklist.CreateItem(auditContext(c), orgId, userId, models.ChecklistItem{
+		TaskId: taskId,
+		Title:  data.Title,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getChecklist(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the checklist of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	items, err := h.Checklist.GetItems(orgId, userId, taskId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if items == nil {
+		c.JSON(200, map[string]any{
+			"message": "there is no any checklist item",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"checklist": items,
+	})
+}
+
+func (h *Handler) updateChecklistItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the checklist of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data checklistItemUpdateIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Checklist.UpdateItem(auditContext(c), orgId, userId, taskId, id, data.Title, data.IsChecked)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "checklist item updated successfully",
+	})
+}
+
+func (h *Handler) deleteChecklistItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the checklist of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	err = h.Checklist.DeleteItem(auditContext(c), orgId, userId, taskId, id)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "checklist item deleted successfully",
+	})
+}
+
+func (h *Handler) moveChecklistItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to change the checklist of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data checklistMoveIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Checklist.MoveItem(auditContext(c), orgId, userId, models.ChecklistMove{
+		TaskId: taskId,
+		ItemId: id,
+		PrevId: data.PrevId,
+		NextId: data.NextId,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "checklist item moved successfully",
+	})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"strconv"
+	"strings"
+)
+
+type commentIn struct {
+	Body     string `json:"body" binding:"required"`
+	Mentions []int  `json:"mentions"`
+}
+
+func (h *Handler) addTaskComment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to comment on a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data commentIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Comment.AddComment(orgId, userId, models.TaskComment{
+		TaskId:   taskId,
+		Body:     data.Body,
+		Mentions: data.Mentions,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getTaskComments(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the comments of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	comments, err := h.Comment.GetComments(orgId, userId, taskId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if len(comments) == 0 {
+		c.JSON(200, map[string]any{
+			"message": "there is no any comment",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"comments": comments,
+	})
+}
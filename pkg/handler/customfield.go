@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"strconv"
+	"strings"
+)
+
+type customFieldIn struct {
+	Name     string   `json:"name" binding:"required"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+func (h *Handler) createCustomField(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to create a custom field",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data customFieldIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.CustomField.CreateCustomField(orgId, managerId, models.CustomField{
+		ProjectId: projectId,
+		Name:      data.Name,
+		Type:      strings.ToLower(data.Type),
+		Options:   data.Options,
+		Required:  data.Required,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getCustomFields(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the custom fields of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	fields, err := h.CustomField.GetCustomFields(orgId, managerId, projectId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if len(fields) == 0 {
+		c.JSON(200, map[string]any{
+			"message": "there is no any custom field",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"fields": fields,
+	})
+}
+
+func (h *Handler) updateCustomField(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to update a custom field",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	fieldId, err := strconv.Atoi(c.Param("fieldId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data customFieldIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.CustomField.UpdateCustomField(orgId, managerId, models.CustomField{
+		ID:        fieldId,
+		ProjectId: projectId,
+		Name:      data.Name,
+		Options:   data.Options,
+		Required:  data.Required,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "custom field updated successfully",
+	})
+}
+
+func (h *Handler) deleteCustomField(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to delete a custom field",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	fieldId, err := strconv.Atoi(c.Param("fieldId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.CustomField.DeleteCustomField(orgId, managerId, projectId, fieldId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "custom field deleted successfully",
+	})
+}
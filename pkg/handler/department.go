@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"net/http"
+	"strconv"
+)
+
+type departmentIn struct {
+	Name   string `json:"name" binding:"required"`
+	HeadId *int   `json:"head_id"`
+}
+
+type departmentMemberIn struct {
+	UserId int `json:"user_id" binding:"required"`
+}
+
+func (h *Handler) createDepartment(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to create a department",
+		})
+		return
+	}
+
+	var data departmentIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Department.CreateDepartment(models.Department{
+		OrganizationId: orgId,
+		Name:           data.Name,
+		HeadId:         data.HeadId,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getDepartments(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	departments, err := h.Department.GetDepartments(orgId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": "failed to get the list of departments",
+		})
+		return
+	}
+
+	if departments == nil {
+		c.JSON(200, map[string]any{
+			"message": "there is no any department",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"departments": departments,
+	})
+}
+
+func (h *Handler) getDepartmentById(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	department, err := h.Department.GetDepartment(orgId, departmentId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "department doesn't exist",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"department": department,
+	})
+}
+
+func (h *Handler) updateDepartment(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to update a department",
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data departmentIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Department.UpdateDepartment(models.Department{
+		ID:             departmentId,
+		OrganizationId: orgId,
+		Name:           data.Name,
+		HeadId:         data.HeadId,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "department updated successfully",
+	})
+}
+
+func (h *Handler) deleteDepartment(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to delete a department",
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Department.DeleteDepartment(orgId, departmentId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "department deleted successfully",
+	})
+}
+
+func (h *Handler) getDepartmentMembers(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	members, err := h.Department.GetMembers(orgId, departmentId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"members": members,
+	})
+}
+
+func (h *Handler) addDepartmentMember(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to add members to a department",
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data departmentMemberIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Department.AddMember(orgId, models.DepartmentMember{
+		DepartmentId: departmentId,
+		UserId:       data.UserId,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"message": "member added successfully",
+	})
+}
+
+func (h *Handler) removeDepartmentMember(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to remove members from a department",
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Department.RemoveMember(orgId, departmentId, userId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "failed to remove the member",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "member removed successfully",
+	})
+}
+
+func (h *Handler) getDepartmentProjects(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	projects, err := h.Department.GetProjects(orgId, departmentId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": "failed to get the list of projects",
+		})
+		return
+	}
+
+	if projects == nil {
+		c.JSON(200, map[string]any{
+			"message": "there is no any project",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"projects": projects,
+	})
+}
+
+func (h *Handler) getDepartmentStats(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	stats, err := h.Department.GetStats(orgId, departmentId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"stats": stats,
+	})
+}
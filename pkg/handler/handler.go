@@ -6,23 +6,62 @@ import (
 )
 
 type Handler struct {
-	Auth    service.Authorization
-	User    service.User
-	Project service.Project
-	Task    service.Task
+	Auth         service.Authorization
+	User         service.User
+	Project      service.Project
+	Task         service.Task
+	Invite       service.Invite
+	Organization service.Organization
+	Department   service.Department
+	Team         service.Team
+	Label        service.Label
+	CustomField  service.CustomField
+	Board        service.Board
+	Sprint       service.Sprint
+	Milestone    service.Milestone
+	Worklog      service.Worklog
+	Recurrence   service.Recurrence
+	Checklist    service.Checklist
+	Template     service.Template
+	Audit        service.Audit
+	Activity     service.Activity
+	Comment      service.Comment
+	Notification service.Notification
+	Webhook      service.Webhook
+	Stream       service.Stream
 }
 
-func NewHandler(auth service.Authorization, user service.User, project service.Project, task service.Task) *Handler {
+func NewHandler(services *service.Service) *Handler {
 	return &Handler{
-		Auth:    auth,
-		User:    user,
-		Project: project,
-		Task:    task,
+		Auth:         services.Auth,
+		User:         services.User,
+		Project:      services.Project,
+		Task:         services.Task,
+		Invite:       services.Invite,
+		Organization: services.Organization,
+		Department:   services.Department,
+		Team:         services.Team,
+		Label:        services.Label,
+		CustomField:  services.CustomField,
+		Board:        services.Board,
+		Sprint:       services.Sprint,
+		Milestone:    services.Milestone,
+		Worklog:      services.Worklog,
+		Recurrence:   services.Recurrence,
+		Checklist:    services.Checklist,
+		Template:     services.Template,
+		Audit:        services.Audit,
+		Activity:     services.Activity,
+		Comment:      services.Comment,
+		Notification: services.Notification,
+		Webhook:      services.Webhook,
+		Stream:       services.Stream,
 	}
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
 	r := gin.Default()
+	r.Use(requestId)
 
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, map[string]any{
@@ -30,6 +69,8 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		})
 	})
 
+	r.GET("/.well-known/jwks.json", h.jwks)
+
 	api := r.Group("/v1")
 	{
 		api.POST("/restore", h.restoreUser)
@@ -40,38 +81,189 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			auth.POST("/sign-in", h.signIn)
 		}
 
+		api.GET("/invites/accept", h.getInvite)
+		api.POST("/invites/accept", h.acceptInvite)
+
 		user := api.Group("/user", h.authMiddleware)
 		{
 			user.GET("/", h.getUser)
-			user.PUT("/", h.updateUser)
-			user.DELETE("/", h.deleteUser)
-			user.GET("/projects", h.getProjects)
-			user.GET("/tasks", h.getTasks)
+			user.PUT("/", h.forbidImpersonation, h.updateUser)
+			user.DELETE("/", h.forbidImpersonation, h.deleteUser)
+			user.GET("/projects", h.organizationMiddleware, h.getProjects)
+			user.GET("/tasks", h.organizationMiddleware, h.getTasks)
+			user.GET("/activity", h.organizationMiddleware, h.getUserActivity)
 			user.POST("/photo", h.setProfilePhoto)
 			user.PUT("/photo", h.changeProfilePhoto)
+			user.GET("/timer", h.getRunningTimer)
+			user.POST("/timer/stop", h.stopTimer)
+			user.GET("/notifications", h.getNotifications)
+			user.GET("/notifications/unread", h.getUnreadCount)
+			user.GET("/notifications/preferences", h.getNotificationPreferences)
+			user.PUT("/notifications/preferences", h.setNotificationPreferences)
+			user.POST("/notifications/read", h.markAllNotificationsRead)
+			user.POST("/notifications/:id/read", h.markNotificationRead)
 		}
 
-		project := api.Group("/project", h.authMiddleware)
+		organization := api.Group("/organizations", h.authMiddleware)
+		{
+			organization.POST("/", h.createOrganization)
+			organization.GET("/", h.getOrganizations)
+
+			member := organization.Group("/:orgId", h.organizationMiddleware)
+			{
+				member.GET("", h.getOrganization)
+				member.PUT("", h.updateOrganization)
+				member.GET("/members", h.getMembers)
+				member.POST("/members", h.addMember)
+				member.PUT("/members/:userId", h.updateMemberRole)
+				member.DELETE("/members/:userId", h.removeMember)
+			}
+		}
+
+		department := api.Group("/department", h.authMiddleware, h.organizationMiddleware)
+		{
+			department.POST("/", h.createDepartment)
+			department.GET("/", h.getDepartments)
+			department.GET("/:id", h.getDepartmentById)
+			department.PUT("/:id", h.updateDepartment)
+			department.DELETE("/:id", h.deleteDepartment)
+			department.GET("/:id/members", h.getDepartmentMembers)
+			department.POST("/:id/members", h.addDepartmentMember)
+			department.DELETE("/:id/members/:userId", h.removeDepartmentMember)
+			department.GET("/:id/projects", h.getDepartmentProjects)
+			department.GET("/:id/stats", h.getDepartmentStats)
+		}
+
+		team := api.Group("/team", h.authMiddleware, h.organizationMiddleware)
+		{
+			team.POST("/", h.createTeam)
+			team.GET("/", h.getTeams)
+			team.GET("/:id", h.getTeamById)
+			team.PUT("/:id", h.updateTeam)
+			team.DELETE("/:id", h.deleteTeam)
+			team.GET("/:id/members", h.getTeamMembers)
+			team.POST("/:id/members", h.addTeamMember)
+			team.DELETE("/:id/members/:userId", h.removeTeamMember)
+			team.GET("/:id/queue", h.getTeamQueue)
+			team.POST("/:id/queue/:taskId/claim", h.claimTeamTask)
+		}
+
+		project := api.Group("/project", h.authMiddleware, h.organizationMiddleware)
 		{
 			project.POST("/", h.createProject)
 			project.GET("/", h.getAllProjects)
 			project.GET("/:id", h.getProjectById)
+			project.GET("/:id/activity", h.getProjectActivity)
 			project.POST("/users", h.addUserToProject)
 			project.PUT("/:id", h.updateProject)
 			project.DELETE("/:id", h.deleteProject)
 			project.GET("/deleted", h.getDeletedProjects)
 			project.POST("/:id/restore", h.restoreProject)
+			project.POST("/:id/invites", h.createInvite)
+			project.GET("/:id/invites", h.getPendingInvites)
+			project.DELETE("/:id/invites/:inviteId", h.revokeInvite)
+			project.POST("/:id/teams", h.addTeamToProject)
+			project.GET("/:id/teams", h.getProjectTeams)
+			project.DELETE("/:id/teams/:teamId", h.removeTeamFromProject)
+			project.POST("/:id/labels", h.createLabel)
+			project.GET("/:id/labels", h.getLabels)
+			project.PUT("/:id/labels/:labelId", h.updateLabel)
+			project.DELETE("/:id/labels/:labelId", h.deleteLabel)
+			project.POST("/:id/fields", h.createCustomField)
+			project.GET("/:id/fields", h.getCustomFields)
+			project.PUT("/:id/fields/:fieldId", h.updateCustomField)
+			project.DELETE("/:id/fields/:fieldId", h.deleteCustomField)
+			project.GET("/:id/board", h.getBoard)
+			project.POST("/:id/board/columns", h.createColumn)
+			project.PUT("/:id/board/columns/:columnId", h.updateColumn)
+			project.DELETE("/:id/board/columns/:columnId", h.deleteColumn)
+			project.POST("/:id/board/move", h.moveTask)
+			project.POST("/:id/sprints", h.createSprint)
+			project.GET("/:id/sprints", h.getSprints)
+			project.GET("/:id/sprints/:sprintId", h.getSprint)
+			project.PUT("/:id/sprints/:sprintId", h.updateSprint)
+			project.DELETE("/:id/sprints/:sprintId", h.deleteSprint)
+			project.POST("/:id/sprints/:sprintId/start", h.startSprint)
+			project.POST("/:id/sprints/:sprintId/complete", h.completeSprint)
+			project.POST("/:id/sprints/:sprintId/tasks", h.addSprintTasks)
+			project.DELETE("/:id/sprints/:sprintId/tasks/:taskId", h.removeSprintTask)
+			project.GET("/:id/backlog", h.getBacklog)
+			project.GET("/:id/velocity", h.getVelocity)
+			project.POST("/:id/milestones", h.createMilestone)
+			project.GET("/:id/milestones", h.getMilestones)
+			project.GET("/:id/milestones/:milestoneId", h.getMilestone)
+			project.PUT("/:id/milestones/:milestoneId", h.updateMilestone)
+			project.DELETE("/:id/milestones/:milestoneId", h.deleteMilestone)
+			project.POST("/:id/milestones/:milestoneId/tasks", h.addMilestoneTasks)
+			project.DELETE("/:id/milestones/:milestoneId/tasks/:taskId", h.removeMilestoneTask)
+			project.POST("/:id/template", h.saveProjectTemplate)
+			project.POST("/:id/clone", h.cloneProject)
 			//project.GET("/:id/users", h.getParticipants)
 		}
 
-		task := api.Group("/task", h.authMiddleware)
+		template := api.Group("/template", h.authMiddleware, h.organizationMiddleware)
+		{
+			template.GET("/", h.getTemplates)
+			template.GET("/:id", h.getTemplateById)
+			template.PUT("/:id", h.updateTemplate)
+			template.DELETE("/:id", h.deleteTemplate)
+			template.POST("/:id/projects", h.createProjectFromTemplate)
+		}
+
+		api.GET("/stream", h.authMiddleware, h.organizationMiddleware, h.stream)
+
+		webhook := api.Group("/webhook", h.authMiddleware, h.organizationMiddleware)
+		{
+			webhook.POST("/", h.createWebhook)
+			webhook.GET("/", h.getWebhooks)
+			webhook.GET("/:id", h.getWebhookById)
+			webhook.PUT("/:id", h.updateWebhook)
+			webhook.DELETE("/:id", h.deleteWebhook)
+			webhook.GET("/:id/deliveries", h.getWebhookDeliveries)
+			webhook.POST("/:id/deliveries/:deliveryId/redeliver", h.redeliverWebhook)
+		}
+
+		admin := api.Group("/admin", h.authMiddleware, h.forbidImpersonation)
+		{
+			admin.POST("/impersonate/:id", h.impersonate)
+			admin.GET("/audit", h.getAuditLog)
+		}
+
+		task := api.Group("/task", h.authMiddleware, h.organizationMiddleware)
 		{
 			task.POST("/", h.createTask)
 			task.GET("/", h.getAllTasks)
+			task.POST("/bulk", h.bulkUpdateTasks)
 			task.GET("/:id", h.getTaskById)
 			task.PUT("/:id", h.updateTask)
 			task.DELETE("/:id", h.deleteTask)
 			task.POST("/:id/restore", h.restoreTask)
+			task.GET("/:id/assignees", h.getTaskAssignees)
+			task.PUT("/:id/assignees", h.setTaskAssignee)
+			task.DELETE("/:id/assignees/:userId", h.removeTaskAssignee)
+			task.PUT("/:id/labels", h.setTaskLabels)
+			task.POST("/:id/move", h.moveTaskToProject)
+			task.POST("/:id/copy", h.copyTaskToProject)
+			task.GET("/:id/transfers", h.getTaskTransfers)
+			task.POST("/:id/worklogs", h.logWork)
+			task.GET("/:id/worklogs", h.getWorklogs)
+			task.DELETE("/:id/worklogs/:worklogId", h.deleteWorklog)
+			task.POST("/:id/timer", h.startTimer)
+			task.GET("/:id/recurrence", h.getRecurrence)
+			task.PUT("/:id/recurrence", h.setRecurrence)
+			task.DELETE("/:id/recurrence", h.stopRecurrence)
+			task.GET("/:id/comments", h.getTaskComments)
+			task.POST("/:id/comments", h.addTaskComment)
+			task.GET("/:id/checklist", h.getChecklist)
+			task.POST("/:id/checklist", h.createChecklistItem)
+			task.PUT("/:id/checklist/:itemId", h.updateChecklistItem)
+			task.DELETE("/:id/checklist/:itemId", h.deleteChecklistItem)
+			task.POST("/:id/checklist/:itemId/move", h.moveChecklistItem)
+		}
+
+		timesheet := api.Group("/timesheet", h.authMiddleware, h.organizationMiddleware)
+		{
+			timesheet.GET("/", h.getTimesheet)
 		}
 	}
 
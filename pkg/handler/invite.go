@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type inviteIn struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role"`
+}
+
+type acceptInviteIn struct {
+	Token     string `json:"token"`
+	FirstName string `json:"firstname"`
+	LastName  string `json:"lastname"`
+	Password  string `json:"password"`
+}
+
+func (h *Handler) createInvite(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to invite users to a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data inviteIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Invite.CreateInvite(orgId, managerId, models.ProjectInvite{
+		ProjectId: projectId,
+		Email:     data.Email,
+		Role:      data.Role,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getPendingInvites(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to get the invites of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	invites, err := h.Invite.GetPendingInvites(orgId, managerId, projectId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if invites == nil {
+		c.JSON(200, map[string]any{
+			"message": "there is no any pending invite",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"invites": invites,
+	})
+}
+
+func (h *Handler) revokeInvite(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to revoke invites",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	inviteId, err := strconv.Atoi(c.Param("inviteId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Invite.RevokeInvite(orgId, managerId, projectId, inviteId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "failed to revoke the invite",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "invite revoked successfully",
+	})
+}
+
+// getInvite is where the emailed link lands: it shows the invite, which is
+// accepted by posting the token to the same address.
+func (h *Handler) getInvite(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(400, map[string]any{
+			"error": "invite token is empty",
+		})
+		return
+	}
+
+	invite, err := h.Invite.GetInvite(token)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, invite)
+}
+
+func (h *Handler) acceptInvite(c *gin.Context) {
+	// Existing users accept by posting the link as is, without a body.
+	var data acceptInviteIn
+	if err := c.ShouldBindJSON(&data); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	if data.Token == "" {
+		data.Token = c.Query("token")
+	}
+
+	if data.Token == "" {
+		c.JSON(400, map[string]any{
+			"error": "invite token is empty",
+		})
+		return
+	}
+
+	participant, err := h.Invite.AcceptInvite(auditContext(c), data.Token, models.User{
+		Firstname: data.FirstName,
+		Lastname:  data.LastName,
+		Password:  data.Password,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "invite accepted",
+		"participant": participant,
+	})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
+	mock_service "github.com/sharifsharifzoda/project-management-system/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getInvite(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockInvite)
+
+	testTable := []struct {
+		name         string
+		query        string
+		status       int
+		response     string
+		mockBehavior mockBehavior
+	}{
+		{
+			name:   "Pending invite",
+			query:  "?token=abc",
+			status: 200,
+			response: `{"project":"Apollo","email":"jane@example.com","role":"participant",` +
+				`"expires_at":"2024-03-04T10:00:00Z","has_account":false}`,
+			mockBehavior: func(s *mock_service.MockInvite) {
+				s.EXPECT().GetInvite("abc").Return(models.InviteInfo{Project: "Apollo", Email: "jane@example.com",
+					Role: "participant", ExpiresAt: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)}, nil)
+			},
+		},
+		{
+			name:     "Used invite",
+			query:    "?token=abc",
+			status:   400,
+			response: `{"error":"invite is no longer valid"}`,
+			mockBehavior: func(s *mock_service.MockInvite) {
+				s.EXPECT().GetInvite("abc").Return(models.InviteInfo{}, errors.New("invite is no longer valid"))
+			},
+		},
+		{
+			name:         "No token",
+			status:       400,
+			response:     `{"error":"invite token is empty"}`,
+			mockBehavior: func(s *mock_service.MockInvite) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			invite := mock_service.NewMockInvite(c)
+			testCase.mockBehavior(invite)
+
+			handler := NewHandler(&service.Service{Invite: invite})
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/invites/accept", handler.getInvite)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/invites/accept"+testCase.query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assert.Equal(t, testCase.response, w.Body.String())
+		})
+	}
+}
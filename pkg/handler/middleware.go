@@ -1,12 +1,38 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// requestIdRegexp is what a request id the client sends may look like, so
+// that it can't forge log lines or inject markup where it is shown.
+var requestIdRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestId tags the request with the X-Request-Id it came with, or a new one
+// if it has none or an invalid one, and sends it back, so the audit log can be
+// matched with client logs.
+func requestId(c *gin.Context) {
+	id := c.GetHeader("X-Request-Id")
+	if !requestIdRegexp.MatchString(id) {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err == nil {
+			id = hex.EncodeToString(b)
+		}
+	}
+
+	c.Set("requestId", id)
+	c.Header("X-Request-Id", id)
+}
+
 func (h *Handler) authMiddleware(c *gin.Context) {
 	header := c.GetHeader("Authorization")
 
@@ -32,7 +58,7 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 		return
 	}
 
-	userId, userRole, err := h.Auth.ParseToken(split[1])
+	identity, err := h.Auth.ParseToken(split[1])
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 			"error": err.Error(),
@@ -40,8 +66,112 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 		return
 	}
 
-	c.Set("userId", userId)
-	c.Set("userRole", userRole)
+	c.Set("userId", identity.UserID)
+	c.Set("userRole", identity.Role)
+	c.Set("tokenExpiresAt", identity.ExpiresAt)
+
+	if identity.ImpersonatorID == 0 {
+		return
+	}
+
+	c.Set("impersonatorId", identity.ImpersonatorID)
+	c.Header("X-Impersonated-By", strconv.Itoa(identity.ImpersonatorID))
+
+	c.Next()
+
+	// Streams are logged when they open, not when they close.
+	if !c.GetBool("impersonationRecorded") {
+		h.recordImpersonation(c, "request")
+	}
+}
+
+// recordImpersonation logs what the impersonated request does.
+func (h *Handler) recordImpersonation(c *gin.Context, action string) {
+	userId, _ := getUserId(c)
+	h.Auth.RecordImpersonation(models.ImpersonationLog{
+		ImpersonatorId: getImpersonatorId(c),
+		UserId:         userId,
+		Action:         action,
+		Method:         c.Request.Method,
+		Path:           c.Request.URL.Path,
+		StatusCode:     c.Writer.Status(),
+		IP:             c.ClientIP(),
+	})
+	c.Set("impersonationRecorded", true)
+}
+
+// forbidImpersonation blocks actions that must only ever be done by the
+// account owner, like changing the password or deleting the account.
+func (h *Handler) forbidImpersonation(c *gin.Context) {
+	if getImpersonatorId(c) != 0 {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "this action is not allowed while impersonating a user",
+		})
+		return
+	}
+}
+
+// organizationMiddleware resolves the organization the request works in, from
+// the :orgId path param or the X-Organization-Id header, and checks that the
+// user belongs to it. Superusers may act in any organization as owners.
+func (h *Handler) organizationMiddleware(c *gin.Context) {
+	value := c.Param("orgId")
+	if value == "" {
+		value = c.GetHeader("X-Organization-Id")
+	}
+
+	if value == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"reason": "empty organization header",
+		})
+		return
+	}
+
+	orgId, err := strconv.Atoi(value)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"reason": "invalid organization header",
+		})
+		return
+	}
+
+	userId, err := getUserId(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgRole := "owner"
+	if strings.ToLower(userRole) == "superuser" {
+		if _, err := h.Organization.GetOrganization(orgId); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"error": "organization doesn't exist",
+			})
+			return
+		}
+	} else {
+		member, err := h.Organization.GetMembership(orgId, userId)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "you are not a member of this organization",
+			})
+			return
+		}
+		orgRole = member.Role
+	}
+
+	c.Set("organizationId", orgId)
+	c.Set("organizationRole", orgRole)
 }
 
 func getUserId(c *gin.Context) (int, error) {
@@ -58,6 +188,50 @@ func getUserId(c *gin.Context) (int, error) {
 	return idInt, nil
 }
 
+func getImpersonatorId(c *gin.Context) int {
+	id, ok := c.Get("impersonatorId")
+	if !ok {
+		return 0
+	}
+
+	idInt, _ := id.(int)
+	return idInt
+}
+
+// getTokenExpiry tells when the request's token expires, or the zero time
+// if it doesn't.
+func getTokenExpiry(c *gin.Context) time.Time {
+	expiresAt, _ := c.Get("tokenExpiresAt")
+	t, _ := expiresAt.(time.Time)
+	return t
+}
+
+func getRequestId(c *gin.Context) string {
+	id, _ := c.Get("requestId")
+	idStr, _ := id.(string)
+	return idStr
+}
+
+func getOrganizationId(c *gin.Context) (int, error) {
+	id, ok := c.Get("organizationId")
+	if !ok {
+		return 0, errors.New("organizationId not found")
+	}
+
+	idInt, ok := id.(int)
+	if !ok {
+		return 0, errors.New("invalid type of organizationId")
+	}
+
+	return idInt, nil
+}
+
+func getOrganizationRole(c *gin.Context) string {
+	role, _ := c.Get("organizationRole")
+	roleStr, _ := role.(string)
+	return roleStr
+}
+
 func GetUserRole(c *gin.Context) (string, error) {
 	role, ok := c.Get("userRole")
 	if !ok {
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
 	mock_service "github.com/sharifsharifzoda/project-management-system/pkg/service/mocks"
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
@@ -21,6 +24,7 @@ func TestHandler_tokenAuthMiddleware(t *testing.T) {
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedImpersonator string
 	}{
 		{
 			name:        "Ok",
@@ -28,11 +32,25 @@ func TestHandler_tokenAuthMiddleware(t *testing.T) {
 			headerValue: "Alif token",
 			token:       "token",
 			mockBehavior: func(s *mock_service.MockAuthorization, token string) {
-				s.EXPECT().ParseToken(token).Return(1, "user", nil).AnyTimes()
+				s.EXPECT().ParseToken(token).Return(models.Identity{UserID: 1, Role: "user"}, nil).AnyTimes()
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: "1",
 		},
+		{
+			name:        "Impersonated",
+			headerName:  "Authorization",
+			headerValue: "Alif token",
+			token:       "token",
+			mockBehavior: func(s *mock_service.MockAuthorization, token string) {
+				s.EXPECT().ParseToken(token).
+					Return(models.Identity{UserID: 2, Role: "user", ImpersonatorID: 1}, nil).AnyTimes()
+				s.EXPECT().RecordImpersonation(gomock.Any()).Times(1)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "2",
+			expectedImpersonator: "1",
+		},
 		{
 			name:                 "Empty header",
 			headerName:           "Authorization",
@@ -61,7 +79,7 @@ func TestHandler_tokenAuthMiddleware(t *testing.T) {
 			auth := mock_service.NewMockAuthorization(c)
 			testCase.mockBehavior(auth, testCase.token)
 
-			handler := NewHandler(auth, nil, nil, nil)
+			handler := NewHandler(&service.Service{Auth: auth})
 
 			gin.SetMode(gin.ReleaseMode)
 			r := gin.New()
@@ -76,6 +94,90 @@ func TestHandler_tokenAuthMiddleware(t *testing.T) {
 
 			r.ServeHTTP(w, req)
 
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+			assert.Equal(t, w.Header().Get("X-Impersonated-By"), testCase.expectedImpersonator)
+		})
+	}
+}
+
+func TestHandler_organizationMiddleware(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockOrganization)
+
+	testTable := []struct {
+		name                 string
+		headerValue          string
+		userRole             string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Member",
+			headerValue: "3",
+			userRole:    "user",
+			mockBehavior: func(s *mock_service.MockOrganization) {
+				s.EXPECT().GetMembership(3, 1).Return(models.OrganizationMember{Role: "admin"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "3 admin",
+		},
+		{
+			name:        "Not a member",
+			headerValue: "3",
+			userRole:    "user",
+			mockBehavior: func(s *mock_service.MockOrganization) {
+				s.EXPECT().GetMembership(3, 1).Return(models.OrganizationMember{}, errors.New("record not found"))
+			},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you are not a member of this organization"}`,
+		},
+		{
+			name:        "Superuser",
+			headerValue: "3",
+			userRole:    "superuser",
+			mockBehavior: func(s *mock_service.MockOrganization) {
+				s.EXPECT().GetOrganization(3).Return(models.Organization{ID: 3}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "3 owner",
+		},
+		{
+			name:                 "Empty header",
+			headerValue:          "",
+			userRole:             "user",
+			mockBehavior:         func(s *mock_service.MockOrganization) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"reason":"empty organization header"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			org := mock_service.NewMockOrganization(c)
+			testCase.mockBehavior(org)
+
+			handler := NewHandler(&service.Service{Organization: org})
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/org", func(c *gin.Context) {
+				c.Set("userId", 1)
+				c.Set("userRole", testCase.userRole)
+			}, handler.organizationMiddleware, func(c *gin.Context) {
+				id, _ := getOrganizationId(c)
+				c.String(200, fmt.Sprintf("%d %s", id, getOrganizationRole(c)))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/org", nil)
+			req.Header.Set("X-Organization-Id", testCase.headerValue)
+
+			r.ServeHTTP(w, req)
+
 			assert.Equal(t, w.Code, testCase.expectedStatusCode)
 			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
 		})
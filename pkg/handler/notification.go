@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"strconv"
+)
+
+func (h *Handler) getNotifications(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	filter := models.NotificationFilter{UserId: userId, UnreadOnly: c.Query("unread") == "true"}
+
+	ints := map[string]*int{
+		"before_id": &filter.BeforeId,
+		"limit":     &filter.Limit,
+	}
+	for name, value := range ints {
+		if v := c.Query(name); v != "" {
+			*value, err = strconv.Atoi(v)
+			if err != nil {
+				c.JSON(400, map[string]any{
+					"error": "invalid type of param",
+				})
+				return
+			}
+		}
+	}
+
+	page, err := h.Notification.GetNotifications(filter)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, page)
+}
+
+func (h *Handler) getUnreadCount(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	count, err := h.Notification.CountUnread(userId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"unread": count,
+	})
+}
+
+func (h *Handler) markNotificationRead(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Notification.MarkRead(userId, id); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "notification marked as read successfully",
+	})
+}
+
+func (h *Handler) markAllNotificationsRead(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	count, err := h.Notification.MarkAllRead(userId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"marked": count,
+	})
+}
+
+type notificationPreferencesIn struct {
+	Email map[string]string `json:"email" binding:"required"`
+}
+
+func (h *Handler) getNotificationPreferences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	preferences, err := h.Notification.GetPreferences(userId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"preferences": preferences,
+	})
+}
+
+func (h *Handler) setNotificationPreferences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var data notificationPreferencesIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	if err := h.Notification.SetPreferences(userId, data.Email); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "preferences saved successfully",
+	})
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"net/http"
+	"strconv"
+)
+
+type organizationIn struct {
+	Name     string         `json:"name" binding:"required"`
+	Slug     string         `json:"slug"`
+	Settings models.JSONMap `json:"settings"`
+}
+
+type memberIn struct {
+	UserId int    `json:"user_id" binding:"required"`
+	Role   string `json:"role"`
+}
+
+type memberRoleIn struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func isOrganizationAdmin(c *gin.Context) bool {
+	role := getOrganizationRole(c)
+	return role == "owner" || role == "admin"
+}
+
+func (h *Handler) createOrganization(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var data organizationIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Organization.CreateOrganization(userId, models.Organization{
+		Name:     data.Name,
+		Slug:     data.Slug,
+		Settings: data.Settings,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getOrganizations(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgs, err := h.Organization.GetOrganizations(userId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": "failed to get the list of organizations",
+		})
+		return
+	}
+
+	if orgs == nil {
+		c.JSON(200, map[string]any{
+			"message": "you are not a member of any organization",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"organizations": orgs,
+	})
+}
+
+func (h *Handler) getOrganization(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	org, err := h.Organization.GetOrganization(orgId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "organization doesn't exist",
+		})
+		return
+	}
+	org.Role = getOrganizationRole(c)
+
+	c.JSON(200, map[string]any{
+		"organization": org,
+	})
+}
+
+func (h *Handler) updateOrganization(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to update the organization",
+		})
+		return
+	}
+
+	var data organizationIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Organization.UpdateOrganization(models.Organization{
+		ID:       orgId,
+		Name:     data.Name,
+		Settings: data.Settings,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "failed to update the organization",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "organization updated successfully",
+	})
+}
+
+func (h *Handler) getMembers(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	members, err := h.Organization.GetMembers(orgId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": "failed to get the list of members",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"members": members,
+	})
+}
+
+func (h *Handler) addMember(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to add members",
+		})
+		return
+	}
+
+	var data memberIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Organization.AddMember(auditContext(c), getOrganizationRole(c), models.OrganizationMember{
+		OrganizationId: orgId,
+		UserId:         data.UserId,
+		Role:           data.Role,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"message": "member added successfully",
+	})
+}
+
+func (h *Handler) updateMemberRole(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to change roles",
+		})
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data memberRoleIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Organization.UpdateMemberRole(auditContext(c), getOrganizationRole(c), orgId, userId, data.Role)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "role updated successfully",
+	})
+}
+
+func (h *Handler) removeMember(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to remove members",
+		})
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Organization.RemoveMember(auditContext(c), getOrganizationRole(c), orgId, userId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "member removed successfully",
+	})
+}
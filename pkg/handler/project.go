@@ -8,12 +8,12 @@ import (
 )
 
 type dataIn struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	Department  string `json:"department" binding:"required"`
-	Status      string `json:"status"`
-	StartDate   string `json:"start_date,omitempty"`
-	Deadline    string `json:"deadline" binding:"required"`
+	Name         string `json:"name" binding:"required"`
+	Description  string `json:"description" binding:"required"`
+	DepartmentId int    `json:"department_id" binding:"required"`
+	Status       string `json:"status"`
+	StartDate    string `json:"start_date,omitempty"`
+	Deadline     string `json:"deadline" binding:"required"`
 }
 
 func (h *Handler) createProject(c *gin.Context) {
@@ -25,6 +25,14 @@ func (h *Handler) createProject(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -49,16 +57,17 @@ func (h *Handler) createProject(c *gin.Context) {
 	}
 
 	var project = models.Project{
-		Name:        data.Name,
-		Description: data.Description,
-		Department:  data.Department,
-		ManagerID:   userId,
-		Status:      data.Status,
-		StartDate:   data.StartDate,
-		Deadline:    data.Deadline,
+		OrganizationId: orgId,
+		Name:           data.Name,
+		Description:    data.Description,
+		DepartmentId:   &data.DepartmentId,
+		ManagerID:      userId,
+		Status:         data.Status,
+		StartDate:      data.StartDate,
+		Deadline:       data.Deadline,
 	}
 
-	id, err := h.Project.CreateProject(project)
+	id, err := h.Project.CreateProject(auditContext(c), project)
 	if err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to create a new project",
@@ -80,6 +89,14 @@ func (h *Handler) getAllProjects(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -95,7 +112,7 @@ func (h *Handler) getAllProjects(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.Project.GetAllProjects(userId)
+	projects, err := h.Project.GetAllProjects(orgId, userId)
 	if err != nil {
 		c.JSON(400, map[string]any{
 			"error": "failed to get the list of projects",
@@ -124,6 +141,14 @@ func (h *Handler) getProjectById(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -147,7 +172,7 @@ func (h *Handler) getProjectById(c *gin.Context) {
 		return
 	}
 
-	project, err := h.Project.GetProjectById(userId, projectId)
+	project, err := h.Project.GetProjectById(orgId, userId, projectId)
 	if err != nil {
 		c.JSON(400, map[string]any{
 			"error": "project doesn't exist",
@@ -169,6 +194,14 @@ func (h *Handler) updateProject(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -201,18 +234,19 @@ func (h *Handler) updateProject(c *gin.Context) {
 	}
 
 	var project = models.Project{
-		ID:          projectId,
-		Name:        pro.Name,
-		Description: pro.Description,
-		Department:  pro.Department,
-		ManagerID:   userId,
-		Status:      pro.Status,
-		StartDate:   pro.StartDate,
-		Deadline:    pro.Deadline,
-		IsActive:    true,
-	}
-
-	if err := h.Project.UpdateProject(project); err != nil {
+		ID:             projectId,
+		OrganizationId: orgId,
+		Name:           pro.Name,
+		Description:    pro.Description,
+		DepartmentId:   &pro.DepartmentId,
+		ManagerID:      userId,
+		Status:         pro.Status,
+		StartDate:      pro.StartDate,
+		Deadline:       pro.Deadline,
+		IsActive:       true,
+	}
+
+	if err := h.Project.UpdateProject(auditContext(c), project); err != nil {
 		c.JSON(400, map[string]any{
 			"error": "failed to update the project",
 		})
@@ -233,6 +267,14 @@ func (h *Handler) deleteProject(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -256,7 +298,7 @@ func (h *Handler) deleteProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.Project.DeleteProject(userId, projectId); err != nil {
+	if err := h.Project.DeleteProject(auditContext(c), orgId, userId, projectId); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to delete the project",
 		})
@@ -277,6 +319,14 @@ func (h *Handler) getDeletedProjects(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -292,7 +342,7 @@ func (h *Handler) getDeletedProjects(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.Project.GetDeletedProjects(userId)
+	projects, err := h.Project.GetDeletedProjects(orgId, userId)
 	if err != nil {
 		c.JSON(400, map[string]any{
 			"error": "failed to get the list of deleted projects",
@@ -321,6 +371,14 @@ func (h *Handler) restoreProject(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -344,7 +402,7 @@ func (h *Handler) restoreProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.Project.Restore(userId, projectId); err != nil {
+	if err := h.Project.Restore(auditContext(c), orgId, userId, projectId); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to restore the project",
 		})
@@ -365,6 +423,14 @@ func (h *Handler) addUserToProject(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -388,7 +454,8 @@ func (h *Handler) addUserToProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.Project.AddUserToProject(managerId, propar); err != nil {
+	id, err := h.Project.AddUserToProject(auditContext(c), orgId, managerId, propar)
+	if err != nil {
 		c.JSON(400, map[string]any{
 			"error": "failed to add a new participant to the project",
 		})
@@ -397,5 +464,6 @@ func (h *Handler) addUserToProject(c *gin.Context) {
 
 	c.JSON(200, map[string]any{
 		"message": "added a new participant to the project",
+		"id":      id,
 	})
 }
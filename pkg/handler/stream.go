@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamHeartbeat is how often an idle stream gets a comment, so that
+// proxies don't close it.
+const streamHeartbeat = 25 * time.Second
+
+// streamMembershipCheck is how often a stream checks that the user is still
+// in the organization.
+const streamMembershipCheck = time.Minute
+
+// writeEvent writes the event in the server-sent events format, named after
+// its type and with the id of its activity.
+func writeEvent(w io.Writer, event broker.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+// stream pushes the changes of the organization the user may see as
+// server-sent events until the client goes away. Like any other request it
+// has to send the Authorization and the X-Organization-Id headers, which the
+// browser's EventSource can't do, so web clients need an SSE client built on
+// fetch. The stream ends when the token expires or the user leaves the
+// organization, so the client has to reconnect with a fresh token.
+func (h *Handler) stream(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// The stream is kept open past the server's write timeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	if getImpersonatorId(c) != 0 {
+		h.recordImpersonation(c, "stream")
+	}
+
+	events := h.Stream.Subscribe(c.Request.Context(), orgId, userId)
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	membership := time.NewTicker(streamMembershipCheck)
+	defer membership.Stop()
+
+	var expired <-chan time.Time
+	if expiresAt := getTokenExpiry(c); !expiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			return writeEvent(w, event) == nil
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case <-membership.C:
+			return h.inOrganization(orgId, userId, userRole)
+		case <-expired:
+			return false
+		}
+	})
+}
+
+// inOrganization tells whether the user may still work in the organization,
+// as organizationMiddleware checks it.
+func (h *Handler) inOrganization(orgId, userId int, userRole string) bool {
+	if strings.ToLower(userRole) == "superuser" {
+		_, err := h.Organization.GetOrganization(orgId)
+		return err == nil
+	}
+
+	_, err := h.Organization.GetMembership(orgId, userId)
+	return err == nil
+}
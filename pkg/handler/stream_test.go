@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
+	mock_service "github.com/sharifsharifzoda/project-management-system/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_stream(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	taskId := 9
+	events := make(chan broker.Event, 1)
+	events <- broker.Event{ID: 12, Type: models.ActivityTaskCreated, OrganizationId: 3, ProjectId: 4, TaskId: &taskId,
+		ActorId: 1, Data: models.JSONMap{"title": "Deploy API"}, CreatedAt: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)}
+	close(events)
+
+	stream := mock_service.NewMockStream(c)
+	stream.EXPECT().Subscribe(gomock.Any(), 3, 1).Return((<-chan broker.Event)(events))
+
+	handler := NewHandler(&service.Service{Stream: stream})
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/stream", func(c *gin.Context) {
+		c.Set("userId", 1)
+		c.Set("userRole", "user")
+		c.Set("organizationId", 3)
+	}, handler.stream)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "id: 12\nevent: task_created\n"+
+		`data: {"id":12,"type":"task_created","organization_id":3,"project_id":4,"task_id":9,"actor_id":1,`+
+		`"data":{"title":"Deploy API"},"created_at":"2024-03-04T10:00:00Z"}`+"\n\n", string(body))
+}
+
+func TestHandler_stream_TokenExpires(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	// The events never end, so only the token's expiry closes the stream.
+	events := make(chan broker.Event)
+
+	stream := mock_service.NewMockStream(c)
+	stream.EXPECT().Subscribe(gomock.Any(), 3, 2).Return((<-chan broker.Event)(events))
+
+	auth := mock_service.NewMockAuthorization(c)
+	auth.EXPECT().RecordImpersonation(models.ImpersonationLog{ImpersonatorId: 1, UserId: 2, Action: "stream",
+		Method: "GET", Path: "/stream", StatusCode: 200, IP: "127.0.0.1"})
+
+	handler := NewHandler(&service.Service{Stream: stream, Auth: auth})
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/stream", func(c *gin.Context) {
+		c.Set("userId", 2)
+		c.Set("impersonatorId", 1)
+		c.Set("userRole", "user")
+		c.Set("organizationId", 3)
+		c.Set("tokenExpiresAt", time.Now().Add(100*time.Millisecond))
+	}, handler.stream)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Empty(t, body)
+}
@@ -1,19 +1,87 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
 	"strconv"
 	"strings"
 )
 
 type taskIn struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	ExecutorId  int    `json:"executor_id" binding:"required"`
-	Status      string `json:"status"`
-	ProjectId   int    `json:"project_id" binding:"required"`
-	Deadline    string `json:"deadline" binding:"required"`
+	Title             string         `json:"title" binding:"required"`
+	Description       string         `json:"description" binding:"required"`
+	ExecutorId        *int           `json:"executor_id"`
+	TeamId            *int           `json:"team_id"`
+	Status            string         `json:"status"`
+	Priority          string         `json:"priority"`
+	ProjectId         int            `json:"project_id" binding:"required"`
+	Deadline          string         `json:"deadline" binding:"required"`
+	CustomFields      models.JSONMap `json:"custom_fields"`
+	OriginalEstimate  *int           `json:"original_estimate"`
+	RemainingEstimate *int           `json:"remaining_estimate"`
+}
+
+type taskLabelsIn struct {
+	LabelIds []int `json:"label_ids"`
+}
+
+type assigneeIn struct {
+	UserId int    `json:"user_id" binding:"required"`
+	Role   string `json:"role"`
+}
+
+// bulkTaskIn leaves out what shouldn't change; label_ids, even empty,
+// replaces the labels of every task. Tasks moved to another project carry
+// their checklists and comments along unless they're turned off.
+type bulkTaskIn struct {
+	TaskIds    []int  `json:"task_ids" binding:"required"`
+	Status     string `json:"status"`
+	ExecutorId *int   `json:"executor_id"`
+	TeamId     *int   `json:"team_id"`
+	ProjectId  int    `json:"project_id"`
+	LabelIds   *[]int `json:"label_ids"`
+	Delete     bool   `json:"delete"`
+	DryRun     bool   `json:"dry_run"`
+	Checklist  *bool  `json:"checklist"`
+	Comments   *bool  `json:"comments"`
+}
+
+// taskTransferIn carries the checklist and the comments along unless they're
+// turned off.
+type taskTransferIn struct {
+	ProjectId int   `json:"project_id" binding:"required"`
+	Checklist *bool `json:"checklist"`
+	Comments  *bool `json:"comments"`
+}
+
+func (t taskTransferIn) options() models.TransferOptions {
+	return transferOptions(t.Checklist, t.Comments)
+}
+
+// transferOptions takes everything along that isn't turned off.
+func transferOptions(checklist, comments *bool) models.TransferOptions {
+	return models.TransferOptions{
+		Checklist: checklist == nil || *checklist,
+		Comments:  comments == nil || *comments,
+	}
+}
+
+// taskError answers 400 with what is wrong with the task the client sent,
+// or 500 with the message when reading or saving it failed.
+func taskError(c *gin.Context, err error, message string) {
+	if errors.Is(err, service.ErrInvalidTask) || errors.Is(err, repository.ErrWipLimit) {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(500, map[string]any{
+		"error": message,
+	})
 }
 
 func (h *Handler) createTask(c *gin.Context) {
@@ -25,6 +93,14 @@ func (h *Handler) createTask(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -49,20 +125,24 @@ func (h *Handler) createTask(c *gin.Context) {
 	}
 
 	var task = models.Task{
-		Title:        data.Title,
-		Description:  data.Description,
-		ControllerId: userId,
-		ExecutorId:   data.ExecutorId,
-		Status:       data.Status,
-		ProjectId:    data.ProjectId,
-		Deadline:     data.Deadline,
-	}
-
-	id, err := h.Task.CreateTask(task)
+		OrganizationId:    orgId,
+		Title:             data.Title,
+		Description:       data.Description,
+		ControllerId:      userId,
+		ExecutorId:        data.ExecutorId,
+		TeamId:            data.TeamId,
+		Status:            data.Status,
+		Priority:          strings.ToLower(data.Priority),
+		ProjectId:         data.ProjectId,
+		Deadline:          data.Deadline,
+		CustomFields:      data.CustomFields,
+		OriginalEstimate:  data.OriginalEstimate,
+		RemainingEstimate: data.RemainingEstimate,
+	}
+
+	id, err := h.Task.CreateTask(auditContext(c), task)
 	if err != nil {
-		c.JSON(500, map[string]any{
-			"error": "failed to create anew task",
-		})
+		taskError(c, err, "failed to create anew task")
 		return
 	}
 
@@ -80,6 +160,14 @@ func (h *Handler) getAllTasks(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -95,11 +183,57 @@ func (h *Handler) getAllTasks(c *gin.Context) {
 		return
 	}
 
-	tasks, err := h.Task.GetAllTasks(userId)
+	filter := models.TaskFilter{
+		Priority: strings.ToLower(c.Query("priority")),
+		Overdue:  c.Query("overdue") == "true",
+	}
+
+	if label := c.Query("label"); label != "" {
+		filter.LabelId, err = strconv.Atoi(label)
+		if err != nil {
+			c.JSON(400, map[string]any{
+				"error": "invalid type of query param",
+			})
+			return
+		}
+	}
+
+	if project := c.Query("project_id"); project != "" {
+		filter.ProjectId, err = strconv.Atoi(project)
+		if err != nil {
+			c.JSON(400, map[string]any{
+				"error": "invalid type of query param",
+			})
+			return
+		}
+	}
+
+	// Custom fields are filtered with cf.<key>=value and sorted with
+	// sort=cf.<key>, or sort=-cf.<key> for the descending order.
+	for param, values := range c.Request.URL.Query() {
+		if key, ok := strings.CutPrefix(param, "cf."); ok && len(values) > 0 {
+			if filter.Fields == nil {
+				filter.Fields = map[string]string{}
+			}
+			filter.Fields[key] = values[0]
+		}
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		sort, filter.SortDesc = strings.CutPrefix(sort, "-")
+		key, ok := strings.CutPrefix(sort, "cf.")
+		if !ok {
+			c.JSON(400, map[string]any{
+				"error": "tasks can be sorted only by custom fields",
+			})
+			return
+		}
+		filter.SortField = key
+	}
+
+	tasks, err := h.Task.GetAllTasks(orgId, userId, filter)
 	if err != nil {
-		c.JSON(500, map[string]any{
-			"error": "failed to get the list of tasks",
-		})
+		taskError(c, err, "failed to get the list of tasks")
 		return
 	}
 
@@ -117,6 +251,14 @@ func (h *Handler) getTaskById(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -140,7 +282,7 @@ func (h *Handler) getTaskById(c *gin.Context) {
 		return
 	}
 
-	task, err := h.Task.GetTaskById(userId, taskId)
+	task, err := h.Task.GetTaskById(orgId, userId, taskId)
 	if err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to get the task",
@@ -162,6 +304,14 @@ func (h *Handler) updateTask(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -194,21 +344,25 @@ func (h *Handler) updateTask(c *gin.Context) {
 	}
 
 	var task = models.Task{
-		ID:           taskId,
-		Title:        in.Title,
-		Description:  in.Description,
-		ControllerId: userId,
-		ExecutorId:   in.ExecutorId,
-		Status:       in.Status,
-		ProjectId:    in.ProjectId,
-		Deadline:     in.Deadline,
-		IsActive:     true,
-	}
-
-	if err := h.Task.UpdateTask(task); err != nil {
-		c.JSON(500, map[string]any{
-			"error": "failed to update the task",
-		})
+		ID:                taskId,
+		OrganizationId:    orgId,
+		Title:             in.Title,
+		Description:       in.Description,
+		ControllerId:      userId,
+		ExecutorId:        in.ExecutorId,
+		TeamId:            in.TeamId,
+		Status:            in.Status,
+		Priority:          strings.ToLower(in.Priority),
+		ProjectId:         in.ProjectId,
+		Deadline:          in.Deadline,
+		CustomFields:      in.CustomFields,
+		OriginalEstimate:  in.OriginalEstimate,
+		RemainingEstimate: in.RemainingEstimate,
+		IsActive:          true,
+	}
+
+	if err := h.Task.UpdateTask(auditContext(c), task); err != nil {
+		taskError(c, err, "failed to update the task")
 		return
 	}
 
@@ -226,6 +380,14 @@ func (h *Handler) deleteTask(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -249,7 +411,7 @@ func (h *Handler) deleteTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.Task.DeleteTask(userId, taskId); err != nil {
+	if err := h.Task.DeleteTask(auditContext(c), orgId, userId, taskId); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to delete the task",
 		})
@@ -270,6 +432,14 @@ func (h *Handler) restoreTask(c *gin.Context) {
 		return
 	}
 
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	userRole, err := GetUserRole(c)
 	if err != nil {
 		c.JSON(500, map[string]any{
@@ -293,7 +463,7 @@ func (h *Handler) restoreTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.Task.RestoreTask(userId, taskId); err != nil {
+	if err := h.Task.RestoreTask(auditContext(c), orgId, userId, taskId); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to restore the task",
 		})
@@ -304,3 +474,496 @@ func (h *Handler) restoreTask(c *gin.Context) {
 		"message": "task restored successfully",
 	})
 }
+
+func (h *Handler) setTaskAssignee(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to assign users to a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data assigneeIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Task.SetAssignee(auditContext(c), orgId, userId, models.TaskAssignee{
+		TaskId: taskId,
+		UserId: data.UserId,
+		Role:   strings.ToLower(data.Role),
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "assignee saved successfully",
+	})
+}
+
+func (h *Handler) getTaskAssignees(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the assignees of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	assignees, err := h.Task.GetAssignees(orgId, userId, taskId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"assignees": assignees,
+	})
+}
+
+func (h *Handler) removeTaskAssignee(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to remove assignees from a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	assigneeId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Task.RemoveAssignee(auditContext(c), orgId, userId, taskId, assigneeId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "failed to remove the assignee",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "assignee removed successfully",
+	})
+}
+
+func (h *Handler) setTaskLabels(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to label a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data taskLabelsIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	if err := h.Task.SetLabels(auditContext(c), orgId, userId, taskId, data.LabelIds); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "labels updated successfully",
+	})
+}
+
+func (h *Handler) bulkUpdateTasks(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to update tasks",
+		})
+		return
+	}
+
+	var data bulkTaskIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	bulk := models.BulkTaskUpdate{
+		TaskIds:    data.TaskIds,
+		Status:     data.Status,
+		ExecutorId: data.ExecutorId,
+		TeamId:     data.TeamId,
+		ProjectId:  data.ProjectId,
+		Delete:     data.Delete,
+		DryRun:     data.DryRun,
+		Transfer:   transferOptions(data.Checklist, data.Comments),
+	}
+	if data.LabelIds != nil {
+		bulk.SetLabels = true
+		bulk.LabelIds = *data.LabelIds
+	}
+
+	results, err := h.Task.BulkUpdate(auditContext(c), orgId, userId, bulk)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !results.Applied && !results.DryRun {
+		c.JSON(400, map[string]any{
+			"error":   "some of the tasks can't be updated, nothing was changed",
+			"results": results,
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"results": results,
+	})
+}
+
+func (h *Handler) moveTaskToProject(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to move a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data taskTransferIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	if err := h.Task.MoveTask(auditContext(c), orgId, userId, taskId, data.ProjectId, data.options()); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "task moved successfully",
+	})
+}
+
+func (h *Handler) copyTaskToProject(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to copy a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data taskTransferIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Task.CopyTask(auditContext(c), orgId, userId, taskId, data.ProjectId, data.options())
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getTaskTransfers(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the transfers of a task",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	transfers, err := h.Task.GetTransfers(orgId, userId, taskId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if len(transfers) == 0 {
+		c.JSON(200, map[string]any{
+			"message": "there is no any transfer",
+		})
+		return
+	}
+
+	c.JSON(200, transfers)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/pkg/service"
+	mock_service "github.com/sharifsharifzoda/project-management-system/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_createTask(t *testing.T) {
+	testTable := []struct {
+		name     string
+		err      error
+		status   int
+		response string
+	}{
+		{
+			name:     "Created",
+			status:   201,
+			response: `{"id":5}`,
+		},
+		{
+			name:     "Invalid task",
+			err:      fmt.Errorf("invalid priority: %w", service.ErrInvalidTask),
+			status:   400,
+			response: `{"error":"invalid priority: invalid task"}`,
+		},
+		{
+			name:     "Column full",
+			err:      repository.ErrWipLimit,
+			status:   400,
+			response: `{"error":"column has reached its WIP limit"}`,
+		},
+		{
+			name:     "Database failure",
+			err:      errors.New("pq: duplicate key value violates unique constraint"),
+			status:   500,
+			response: `{"error":"failed to create anew task"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			task := mock_service.NewMockTask(c)
+			id := 5
+			if testCase.err != nil {
+				id = -1
+			}
+			task.EXPECT().CreateTask(gomock.Any(), gomock.Any()).Return(id, testCase.err)
+
+			handler := NewHandler(&service.Service{Task: task})
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/task", func(c *gin.Context) {
+				c.Set("userId", 1)
+				c.Set("userRole", "superuser")
+				c.Set("organizationId", 3)
+			}, handler.createTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/task", strings.NewReader(`{"title":"Deploy","description":"API",`+
+				`"executor_id":2,"project_id":4,"deadline":"2030-01-01 00:00"}`))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assert.Equal(t, testCase.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,599 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type teamIn struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type teamMemberIn struct {
+	UserId int `json:"user_id" binding:"required"`
+}
+
+type projectTeamIn struct {
+	TeamId int    `json:"team_id" binding:"required"`
+	Role   string `json:"role"`
+}
+
+func (h *Handler) createTeam(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to create a team",
+		})
+		return
+	}
+
+	var data teamIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	id, err := h.Team.CreateTeam(models.Team{
+		OrganizationId: orgId,
+		Name:           data.Name,
+		Description:    data.Description,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getTeams(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	teams, err := h.Team.GetTeams(orgId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": "failed to get the list of teams",
+		})
+		return
+	}
+
+	if len(teams) == 0 {
+		c.JSON(200, map[string]any{
+			"message": "there is no any team",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"teams": teams,
+	})
+}
+
+func (h *Handler) getTeamById(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	team, err := h.Team.GetTeam(orgId, teamId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "team doesn't exist",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"team": team,
+	})
+}
+
+func (h *Handler) updateTeam(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to update a team",
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data teamIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Team.UpdateTeam(models.Team{
+		ID:             teamId,
+		OrganizationId: orgId,
+		Name:           data.Name,
+		Description:    data.Description,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "team updated successfully",
+	})
+}
+
+func (h *Handler) deleteTeam(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to delete a team",
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Team.DeleteTeam(orgId, teamId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "team deleted successfully",
+	})
+}
+
+func (h *Handler) getTeamMembers(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	members, err := h.Team.GetMembers(orgId, teamId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"members": members,
+	})
+}
+
+func (h *Handler) addTeamMember(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to add members to a team",
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data teamMemberIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Team.AddMember(orgId, models.TeamMember{
+		TeamId: teamId,
+		UserId: data.UserId,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"message": "member added successfully",
+	})
+}
+
+func (h *Handler) removeTeamMember(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to remove members from a team",
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Team.RemoveMember(orgId, teamId, userId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "failed to remove the member",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "member removed successfully",
+	})
+}
+
+func (h *Handler) getTeamQueue(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	tasks, err := h.Team.GetQueue(orgId, userId, teamId, isOrganizationAdmin(c))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if len(tasks) == 0 {
+		c.JSON(200, map[string]any{
+			"message": "there is no any task in the queue",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"tasks": tasks,
+	})
+}
+
+func (h *Handler) claimTeamTask(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("taskId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Team.ClaimTask(auditContext(c), orgId, userId, teamId, taskId); err != nil {
+		c.JSON(http.StatusConflict, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "task claimed successfully",
+	})
+}
+
+func (h *Handler) addTeamToProject(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to add participants to a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data projectTeamIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	err = h.Team.AddTeamToProject(auditContext(c), orgId, managerId, models.ProjectTeam{
+		ProjectId: projectId,
+		TeamId:    data.TeamId,
+		Role:      data.Role,
+	})
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"message": "added a new team to the project",
+	})
+}
+
+func (h *Handler) getProjectTeams(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to see the teams of a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	teams, err := h.Team.GetProjectTeams(orgId, managerId, projectId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"teams": teams,
+	})
+}
+
+func (h *Handler) removeTeamFromProject(c *gin.Context) {
+	managerId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userRole, err := GetUserRole(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if strings.ToLower(userRole) != "superuser" {
+		c.JSON(400, map[string]any{
+			"error": "You are not allowed to remove participants from a project",
+		})
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	teamId, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Team.RemoveTeamFromProject(auditContext(c), orgId, managerId, projectId, teamId); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "failed to remove the team from the project",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "team removed from the project",
+	})
+}
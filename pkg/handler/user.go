@@ -75,7 +75,7 @@ func (h *Handler) updateUser(c *gin.Context) {
 
 	newUser.Password = string(hash)
 
-	if err := h.User.UpdateUser(newUser); err != nil {
+	if err := h.User.UpdateUser(auditContext(c), newUser); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to update the user",
 		})
@@ -96,7 +96,7 @@ func (h *Handler) deleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.User.DeleteUser(id); err != nil {
+	if err := h.User.DeleteUser(auditContext(c), id); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to delete the user",
 		})
@@ -117,7 +117,7 @@ func (h *Handler) restoreUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.User.Restore(id); err != nil {
+	if err := h.User.Restore(auditContext(c), id); err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to restore the user",
 		})
@@ -138,7 +138,15 @@ func (h *Handler) getProjects(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.User.GetProjects(userId)
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	projects, err := h.User.GetProjects(orgId, userId)
 	if err != nil {
 		c.JSON(500, map[string]any{
 			"error": "failed to get the list of projects",
@@ -167,7 +175,15 @@ func (h *Handler) getTasks(c *gin.Context) {
 		return
 	}
 
-	tasks, err := h.User.GetTasks(userId)
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	tasks, err := h.User.GetTasks(orgId, userId)
 	if err != nil {
 		c.JSON(400, map[string]any{
 			"error": "failed ti get the list of tasks",
@@ -212,7 +228,7 @@ func (h *Handler) setProfilePhoto(c *gin.Context) {
 		return
 	}
 
-	user, err := h.User.UploadUserPicture(userId, filePath)
+	user, err := h.User.UploadUserPicture(auditContext(c), userId, filePath)
 	if err != nil {
 		c.JSON(400, map[string]any{
 			"error": err.Error(),
@@ -258,7 +274,7 @@ func (h *Handler) changeProfilePhoto(c *gin.Context) {
 		return
 	}
 
-	user, err := h.User.UpdatePictureUser(userId, filePath)
+	user, err := h.User.UpdatePictureUser(auditContext(c), userId, filePath)
 	if err != nil {
 		c.JSON(500, map[string]any{
 			"error": err.Error(),
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"net/http"
+	"strconv"
+)
+
+// webhookIn subscribes to the whole organization when ProjectId is left out,
+// and to every event when Events is empty.
+type webhookIn struct {
+	URL       string   `json:"url" binding:"required"`
+	ProjectId *int     `json:"project_id"`
+	Events    []string `json:"events"`
+	IsActive  *bool    `json:"is_active"`
+}
+
+func (w webhookIn) webhook(orgId int) models.Webhook {
+	isActive := true
+	if w.IsActive != nil {
+		isActive = *w.IsActive
+	}
+
+	return models.Webhook{
+		OrganizationId: orgId,
+		ProjectId:      w.ProjectId,
+		URL:            w.URL,
+		Events:         w.Events,
+		IsActive:       isActive,
+	}
+}
+
+func (h *Handler) createWebhook(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to create a webhook",
+		})
+		return
+	}
+
+	var data webhookIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	webhook := data.webhook(orgId)
+	webhook.CreatedBy = userId
+
+	webhook, err = h.Webhook.CreateWebhook(webhook)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id":     webhook.ID,
+		"secret": webhook.Secret,
+	})
+}
+
+func (h *Handler) getWebhooks(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to see webhooks",
+		})
+		return
+	}
+
+	webhooks, err := h.Webhook.GetWebhooks(orgId)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": "failed to get the list of webhooks",
+		})
+		return
+	}
+
+	if len(webhooks) == 0 {
+		c.JSON(200, map[string]any{
+			"message": "there is no any webhook",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"webhooks": webhooks,
+	})
+}
+
+func (h *Handler) getWebhookById(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to see webhooks",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	webhook, err := h.Webhook.GetWebhook(orgId, id)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "webhook doesn't exist",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"webhook": webhook,
+	})
+}
+
+func (h *Handler) updateWebhook(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to update a webhook",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data webhookIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	webhook := data.webhook(orgId)
+	webhook.ID = id
+
+	if err := h.Webhook.UpdateWebhook(webhook); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "webhook updated successfully",
+	})
+}
+
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to delete a webhook",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	if err := h.Webhook.DeleteWebhook(orgId, id); err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "webhook deleted successfully",
+	})
+}
+
+func (h *Handler) getWebhookDeliveries(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to see webhook deliveries",
+		})
+		return
+	}
+
+	var beforeId, limit int
+	ints := map[string]*int{
+		"before_id": &beforeId,
+		"limit":     &limit,
+	}
+	for name, value := range ints {
+		if v := c.Query(name); v != "" {
+			*value, err = strconv.Atoi(v)
+			if err != nil {
+				c.JSON(400, map[string]any{
+					"error": "invalid type of param",
+				})
+				return
+			}
+		}
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	page, err := h.Webhook.GetDeliveries(orgId, id, beforeId, limit)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, page)
+}
+
+func (h *Handler) redeliverWebhook(c *gin.Context) {
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to redeliver a webhook",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	deliveryId, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	newId, err := h.Webhook.Redeliver(orgId, id, deliveryId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": newId,
+	})
+}
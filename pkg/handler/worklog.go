@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type worklogIn struct {
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Minutes   int        `json:"minutes"`
+	Note      string     `json:"note"`
+}
+
+func (h *Handler) logWork(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	var data worklogIn
+	if err := c.BindJSON(&data); err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid JSON provided",
+		})
+		return
+	}
+
+	worklog := models.Worklog{
+		TaskId:  taskId,
+		EndedAt: data.EndedAt,
+		Minutes: data.Minutes,
+		Note:    data.Note,
+	}
+	if data.StartedAt != nil {
+		worklog.StartedAt = *data.StartedAt
+	}
+
+	id, err := h.Worklog.LogWork(auditContext(c), orgId, userId, worklog)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getWorklogs(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	worklogs, err := h.Worklog.GetWorklogs(orgId, userId, taskId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if worklogs == nil {
+		c.JSON(200, map[string]any{
+			"message": "there is no any worklog",
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"worklogs": worklogs,
+	})
+}
+
+func (h *Handler) deleteWorklog(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("worklogId"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	err = h.Worklog.DeleteWorklog(auditContext(c), orgId, userId, taskId, id)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"message": "worklog deleted successfully",
+	})
+}
+
+func (h *Handler) startTimer(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": "invalid type of param",
+		})
+		return
+	}
+
+	id, err := h.Worklog.StartTimer(orgId, userId, taskId)
+	if errors.Is(err, repository.ErrTimerRunning) {
+		c.JSON(http.StatusConflict, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, map[string]any{
+		"id": id,
+	})
+}
+
+func (h *Handler) getRunningTimer(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	timer, err := h.Worklog.GetRunningTimer(userId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"timer": timer,
+	})
+}
+
+func (h *Handler) stopTimer(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	worklog, err := h.Worklog.StopTimer(auditContext(c), userId)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"worklog": worklog,
+	})
+}
+
+func (h *Handler) getTimesheet(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	orgId, err := getOrganizationId(c)
+	if err != nil {
+		c.JSON(500, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Members see their own time, admins everyone's unless they ask for a user.
+	filter := models.TimesheetFilter{OrganizationId: orgId, UserId: userId}
+	if isOrganizationAdmin(c) {
+		filter.UserId = 0
+	}
+
+	if v := c.Query("user_id"); v != "" {
+		filter.UserId, err = strconv.Atoi(v)
+		if err != nil {
+			c.JSON(400, map[string]any{
+				"error": "invalid type of param",
+			})
+			return
+		}
+	}
+
+	if filter.UserId != userId && !isOrganizationAdmin(c) {
+		c.JSON(http.StatusForbidden, map[string]any{
+			"error": "You are not allowed to see the timesheets of other users",
+		})
+		return
+	}
+
+	if v := c.Query("project_id"); v != "" {
+		filter.ProjectId, err = strconv.Atoi(v)
+		if err != nil {
+			c.JSON(400, map[string]any{
+				"error": "invalid type of param",
+			})
+			return
+		}
+	}
+
+	if v := c.Query("from"); v != "" {
+		filter.From, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(400, map[string]any{
+				"error": "invalid date format, expected YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	if v := c.Query("to"); v != "" {
+		filter.To, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(400, map[string]any{
+				"error": "invalid date format, expected YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	timesheet, err := h.Worklog.GetTimesheet(filter)
+	if err != nil {
+		c.JSON(400, map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, map[string]any{
+		"timesheet": timesheet,
+	})
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/logging"
+	"strings"
+)
+
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// LogMailer writes messages to the log instead of delivering them. It is used
+// when no SMTP server is configured.
+type LogMailer struct {
+	logger *logging.Logger
+}
+
+func NewLogMailer(logger *logging.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	m.logger.Infof("mail to %s: %s\n%s", strings.Join(msg.To, ", "), msg.Subject, msg.Text)
+	return nil
+}
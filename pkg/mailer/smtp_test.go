@@ -0,0 +1,167 @@
+package mailer
+
+import (
+	"bufio"
+	"github.com/sharifsharifzoda/project-management-system/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpSink accepts one message the way a local SMTP sink would and hands
+// over what it received.
+func smtpSink(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 sink ready")
+		var data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch command := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 sink")
+			case command == "DATA":
+				reply("354 go ahead")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				reply("250 queued")
+			case command == "QUIT":
+				reply("221 bye")
+				received <- data.String()
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	addr, received := smtpSink(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	m := NewSMTPMailer(configs.SMTPConfig{Host: host, Port: port, From: "PMS <no-reply@example.com>"})
+	msg, err := Render("notification", []string{"ali@example.com"}, "Vali assigned you to 'Deploy API'",
+		NotificationData{Name: "Ali", Message: "Vali assigned you to 'Deploy API'"})
+	require.NoError(t, err)
+	require.NoError(t, m.Send(msg))
+
+	var raw string
+	select {
+	case raw = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sink received nothing")
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "ali@example.com", parsed.Header.Get("To"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Vali assigned you to 'Deploy API'", subject)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "Hi Ali,")
+
+		types = append(types, part.Header.Get("Content-Type"))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, types)
+}
+
+func TestSMTPMailerSendTimeout(t *testing.T) {
+	// The server accepts the connection but never greets.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	m := NewSMTPMailer(configs.SMTPConfig{Host: host, Port: port, From: "no-reply@example.com"})
+	m.timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	err = m.Send(Message{To: []string{"ali@example.com"}, Subject: "Hi", Text: "Hello"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestBuildMessageTextOnly(t *testing.T) {
+	data, err := buildMessage("no-reply@example.com", Message{To: []string{"ali@example.com"}, Subject: "Hi",
+		Text: "Hello"}, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", parsed.Header.Get("Content-Type"))
+	assert.Equal(t, "Fri, 01 Mar 2024 09:00:00 +0000", parsed.Header.Get("Date"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(body))
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	msg, err := Render("digest", []string{"ali@example.com"}, "Digest", DigestData{Name: "Ali",
+		Messages: []string{"Vali moved '<b>API</b>' to Done"}})
+	require.NoError(t, err)
+
+	assert.Contains(t, msg.Text, "- Vali moved '<b>API</b>' to Done")
+	assert.Contains(t, msg.HTML, "<li>Vali moved &#39;&lt;b&gt;API&lt;/b&gt;&#39; to Done</li>")
+}
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"text/template"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+var (
+	textTemplates = template.Must(template.ParseFS(templateFiles, "templates/*.txt.tmpl"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFiles, "templates/*.html.tmpl"))
+)
+
+type NotificationData struct {
+	Name    string
+	Message string
+}
+
+type DigestData struct {
+	Name     string
+	Messages []string
+}
+
+type InviteData struct {
+	Project string
+	Role    string
+	Link    string
+	Expires string
+}
+
+// Render fills the text and the HTML template of the given name, like
+// "digest", with the data into a message.
+func Render(name string, to []string, subject string, data any) (Message, error) {
+	var text, html bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return Message{}, err
+	}
+
+	if err := htmlTemplates.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{To: to, Subject: subject, Text: text.String(), HTML: html.String()}, nil
+}
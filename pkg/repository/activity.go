@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+)
+
+type ActivityRepo struct {
+	db *gorm.DB
+}
+
+func NewActivityRepo(db *gorm.DB) *ActivityRepo {
+	return &ActivityRepo{db: db}
+}
+
+// CreateActivity saves the activity and returns it as saved. An activity
+// about a task takes its organization and project from the task, and the
+// title of the task and the name of the project into its data, as they are
+// now; any other activity takes the name of its project.
+func (a *ActivityRepo) CreateActivity(activity models.Activity) (models.Activity, error) {
+	var query *gorm.DB
+	if activity.TaskId != nil {
+		query = a.db.Raw("INSERT INTO activities (organization_id, project_id, actor_id, verb, task_id, user_id, "+
+			"data, created_at) "+
+			"SELECT tasks.organization_id, tasks.project_id, ?, ?, tasks.id, ?, "+
+			"?::jsonb || jsonb_build_object('title', tasks.title, 'project', projects.name), now() "+
+			"FROM tasks INNER JOIN projects ON projects.id = tasks.project_id WHERE tasks.id = ? "+
+			"RETURNING "+activityColumns,
+			activity.ActorId, activity.Verb, activity.UserId, activity.Data, *activity.TaskId)
+	} else {
+		query = a.db.Raw("INSERT INTO activities (organization_id, project_id, actor_id, verb, user_id, data, "+
+			"created_at) "+
+			"SELECT projects.organization_id, projects.id, ?, ?, ?, ?::jsonb || jsonb_build_object('project', "+
+			"projects.name), now() FROM projects WHERE projects.id = ? "+
+			"RETURNING "+activityColumns,
+			activity.ActorId, activity.Verb, activity.UserId, activity.Data, activity.ProjectId)
+	}
+
+	var saved models.Activity
+	result := query.Scan(&saved)
+	if result.Error != nil {
+		return models.Activity{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return models.Activity{}, gorm.ErrRecordNotFound
+	}
+
+	return saved, nil
+}
+
+const activityColumns = "id, organization_id, project_id, actor_id, verb, task_id, user_id, data, created_at"
+
+// GetActivities returns the newest activities of the project or, for a
+// user, of the projects of the organization they are a member of along with
+// the ones about them.
+func (a *ActivityRepo) GetActivities(filter models.ActivityFilter) (models.Activities, error) {
+	query := a.db.Model(&models.Activity{}).
+		Joins("inner join users actors on actors.id = activities.actor_id").
+		Joins("left join users subjects on subjects.id = activities.user_id").
+		Select([]string{"activities.id", "activities.project_id", "activities.actor_id", "actors.firstname",
+			"activities.verb", "activities.task_id", "activities.user_id", "COALESCE(subjects.firstname, '')",
+			"activities.data", "activities.created_at"}).
+		Where("activities.organization_id = ?", filter.OrganizationId)
+
+	if filter.ProjectId != 0 {
+		query = query.Where("activities.project_id = ?", filter.ProjectId)
+	}
+
+	if filter.UserId != 0 {
+		query = query.Where("activities.user_id = ? OR activities.project_id IN "+
+			"(SELECT projects.id FROM projects WHERE "+projectMember+")",
+			filter.UserId, filter.UserId, filter.UserId, filter.UserId)
+	}
+
+	if filter.ActorId != 0 {
+		query = query.Where("activities.actor_id = ?", filter.ActorId)
+	}
+
+	if filter.BeforeId != 0 {
+		query = query.Where("activities.id < ?", filter.BeforeId)
+	}
+
+	rows, err := query.Order("activities.id DESC").Limit(filter.Limit).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := models.Activities{}
+	for rows.Next() {
+		var activity models.Activity
+		err := rows.Scan(&activity.ID, &activity.ProjectId, &activity.ActorId, &activity.ActorName, &activity.Verb,
+			&activity.TaskId, &activity.UserId, &activity.UserName, &activity.Data, &activity.CreatedAt)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		activities = append(activities, activity)
+	}
+
+	return activities, rows.Err()
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+)
+
+// auditStates read an audited entity as a JSON object. Users leave their
+// password out; projects carry their teams and tasks their assignees, labels
+// and checklist, which are changed through them.
+var auditStates = map[string]string{
+	models.EntityUser: "SELECT to_jsonb(t) - 'password' FROM users t WHERE t.id = ?",
+	models.EntityProject: "SELECT to_jsonb(t) || jsonb_build_object(" +
+		"'teams', (SELECT COALESCE(jsonb_object_agg(p.team_id, p.role), '{}') FROM project_teams p " +
+		"WHERE p.project_id = t.id)) FROM projects t WHERE t.id = ?",
+	models.EntityTask: "SELECT to_jsonb(t) || jsonb_build_object(" +
+		"'assignees', (SELECT COALESCE(jsonb_object_agg(a.user_id, a.role), '{}') FROM task_assignees a " +
+		"WHERE a.task_id = t.id), " +
+		"'label_ids', (SELECT COALESCE(jsonb_agg(l.label_id ORDER BY l.label_id), '[]') FROM task_labels l " +
+		"WHERE l.task_id = t.id), " +
+		"'checklist', (SELECT COALESCE(jsonb_agg(jsonb_build_object('id', c.id, 'title', c.title, " +
+		"'is_checked', c.is_checked) ORDER BY c.rank), '[]') FROM checklist_items c WHERE c.task_id = t.id)) " +
+		"FROM tasks t WHERE t.id = ?",
+	models.EntityParticipant: "SELECT to_jsonb(t) FROM project_participants t WHERE t.id = ?",
+	models.EntityMember:      "SELECT to_jsonb(t) FROM organization_members t WHERE t.id = ?",
+}
+
+type AuditRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditRepo(db *gorm.DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+// GetState returns the entity as it is stored now, or nil if it doesn't
+// exist.
+func (a *AuditRepo) GetState(entityType string, id int) (models.JSONMap, error) {
+	query, ok := auditStates[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type %s", entityType)
+	}
+
+	var state models.JSONMap
+	err := a.db.Raw(query, id).Row().Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (a *AuditRepo) CreateEntry(entry models.AuditEntry) error {
+	return a.db.Create(&entry).Error
+}
+
+func (a *AuditRepo) GetEntries(filter models.AuditFilter) (models.AuditEntries, error) {
+	query := a.db.Model(&models.AuditEntry{})
+
+	if filter.OrganizationId != 0 {
+		query = query.Where("organization_id = ?", filter.OrganizationId)
+	}
+
+	if filter.ActorId != 0 {
+		query = query.Where("actor_id = ?", filter.ActorId)
+	}
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+
+	if filter.EntityId != 0 {
+		query = query.Where("entity_id = ?", filter.EntityId)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if filter.RequestId != "" {
+		query = query.Where("request_id = ?", filter.RequestId)
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	if filter.BeforeId != 0 {
+		query = query.Where("id < ?", filter.BeforeId)
+	}
+
+	var entries models.AuditEntries
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -44,3 +44,16 @@ func (r *AuthPostgres) IsEmailUsed(email string) bool {
 
 	return true
 }
+
+func (r *AuthPostgres) GetUserById(id int) (user models.User, err error) {
+	tx := r.db.Where("id = ? AND is_active = ?", id, true).First(&user)
+	if tx.Error != nil {
+		return models.User{}, tx.Error
+	}
+
+	return user, nil
+}
+
+func (r *AuthPostgres) CreateImpersonationLog(entry models.ImpersonationLog) error {
+	return r.db.Create(&entry).Error
+}
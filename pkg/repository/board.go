@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+)
+
+var (
+	ErrWipLimit    = errors.New("column has reached its WIP limit")
+	ErrBadPosition = errors.New("neighbour tasks are not next to each other in the column")
+)
+
+// boardOrder puts tasks without a rank, i.e. created before the board existed,
+// after the ranked ones.
+const boardOrder = "tasks.rank = '', tasks.rank, tasks.id"
+
+type BoardRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardRepo(db *gorm.DB) *BoardRepo {
+	return &BoardRepo{db: db}
+}
+
+// CreateDefaultColumns gives the project a column for each standard status
+// and for every other status its tasks already use.
+func (b *BoardRepo) CreateDefaultColumns(projectId int) error {
+	statuses := []string{models.StatusNotStarted, models.StatusInProgress, models.StatusDone}
+
+	var used []string
+	err := b.db.Model(&models.Task{}).Distinct("status").
+		Where("project_id = ? AND is_active = ? AND status NOT IN ?", projectId, true, statuses).
+		Order("status").Pluck("status", &used).Error
+	if err != nil {
+		return err
+	}
+	statuses = append(statuses, used...)
+
+	columns := make([]models.BoardColumn, 0, len(statuses))
+	for i, status := range statuses {
+		columns = append(columns, models.BoardColumn{ProjectId: projectId, Status: status, Position: i})
+	}
+
+	return b.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&columns).Error
+}
+
+func (b *BoardRepo) GetColumns(projectId int) ([]models.BoardColumn, error) {
+	var columns []models.BoardColumn
+	err := b.db.Where("project_id = ?", projectId).Order("position, id").Find(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+func (b *BoardRepo) GetColumn(projectId, id int) (models.BoardColumn, error) {
+	var column models.BoardColumn
+	err := b.db.Where("id = ? AND project_id = ?", id, projectId).First(&column).Error
+	if err != nil {
+		return models.BoardColumn{}, err
+	}
+
+	return column, nil
+}
+
+func (b *BoardRepo) CreateColumn(column models.BoardColumn) (int, error) {
+	err := b.db.Create(&column).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return column.ID, nil
+}
+
+// UpdateColumn also moves the tasks of the column when its status is renamed.
+func (b *BoardRepo) UpdateColumn(column models.BoardColumn, oldStatus string) error {
+	return b.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.BoardColumn{}).Where("id = ? AND project_id = ?", column.ID, column.ProjectId).
+			Updates(map[string]any{"status": column.Status, "position": column.Position,
+				"wip_limit": column.WipLimit}).Error
+		if err != nil {
+			return err
+		}
+
+		if oldStatus == column.Status {
+			return nil
+		}
+
+		return tx.Model(&models.Task{}).Where("project_id = ? AND status = ?", column.ProjectId, oldStatus).
+			Update("status", column.Status).Error
+	})
+}
+
+func (b *BoardRepo) DeleteColumn(projectId, id int) error {
+	tx := b.db.Where("id = ? AND project_id = ?", id, projectId).Delete(&models.BoardColumn{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (b *BoardRepo) CountColumnTasks(projectId int, status string) (int64, error) {
+	var count int64
+	err := b.db.Model(&models.Task{}).Where("project_id = ? AND status = ? AND is_active = ?", projectId, status, true).
+		Count(&count).Error
+
+	return count, err
+}
+
+// placeInColumn gives the task the rank at the bottom of its status column,
+// unless the column is at its WIP limit. Statuses without a column have no
+// limit. It locks the column, or the project while it has no board, so it
+// has to run in the transaction that saves the task: two tasks then can't
+// both take the last place in the column or get the same rank.
+func placeInColumn(tx *gorm.DB, task *models.Task) error {
+	var columns []models.BoardColumn
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("project_id = ? AND status = ?", task.ProjectId, task.Status).Limit(1).Find(&columns).Error
+	if err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		// NO KEY UPDATE still lets other transactions add rows that refer to
+		// the project.
+		err := tx.Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).Select("id").
+			Where("id = ?", task.ProjectId).First(&models.Project{}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	column := tx.Model(&models.Task{}).
+		Where("project_id = ? AND status = ? AND is_active = ? AND id <> ?", task.ProjectId, task.Status, true,
+			task.ID).Session(&gorm.Session{})
+
+	if len(columns) > 0 && columns[0].WipLimit > 0 {
+		var count int64
+		if err := column.Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count >= int64(columns[0].WipLimit) {
+			return ErrWipLimit
+		}
+	}
+
+	var ranks []string
+	err = column.Where("rank <> ''").Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+	if err != nil {
+		return err
+	}
+
+	last := ""
+	if len(ranks) > 0 {
+		last = ranks[0]
+	}
+	task.Rank = utils.RankBetween(last, "")
+
+	return nil
+}
+
+func (b *BoardRepo) GetBoardTasks(projectId int) (models.Tasks, error) {
+	var tasks models.Tasks
+	rows, err := b.db.Model(&models.Task{}).Joins("left join users on tasks.executor_id = users.id").
+		Joins("left join teams on tasks.team_id = teams.id").
+		Select([]string{"tasks.id", "tasks.title", "COALESCE(users.firstname, '')", "tasks.team_id",
+			"COALESCE(teams.name, '')", "tasks.status", "tasks.priority", "tasks.rank", "tasks.deadline"}).
+		Where("tasks.project_id = ? AND tasks.is_active = ?", projectId, true).
+		Order(boardOrder).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+		err := rows.Scan(&task.ID, &task.Title, &task.ExecutorName, &task.TeamId, &task.TeamName, &task.Status,
+			&task.Priority, &task.Rank, &task.Deadline)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+type rankedTask struct {
+	ID   int
+	Rank string
+}
+
+// MoveTask changes the column and the position of a task in one
+// transaction. The target column row is locked, so concurrent moves into it
+// can't both slip under the WIP limit or pick the same rank. Only the moved
+// task is updated, unless the column still has unranked tasks: those get
+// ranked once, here. It returns the status the task had before the move.
+func (b *BoardRepo) MoveTask(move models.TaskMove) (string, error) {
+	var status string
+	err := b.db.Transaction(func(tx *gorm.DB) error {
+		var column models.BoardColumn
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("project_id = ? AND status = ?", move.ProjectId, move.Status).First(&column).Error
+		if err != nil {
+			return err
+		}
+
+		var task models.Task
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND project_id = ? AND is_active = ?", move.TaskId, move.ProjectId, true).First(&task).Error
+		if err != nil {
+			return err
+		}
+		status = task.Status
+
+		var ranked []rankedTask
+		err = tx.Model(&models.Task{}).Select("id, rank").
+			Where("project_id = ? AND status = ? AND is_active = ? AND id <> ?", move.ProjectId, move.Status, true,
+				move.TaskId).
+			Order(boardOrder).Scan(&ranked).Error
+		if err != nil {
+			return err
+		}
+
+		if column.WipLimit > 0 && task.Status != move.Status && len(ranked) >= column.WipLimit {
+			return ErrWipLimit
+		}
+
+		if len(ranked) > 0 && ranked[len(ranked)-1].Rank == "" {
+			ranks := utils.SpreadRanks(len(ranked))
+			for i := range ranked {
+				ranked[i].Rank = ranks[i]
+				err := tx.Model(&models.Task{}).Where("id = ?", ranked[i].ID).Update("rank", ranks[i]).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		prev, next, err := neighbourRanks(ranked, move.PrevId, move.NextId)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Task{}).Where("id = ?", move.TaskId).
+			Updates(map[string]any{"status": move.Status, "rank": utils.RankBetween(prev, next)}).Error
+	})
+
+	return status, err
+}
+
+func neighbourRanks(ranked []rankedTask, prevId, nextId int) (string, string, error) {
+	indexOf := func(id int) int {
+		for i, t := range ranked {
+			if t.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	rankAt := func(i int) string {
+		if i < 0 || i >= len(ranked) {
+			return ""
+		}
+		return ranked[i].Rank
+	}
+
+	switch {
+	case prevId != 0:
+		i := indexOf(prevId)
+		if i < 0 || (nextId != 0 && indexOf(nextId) != i+1) {
+			return "", "", ErrBadPosition
+		}
+		return ranked[i].Rank, rankAt(i + 1), nil
+	case nextId != 0:
+		i := indexOf(nextId)
+		if i < 0 {
+			return "", "", ErrBadPosition
+		}
+		return rankAt(i - 1), ranked[i].Rank, nil
+	}
+
+	return rankAt(len(ranked) - 1), "", nil
+}
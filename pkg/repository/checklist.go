@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChecklistRepo struct {
+	db *gorm.DB
+}
+
+func NewChecklistRepo(db *gorm.DB) *ChecklistRepo {
+	return &ChecklistRepo{db: db}
+}
+
+// CreateItem adds the item at the end of the task's checklist.
+func (c *ChecklistRepo) CreateItem(item models.ChecklistItem) (int, error) {
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the task keeps two new items from getting the same rank.
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id = ?", item.TaskId).First(&models.Task{}).Error
+		if err != nil {
+			return err
+		}
+
+		var ranks []string
+		err = tx.Model(&models.ChecklistItem{}).Where("task_id = ?", item.TaskId).
+			Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+		if err != nil {
+			return err
+		}
+
+		last := ""
+		if len(ranks) > 0 {
+			last = ranks[0]
+		}
+		item.Rank = utils.RankBetween(last, "")
+
+		return tx.Create(&item).Error
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return item.ID, nil
+}
+
+func (c *ChecklistRepo) GetItems(taskId int) (models.ChecklistItems, error) {
+	var items models.ChecklistItems
+	err := c.db.Where("task_id = ?", taskId).Order("rank, id").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *ChecklistRepo) GetItem(taskId, id int) (models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := c.db.Where("id = ? AND task_id = ?", id, taskId).First(&item).Error
+	if err != nil {
+		return models.ChecklistItem{}, err
+	}
+
+	return item, nil
+}
+
+func (c *ChecklistRepo) UpdateItem(item models.ChecklistItem) error {
+	return c.db.Model(&models.ChecklistItem{}).Where("id = ? AND task_id = ?", item.ID, item.TaskId).
+		Updates(map[string]any{"title": item.Title, "is_checked": item.IsChecked}).Error
+}
+
+func (c *ChecklistRepo) DeleteItem(taskId, id int) error {
+	tx := c.db.Where("id = ? AND task_id = ?", id, taskId).Delete(&models.ChecklistItem{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// MoveItem puts the item between its new neighbours, which must be next to
+// each other in the checklist.
+func (c *ChecklistRepo) MoveItem(move models.ChecklistMove) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND task_id = ?", move.ItemId, move.TaskId).First(&models.ChecklistItem{}).Error
+		if err != nil {
+			return err
+		}
+
+		var ranked []rankedTask
+		err = tx.Model(&models.ChecklistItem{}).Select("id, rank").
+			Where("task_id = ? AND id <> ?", move.TaskId, move.ItemId).
+			Order("rank, id").Scan(&ranked).Error
+		if err != nil {
+			return err
+		}
+
+		prev, next, err := neighbourRanks(ranked, move.PrevId, move.NextId)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ChecklistItem{}).Where("id = ?", move.ItemId).
+			Update("rank", utils.RankBetween(prev, next)).Error
+	})
+}
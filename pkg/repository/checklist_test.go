@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNeighbourRanks(t *testing.T) {
+	ranked := []rankedTask{{ID: 1, Rank: "b"}, {ID: 2, Rank: "d"}, {ID: 3, Rank: "f"}}
+
+	testTable := []struct {
+		name   string
+		prevId int
+		nextId int
+		prev   string
+		next   string
+		err    error
+	}{
+		{
+			name: "without neighbours, to the end",
+			prev: "f",
+		},
+		{
+			name:   "after an item",
+			prevId: 2,
+			prev:   "d",
+			next:   "f",
+		},
+		{
+			name:   "after the last item",
+			prevId: 3,
+			prev:   "f",
+		},
+		{
+			name:   "before the first item",
+			nextId: 1,
+			next:   "b",
+		},
+		{
+			name:   "between neighbours",
+			prevId: 1,
+			nextId: 2,
+			prev:   "b",
+			next:   "d",
+		},
+		{
+			name:   "between items that aren't neighbours",
+			prevId: 1,
+			nextId: 3,
+			err:    ErrBadPosition,
+		},
+		{
+			name:   "after an unknown item",
+			prevId: 7,
+			err:    ErrBadPosition,
+		},
+		{
+			name:   "before an unknown item",
+			nextId: 7,
+			err:    ErrBadPosition,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			prev, next, err := neighbourRanks(ranked, test.prevId, test.nextId)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.prev, prev)
+			assert.Equal(t, test.next, next)
+		})
+	}
+}
+
+func checklistTitles(t *testing.T, repo *ChecklistRepo, taskId int) []string {
+	t.Helper()
+
+	items, err := repo.GetItems(taskId)
+	assert.NoError(t, err)
+
+	var titles []string
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+
+	return titles
+}
+
+func TestChecklistRepo_MoveItem(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewChecklistRepo(tx)
+
+	ids := map[string]int{}
+	for _, title := range []string{"a", "b", "c"} {
+		id, err := repo.CreateItem(models.ChecklistItem{TaskId: task.ID, Title: title})
+		assert.NoError(t, err)
+		ids[title] = id
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, checklistTitles(t, repo, task.ID))
+
+	assert.NoError(t, repo.MoveItem(models.ChecklistMove{TaskId: task.ID, ItemId: ids["c"], NextId: ids["a"]}))
+	assert.Equal(t, []string{"c", "a", "b"}, checklistTitles(t, repo, task.ID))
+
+	assert.NoError(t, repo.MoveItem(models.ChecklistMove{TaskId: task.ID, ItemId: ids["c"], PrevId: ids["a"],
+		NextId: ids["b"]}))
+	assert.Equal(t, []string{"a", "c", "b"}, checklistTitles(t, repo, task.ID))
+
+	assert.NoError(t, repo.MoveItem(models.ChecklistMove{TaskId: task.ID, ItemId: ids["a"], PrevId: ids["b"]}))
+	assert.Equal(t, []string{"c", "b", "a"}, checklistTitles(t, repo, task.ID))
+
+	err := repo.MoveItem(models.ChecklistMove{TaskId: task.ID, ItemId: ids["a"], PrevId: ids["c"], NextId: ids["a"]})
+	assert.ErrorIs(t, err, ErrBadPosition)
+	assert.Equal(t, []string{"c", "b", "a"}, checklistTitles(t, repo, task.ID))
+}
+
+func TestChecklistRepo_Progress(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewChecklistRepo(tx)
+	tasks := NewTaskRepo(tx)
+
+	progress := func() (int, int) {
+		list, err := tasks.GetTasks(task.OrganizationId, task.ControllerId, models.TaskFilter{})
+		assert.NoError(t, err)
+		if assert.Len(t, list, 1) {
+			return list[0].ChecklistDone, list[0].ChecklistTotal
+		}
+		return -1, -1
+	}
+
+	done, total := progress()
+	assert.Equal(t, 0, done)
+	assert.Equal(t, 0, total)
+
+	var items models.ChecklistItems
+	for _, title := range []string{"a", "b", "c"} {
+		id, err := repo.CreateItem(models.ChecklistItem{TaskId: task.ID, Title: title})
+		assert.NoError(t, err)
+		items = append(items, models.ChecklistItem{ID: id, TaskId: task.ID, Title: title})
+	}
+
+	items[0].IsChecked, items[2].IsChecked = true, true
+	assert.NoError(t, repo.UpdateItem(items[0]))
+	assert.NoError(t, repo.UpdateItem(items[2]))
+
+	done, total = progress()
+	assert.Equal(t, 2, done)
+	assert.Equal(t, 3, total)
+
+	items[0].IsChecked = false
+	assert.NoError(t, repo.UpdateItem(items[0]))
+	assert.NoError(t, repo.DeleteItem(task.ID, items[1].ID))
+
+	item, err := repo.GetItem(task.ID, items[0].ID)
+	assert.NoError(t, err)
+	assert.False(t, item.IsChecked)
+
+	done, total = progress()
+	assert.Equal(t, 1, done)
+	assert.Equal(t, 2, total)
+}
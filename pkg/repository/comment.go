@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+)
+
+type CommentRepo struct {
+	db *gorm.DB
+}
+
+func NewCommentRepo(db *gorm.DB) *CommentRepo {
+	return &CommentRepo{db: db}
+}
+
+func (c *CommentRepo) CreateComment(comment models.TaskComment) (int, error) {
+	if err := c.db.Create(&comment).Error; err != nil {
+		return -1, err
+	}
+
+	return comment.ID, nil
+}
+
+func (c *CommentRepo) GetComments(taskId int) (models.TaskComments, error) {
+	comments := models.TaskComments{}
+	rows, err := c.db.Model(&models.TaskComment{}).Joins("inner join users on task_comments.author_id = users.id").
+		Select([]string{"task_comments.id", "task_comments.task_id", "task_comments.author_id", "users.firstname",
+			"task_comments.body", "task_comments.created_at"}).
+		Where("task_comments.task_id = ?", taskId).
+		Order("task_comments.id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var comment models.TaskComment
+		err := rows.Scan(&comment.ID, &comment.TaskId, &comment.AuthorId, &comment.AuthorName, &comment.Body,
+			&comment.CreatedAt)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
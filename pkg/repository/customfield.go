@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+)
+
+type CustomFieldRepo struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepo(db *gorm.DB) *CustomFieldRepo {
+	return &CustomFieldRepo{db: db}
+}
+
+func (c *CustomFieldRepo) CreateCustomField(field models.CustomField) (int, error) {
+	err := c.db.Create(&field).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return field.ID, nil
+}
+
+func (c *CustomFieldRepo) GetCustomFields(projectId int) (models.CustomFields, error) {
+	var fields models.CustomFields
+	err := c.db.Where("project_id = ?", projectId).Order("id").Find(&fields).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (c *CustomFieldRepo) GetCustomField(projectId, id int) (models.CustomField, error) {
+	var field models.CustomField
+	err := c.db.Where("id = ? AND project_id = ?", id, projectId).First(&field).Error
+	if err != nil {
+		return models.CustomField{}, err
+	}
+
+	return field, nil
+}
+
+func (c *CustomFieldRepo) GetCustomFieldByKey(projectId int, key string) (models.CustomField, error) {
+	var field models.CustomField
+	err := c.db.Where("project_id = ? AND key = ?", projectId, key).First(&field).Error
+	if err != nil {
+		return models.CustomField{}, err
+	}
+
+	return field, nil
+}
+
+// UpdateCustomField changes everything but the key and the type, so values
+// already stored on tasks keep their meaning.
+func (c *CustomFieldRepo) UpdateCustomField(field models.CustomField) error {
+	return c.db.Model(&models.CustomField{}).Where("id = ? AND project_id = ?", field.ID, field.ProjectId).
+		Updates(map[string]any{"name": field.Name, "options": field.Options, "required": field.Required}).Error
+}
+
+// DeleteCustomField removes the definition and its values from the tasks of
+// the project.
+func (c *CustomFieldRepo) DeleteCustomField(projectId, id int) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var field models.CustomField
+		err := tx.Where("id = ? AND project_id = ?", id, projectId).First(&field).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Task{}).Where("project_id = ?", projectId).
+			Update("custom_fields", gorm.Expr("custom_fields - ?", field.Key)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&field).Error
+	})
+}
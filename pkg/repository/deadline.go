@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Who is told about a deadline, as subqueries of user ids run for every task
+// or project.
+const (
+	// taskWorkers are the executor and the assignees of the task.
+	taskWorkers = "SELECT tasks.executor_id AS user_id WHERE tasks.executor_id IS NOT NULL " +
+		"UNION SELECT task_assignees.user_id FROM task_assignees WHERE task_assignees.task_id = tasks.id AND " +
+		"task_assignees.role = '" + models.TaskRoleAssignee + "'"
+	// taskSupervisors are the controller of the task and the manager of its
+	// project.
+	taskSupervisors = "SELECT tasks.controller_id AS user_id UNION SELECT projects.manager_id"
+	projectManagers = "SELECT projects.manager_id AS user_id"
+	// projectSupervisors are the manager of the project and the owners and
+	// the admins of its organization.
+	projectSupervisors = "SELECT projects.manager_id AS user_id UNION SELECT organization_members.user_id " +
+		"FROM organization_members WHERE organization_members.organization_id = projects.organization_id AND " +
+		"organization_members.role IN ('owner', 'admin')"
+)
+
+// legacyReminderHours is the reminder the scheduler sent before reminders
+// could be configured. It keeps the key it had then, "deadline:<task
+// id>:<deadline>", so that it isn't sent again for the tasks it was already
+// sent for.
+const legacyReminderHours = 24
+
+// unfinished and finished compare the status column with models.StatusDone.
+// Project statuses are free text, so case and surrounding spaces don't
+// count. Both take models.StatusDone as their argument.
+func unfinished(column string) string {
+	return "lower(trim(" + column + ")) <> lower(?)"
+}
+
+func finished(column string) string {
+	return "lower(trim(" + column + ")) = lower(?)"
+}
+
+type DeadlineRepo struct {
+	db *gorm.DB
+}
+
+func NewDeadlineRepo(db *gorm.DB) *DeadlineRepo {
+	return &DeadlineRepo{db: db}
+}
+
+// MarkOverdue marks the unfinished tasks and projects whose deadline has
+// passed by now as overdue, and clears the mark of those that were finished
+// or got a later deadline since. It returns the marks it set and cleared.
+func (d *DeadlineRepo) MarkOverdue(now time.Time) ([]models.OverdueMark, error) {
+	var marks []models.OverdueMark
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		tables := []struct{ name, entityType string }{{"tasks", models.EntityTask}, {"projects", models.EntityProject}}
+		for _, table := range tables {
+			var marked, cleared []models.OverdueMark
+			err := tx.Raw("UPDATE "+table.name+" SET is_overdue = ? WHERE is_overdue = ? AND is_active = ? AND "+
+				unfinished("status")+" AND deadline <= ? RETURNING id, organization_id, is_overdue",
+				true, false, true, models.StatusDone, now).Scan(&marked).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Raw("UPDATE "+table.name+" SET is_overdue = ? WHERE is_overdue = ? AND "+
+				"("+finished("status")+" OR deadline > ?) RETURNING id, organization_id, is_overdue",
+				false, true, models.StatusDone, now).Scan(&cleared).Error
+			if err != nil {
+				return err
+			}
+
+			for _, mark := range append(marked, cleared...) {
+				mark.EntityType = table.entityType
+				marks = append(marks, mark)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return marks, nil
+}
+
+// NotifyTasks sends the notification of the given type about the unfinished
+// tasks due between from and to: reminders to those who work on them, or,
+// once they are overdue, escalations to those who supervise them. Each
+// user gets it once for the deadline and the number of hours of the rule.
+func (d *DeadlineRepo) NotifyTasks(notificationType string, hours int, from, to time.Time) (int64, error) {
+	recipients, overdue := taskWorkers, false
+	if notificationType == models.NotificationOverdue {
+		recipients, overdue = taskSupervisors, true
+	}
+
+	keyPrefix, keySuffix := notificationType+":task:", fmt.Sprintf(":%dh", hours)
+	if notificationType == models.NotificationDeadline && hours == legacyReminderHours {
+		keyPrefix, keySuffix = "deadline:", ""
+	}
+
+	result := d.db.Exec("INSERT INTO notifications (user_id, type, task_id, project_id, data, key, created_at) "+
+		"SELECT recipients.user_id, ?, tasks.id, tasks.project_id, jsonb_build_object('title', tasks.title, "+
+		"'project', projects.name, 'deadline', to_char(tasks.deadline, 'YYYY-MM-DD HH24:MI')), "+
+		"?::text || tasks.id || ':' || to_char(tasks.deadline, 'YYYY-MM-DD\"T\"HH24:MI') || ?::text, now() "+
+		"FROM tasks INNER JOIN projects ON projects.id = tasks.project_id "+
+		"CROSS JOIN LATERAL ("+recipients+") recipients "+
+		"INNER JOIN users ON users.id = recipients.user_id "+
+		"WHERE tasks.is_active = ? AND "+unfinished("tasks.status")+" AND tasks.is_overdue = ? AND tasks.deadline > ? "+
+		"AND tasks.deadline <= ? AND users.is_active = ? ON CONFLICT DO NOTHING",
+		notificationType, keyPrefix, keySuffix, true, models.StatusDone, overdue, from, to, true)
+
+	return result.RowsAffected, result.Error
+}
+
+// NotifyProjects is NotifyTasks for projects: reminders go to their
+// managers, and escalations to their managers and the admins of their
+// organizations.
+func (d *DeadlineRepo) NotifyProjects(notificationType string, hours int, from, to time.Time) (int64, error) {
+	recipients, overdue := projectManagers, false
+	if notificationType == models.NotificationOverdue {
+		recipients, overdue = projectSupervisors, true
+	}
+
+	result := d.db.Exec("INSERT INTO notifications (user_id, type, project_id, data, key, created_at) "+
+		"SELECT recipients.user_id, ?, projects.id, jsonb_build_object('project', projects.name, "+
+		"'deadline', to_char(projects.deadline, 'YYYY-MM-DD HH24:MI')), "+
+		"?::text || ':project:' || projects.id || ':' || "+
+		"to_char(projects.deadline, 'YYYY-MM-DD\"T\"HH24:MI') || ?, now() "+
+		"FROM projects CROSS JOIN LATERAL ("+recipients+") recipients "+
+		"INNER JOIN users ON users.id = recipients.user_id "+
+		"WHERE projects.is_active = ? AND "+unfinished("projects.status")+" AND projects.is_overdue = ? AND "+
+		"projects.deadline > ? AND projects.deadline <= ? AND users.is_active = ? ON CONFLICT DO NOTHING",
+		notificationType, notificationType, fmt.Sprintf(":%dh", hours), true, models.StatusDone, overdue, from, to,
+		true)
+
+	return result.RowsAffected, result.Error
+}
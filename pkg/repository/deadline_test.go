@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDeadlineRepo_NotifyTasksKeys(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewDeadlineRepo(tx)
+
+	deadline := time.Now().Add(30 * time.Minute)
+	assert.NoError(t, tx.Model(&models.Task{}).Where("id = ?", task.ID).
+		Updates(map[string]any{"deadline": deadline, "executor_id": task.ControllerId}).Error)
+
+	from, to := time.Now(), time.Now().Add(time.Hour)
+	for _, hours := range []int{24, 1} {
+		sent, err := repo.NotifyTasks(models.NotificationDeadline, hours, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), sent)
+
+		// A reminder is sent once.
+		sent, err = repo.NotifyTasks(models.NotificationDeadline, hours, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), sent)
+	}
+
+	var keys []string
+	assert.NoError(t, tx.Model(&models.Notification{}).Where("task_id = ?", task.ID).Order("id").
+		Pluck("key", &keys).Error)
+
+	var at string
+	assert.NoError(t, tx.Model(&models.Task{}).Select("to_char(deadline, 'YYYY-MM-DD\"T\"HH24:MI')").
+		Where("id = ?", task.ID).Row().Scan(&at))
+	assert.Equal(t, []string{
+		fmt.Sprintf("deadline:%d:%s", task.ID, at),
+		fmt.Sprintf("%s:task:%d:%s:1h", models.NotificationDeadline, task.ID, at),
+	}, keys)
+}
+
+func TestDeadlineRepo_MarkOverdue_StatusCase(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewDeadlineRepo(tx)
+
+	past := time.Now().Add(-time.Hour)
+	assert.NoError(t, tx.Model(&models.Project{}).Where("id = ?", task.ProjectId).
+		Updates(map[string]any{"deadline": past, "status": " done "}).Error)
+	assert.NoError(t, tx.Model(&models.Task{}).Where("id = ?", task.ID).Update("deadline", past).Error)
+
+	marks, err := repo.MarkOverdue(time.Now())
+	assert.NoError(t, err)
+
+	// Projects have free-text statuses; any spelling of done is finished.
+	assert.Equal(t, []models.OverdueMark{{EntityType: models.EntityTask, ID: task.ID,
+		OrganizationId: task.OrganizationId, IsOverdue: true}}, marks)
+}
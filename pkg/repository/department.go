@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+type DepartmentRepo struct {
+	db *gorm.DB
+}
+
+func NewDepartmentRepo(db *gorm.DB) *DepartmentRepo {
+	return &DepartmentRepo{db: db}
+}
+
+func (d *DepartmentRepo) CreateDepartment(department models.Department) (int, error) {
+	err := d.db.Create(&department).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return department.ID, nil
+}
+
+func (d *DepartmentRepo) GetDepartments(orgId int) (models.Departments, error) {
+	var departments models.Departments
+	rows, err := d.db.Model(&models.Department{}).Joins("left join users on departments.head_id = users.id").
+		Select([]string{"departments.id", "departments.name", "departments.head_id", "COALESCE(users.firstname, '')"}).
+		Where("departments.organization_id = ? AND departments.is_active = ?", orgId, true).
+		Order("departments.name").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dep models.Department
+		err := rows.Scan(&dep.ID, &dep.Name, &dep.HeadId, &dep.HeadName)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		departments = append(departments, dep)
+	}
+
+	return departments, nil
+}
+
+func (d *DepartmentRepo) GetDepartment(orgId, id int) (models.Department, error) {
+	var dep models.Department
+	row := d.db.Model(&models.Department{}).Joins("left join users on departments.head_id = users.id").
+		Select([]string{"departments.id", "departments.organization_id", "departments.name", "departments.key",
+			"departments.head_id", "COALESCE(users.firstname, '')"}).
+		Where("departments.organization_id = ? AND departments.id = ? AND departments.is_active = ?", orgId, id, true).
+		Row()
+
+	err := row.Scan(&dep.ID, &dep.OrganizationId, &dep.Name, &dep.Key, &dep.HeadId, &dep.HeadName)
+	if err != nil {
+		return models.Department{}, err
+	}
+
+	return dep, nil
+}
+
+func (d *DepartmentRepo) GetDepartmentByKey(orgId int, key string) (models.Department, error) {
+	var dep models.Department
+	err := d.db.Where("organization_id = ? AND key = ? AND is_active = ?", orgId, key, true).First(&dep).Error
+	if err != nil {
+		return models.Department{}, err
+	}
+
+	return dep, nil
+}
+
+func (d *DepartmentRepo) UpdateDepartment(department models.Department) error {
+	return d.db.Model(&models.Department{}).
+		Where("id = ? AND organization_id = ? AND is_active = ?", department.ID, department.OrganizationId, true).
+		Updates(map[string]any{"name": department.Name, "key": department.Key, "head_id": department.HeadId}).Error
+}
+
+func (d *DepartmentRepo) DeleteDepartment(orgId, id int) error {
+	return d.db.Model(&models.Department{}).Where("id = ? AND organization_id = ? AND is_active = ?", id, orgId, true).
+		Update("is_active", false).Error
+}
+
+func (d *DepartmentRepo) HasActiveProjects(id int) bool {
+	var count int64
+	err := d.db.Model(&models.Project{}).Where("department_id = ? AND is_active = ?", id, true).
+		Count(&count).Error
+	if err != nil {
+		return true
+	}
+
+	return count > 0
+}
+
+func (d *DepartmentRepo) AddMember(member models.DepartmentMember) error {
+	return d.db.Create(&member).Error
+}
+
+func (d *DepartmentRepo) RemoveMember(departmentId, userId int) error {
+	tx := d.db.Where("department_id = ? AND user_id = ?", departmentId, userId).Delete(&models.DepartmentMember{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (d *DepartmentRepo) GetMembers(departmentId int) ([]models.DepartmentMember, error) {
+	var members []models.DepartmentMember
+	rows, err := d.db.Model(&models.DepartmentMember{}).
+		Joins("inner join users on department_members.user_id = users.id").
+		Select([]string{"department_members.id", "department_members.department_id", "department_members.user_id",
+			"users.firstname", "users.lastname"}).
+		Where("department_members.department_id = ? AND users.is_active = ?", departmentId, true).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m models.DepartmentMember
+		err := rows.Scan(&m.ID, &m.DepartmentId, &m.UserId, &m.Firstname, &m.Lastname)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+func (d *DepartmentRepo) GetProjects(orgId, departmentId int) (models.Projects, error) {
+	var projects models.Projects
+	rows, err := d.db.Model(&models.Project{}).Joins("inner join users on projects.manager_id = users.id").
+		Joins("inner join departments on projects.department_id = departments.id").
+		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department_id",
+			"departments.name", "projects.status", "projects.start_date", "projects.deadline", "users.firstname"}).
+		Where("projects.organization_id = ? AND projects.department_id = ? AND projects.is_active = ?",
+			orgId, departmentId, true).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pro models.Project
+		err := rows.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.DepartmentId, &pro.DepartmentName, &pro.Status,
+			&pro.StartDate, &pro.Deadline, &pro.ManagerName)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		projects = append(projects, pro)
+	}
+
+	return projects, nil
+}
+
+type statusCount struct {
+	Status string
+	Count  int64
+}
+
+func (d *DepartmentRepo) GetStats(orgId, departmentId int) (models.DepartmentStats, error) {
+	stats := models.DepartmentStats{
+		DepartmentId: departmentId,
+		Projects:     map[string]int64{},
+		Tasks:        map[string]int64{},
+	}
+
+	err := d.db.Model(&models.DepartmentMember{}).Where("department_id = ?", departmentId).
+		Count(&stats.Members).Error
+	if err != nil {
+		return models.DepartmentStats{}, err
+	}
+
+	var projects []statusCount
+	err = d.db.Model(&models.Project{}).Select("status, count(*) as count").
+		Where("organization_id = ? AND department_id = ? AND is_active = ?", orgId, departmentId, true).
+		Group("status").Scan(&projects).Error
+	if err != nil {
+		return models.DepartmentStats{}, err
+	}
+
+	for _, p := range projects {
+		stats.Projects[p.Status] = p.Count
+	}
+
+	tasksQuery := d.db.Model(&models.Task{}).Joins("inner join projects on tasks.project_id = projects.id").
+		Where("projects.organization_id = ? AND projects.department_id = ? AND projects.is_active = ? AND "+
+			"tasks.is_active = ?", orgId, departmentId, true, true).Session(&gorm.Session{})
+
+	var tasks []statusCount
+	err = tasksQuery.Select("tasks.status, count(*) as count").
+		Group("tasks.status").Scan(&tasks).Error
+	if err != nil {
+		return models.DepartmentStats{}, err
+	}
+
+	for _, t := range tasks {
+		stats.Tasks[t.Status] = t.Count
+	}
+
+	err = tasksQuery.
+		Where("tasks.deadline < ? AND tasks.status <> ?", time.Now(), models.StatusDone).
+		Count(&stats.OverdueTasks).Error
+	if err != nil {
+		return models.DepartmentStats{}, err
+	}
+
+	return stats, nil
+}
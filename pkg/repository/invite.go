@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// ErrAlreadyParticipant is returned when the invited user has taken part in
+// the project since they were invited.
+var ErrAlreadyParticipant = errors.New("you already take part in the project")
+
+type InviteRepo struct {
+	db *gorm.DB
+}
+
+func NewInviteRepo(db *gorm.DB) *InviteRepo {
+	return &InviteRepo{db: db}
+}
+
+func (i *InviteRepo) CreateInvite(invite models.ProjectInvite) (int, error) {
+	err := i.db.Create(&invite).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return invite.ID, nil
+}
+
+func (i *InviteRepo) GetInvite(id int) (models.ProjectInvite, error) {
+	var invite models.ProjectInvite
+	err := i.db.Where("id = ?", id).First(&invite).Error
+	if err != nil {
+		return models.ProjectInvite{}, err
+	}
+
+	err = i.db.Model(&models.Project{}).Select("name").Where("id = ?", invite.ProjectId).Row().
+		Scan(&invite.ProjectName)
+	if err != nil {
+		return models.ProjectInvite{}, err
+	}
+
+	return invite, nil
+}
+
+func (i *InviteRepo) GetPendingInvites(projectId int) (models.ProjectInvites, error) {
+	var invites models.ProjectInvites
+	err := i.db.Where("project_id = ? AND status = ? AND expires_at > ?", projectId, "pending", time.Now()).
+		Order("created_at desc").Find(&invites).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (i *InviteRepo) HasPendingInvite(projectId int, email string) bool {
+	var count int64
+	err := i.db.Model(&models.ProjectInvite{}).
+		Where("project_id = ? AND email = ? AND status = ? AND expires_at > ?", projectId, email, "pending", time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+func (i *InviteRepo) IsParticipant(projectId int, email string) bool {
+	var count int64
+	err := i.db.Model(&models.ProjectParticipant{}).
+		Joins("inner join users on project_participants.participant_id = users.id").
+		Where("project_participants.project_id = ? AND users.email = ?", projectId, email).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+func (i *InviteRepo) RevokeInvite(projectId, inviteId int) error {
+	tx := i.db.Model(&models.ProjectInvite{}).
+		Where("id = ? AND project_id = ? AND status = ?", inviteId, projectId, "pending").
+		Update("status", "revoked")
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AcceptInvite creates the account when user has no id yet, makes it a member
+// of the project's organization, attaches it to the project and closes the
+// invite, all in one transaction.
+func (i *InviteRepo) AcceptInvite(invite models.ProjectInvite, user *models.User) (models.ProjectParticipant, error) {
+	participant := models.ProjectParticipant{
+		Role:      invite.Role,
+		ProjectId: invite.ProjectId,
+	}
+
+	err := i.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&models.ProjectInvite{}).
+			Where("id = ? AND status = ?", invite.ID, "pending").
+			Updates(map[string]any{"status": "accepted", "accepted_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errors.New("invite is no longer pending")
+		}
+
+		if user.ID == 0 {
+			if err := tx.Create(user).Error; err != nil {
+				return err
+			}
+		} else {
+			var count int64
+			err := tx.Model(&models.ProjectParticipant{}).
+				Where("project_id = ? AND participant_id = ?", invite.ProjectId, user.ID).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrAlreadyParticipant
+			}
+		}
+
+		var orgId int
+		err := tx.Model(&models.Project{}).Select("organization_id").Where("id = ?", invite.ProjectId).
+			Scan(&orgId).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.OrganizationMember{
+			OrganizationId: orgId,
+			UserId:         user.ID,
+			Role:           "member",
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		participant.ParticipantId = user.ID
+		return tx.Create(&participant).Error
+	})
+	if err != nil {
+		return models.ProjectParticipant{}, err
+	}
+
+	return participant, nil
+}
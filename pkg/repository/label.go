@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+)
+
+type LabelRepo struct {
+	db *gorm.DB
+}
+
+func NewLabelRepo(db *gorm.DB) *LabelRepo {
+	return &LabelRepo{db: db}
+}
+
+func (l *LabelRepo) CreateLabel(label models.Label) (int, error) {
+	err := l.db.Create(&label).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return label.ID, nil
+}
+
+func (l *LabelRepo) GetLabels(projectId int) (models.Labels, error) {
+	var labels models.Labels
+	err := l.db.Where("project_id = ?", projectId).Order("name").Find(&labels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (l *LabelRepo) GetLabel(projectId, id int) (models.Label, error) {
+	var label models.Label
+	err := l.db.Where("id = ? AND project_id = ?", id, projectId).First(&label).Error
+	if err != nil {
+		return models.Label{}, err
+	}
+
+	return label, nil
+}
+
+func (l *LabelRepo) GetLabelByKey(projectId int, key string) (models.Label, error) {
+	var label models.Label
+	err := l.db.Where("project_id = ? AND key = ?", projectId, key).First(&label).Error
+	if err != nil {
+		return models.Label{}, err
+	}
+
+	return label, nil
+}
+
+func (l *LabelRepo) UpdateLabel(label models.Label) error {
+	return l.db.Model(&models.Label{}).Where("id = ? AND project_id = ?", label.ID, label.ProjectId).
+		Updates(map[string]any{"name": label.Name, "key": label.Key, "color": label.Color}).Error
+}
+
+// DeleteLabel removes the label together with its taggings.
+func (l *LabelRepo) DeleteLabel(projectId, id int) error {
+	return l.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("label_id = ?", id).Delete(&models.TaskLabel{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Where("id = ? AND project_id = ?", id, projectId).Delete(&models.Label{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+func (l *LabelRepo) CountProjectLabels(projectId int, ids []int) (int64, error) {
+	var count int64
+	err := l.db.Model(&models.Label{}).Where("project_id = ? AND id IN ?", projectId, ids).Count(&count).Error
+
+	return count, err
+}
+
+// SetTaskLabels replaces the labels of the task.
+func (l *LabelRepo) SetTaskLabels(taskId int, ids []int) error {
+	return l.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskId).Delete(&models.TaskLabel{}).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		taskLabels := make([]models.TaskLabel, 0, len(ids))
+		for _, id := range ids {
+			taskLabels = append(taskLabels, models.TaskLabel{TaskId: taskId, LabelId: id})
+		}
+
+		return tx.Create(&taskLabels).Error
+	})
+}
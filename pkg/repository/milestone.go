@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+)
+
+type MilestoneRepo struct {
+	db *gorm.DB
+}
+
+func NewMilestoneRepo(db *gorm.DB) *MilestoneRepo {
+	return &MilestoneRepo{db: db}
+}
+
+func (m *MilestoneRepo) CreateMilestone(milestone models.Milestone) (int, error) {
+	err := m.db.Create(&milestone).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return milestone.ID, nil
+}
+
+// progressQuery selects the milestones with the counts of their active tasks
+// and whether any open task is due after the milestone.
+func (m *MilestoneRepo) progressQuery() *gorm.DB {
+	return m.db.Model(&models.Milestone{}).
+		Joins("left join tasks on tasks.milestone_id = milestones.id AND tasks.is_active = ?", true).
+		Select([]string{"milestones.id", "milestones.project_id", "milestones.name", "milestones.description",
+			"milestones.due_date", "count(tasks.id)",
+			"count(tasks.id) FILTER (WHERE tasks.status = '" + models.StatusDone + "')",
+			"COALESCE(bool_or(tasks.status <> '" + models.StatusDone + "' AND " +
+				"tasks.deadline::date > milestones.due_date), false)"}).
+		Group("milestones.id")
+}
+
+func scanMilestone(scan func(dest ...any) error) (models.Milestone, error) {
+	var ms models.Milestone
+	err := scan(&ms.ID, &ms.ProjectId, &ms.Name, &ms.Description, &ms.DueDate, &ms.TotalTasks, &ms.DoneTasks,
+		&ms.AtRisk)
+
+	return ms, err
+}
+
+func (m *MilestoneRepo) GetMilestones(projectId int) (models.Milestones, error) {
+	var milestones models.Milestones
+	rows, err := m.progressQuery().Where("milestones.project_id = ?", projectId).
+		Order("milestones.due_date, milestones.id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		ms, err := scanMilestone(rows.Scan)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		milestones = append(milestones, ms)
+	}
+
+	return milestones, nil
+}
+
+func (m *MilestoneRepo) GetMilestone(projectId, id int) (models.Milestone, error) {
+	row := m.progressQuery().Where("milestones.project_id = ? AND milestones.id = ?", projectId, id).Row()
+
+	ms, err := scanMilestone(row.Scan)
+	if err != nil {
+		return models.Milestone{}, err
+	}
+
+	return ms, nil
+}
+
+func (m *MilestoneRepo) UpdateMilestone(milestone models.Milestone) error {
+	tx := m.db.Model(&models.Milestone{}).Where("id = ? AND project_id = ?", milestone.ID, milestone.ProjectId).
+		Updates(map[string]any{"name": milestone.Name, "description": milestone.Description,
+			"due_date": milestone.DueDate})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// DeleteMilestone removes the milestone and unlinks its tasks.
+func (m *MilestoneRepo) DeleteMilestone(projectId, id int) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Task{}).Where("milestone_id = ?", id).Update("milestone_id", nil).Error; err != nil {
+			return err
+		}
+
+		res := tx.Where("id = ? AND project_id = ?", id, projectId).Delete(&models.Milestone{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// AddMilestoneTasks links active tasks of the project to the milestone and returns how
+// many of them were found.
+func (m *MilestoneRepo) AddMilestoneTasks(projectId, id int, taskIds []int) (int64, error) {
+	res := m.db.Model(&models.Task{}).Where("id IN ? AND project_id = ? AND is_active = ?", taskIds, projectId, true).
+		Update("milestone_id", id)
+
+	return res.RowsAffected, res.Error
+}
+
+func (m *MilestoneRepo) RemoveMilestoneTask(id, taskId int) error {
+	tx := m.db.Model(&models.Task{}).Where("id = ? AND milestone_id = ?", taskId, id).Update("milestone_id", nil)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (m *MilestoneRepo) GetMilestoneTasks(id int) (models.Tasks, error) {
+	var tasks models.Tasks
+	rows, err := m.db.Model(&models.Task{}).Joins("left join users on tasks.executor_id = users.id").
+		Select([]string{"tasks.id", "tasks.title", "COALESCE(users.firstname, '')", "tasks.status", "tasks.priority",
+			"tasks.milestone_id", "tasks.deadline"}).
+		Where("tasks.milestone_id = ? AND tasks.is_active = ?", id, true).
+		Order("tasks.deadline, tasks.id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+		err := rows.Scan(&task.ID, &task.Title, &task.ExecutorName, &task.Status, &task.Priority, &task.MilestoneId,
+			&task.Deadline)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
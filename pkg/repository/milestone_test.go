@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMilestoneRepo_AtRisk(t *testing.T) {
+	testTable := []struct {
+		name     string
+		status   string
+		deadline string
+		inactive bool
+		atRisk   bool
+	}{
+		{name: "no tasks"},
+		{name: "open task due before", status: models.StatusInProgress, deadline: "2030-05-30 18:00"},
+		{name: "open task due later the same day", status: models.StatusInProgress, deadline: "2030-06-01 23:00"},
+		{name: "open task due after", status: models.StatusNotStarted, deadline: "2030-06-02 09:00", atRisk: true},
+		{name: "done task due after", status: models.StatusDone, deadline: "2030-06-02 09:00"},
+		{name: "deleted task due after", status: models.StatusInProgress, deadline: "2030-06-02 09:00",
+			inactive: true},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			tx := testDB(t)
+			task := testTask(t, tx)
+			repo := NewMilestoneRepo(tx)
+
+			id, err := repo.CreateMilestone(models.Milestone{ProjectId: task.ProjectId, Name: "Release",
+				DueDate: time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)})
+			assert.NoError(t, err)
+
+			if test.deadline != "" {
+				err := tx.Model(&models.Task{}).Where("id = ?", task.ID).Updates(map[string]any{
+					"status": test.status, "deadline": test.deadline, "is_active": !test.inactive}).Error
+				assert.NoError(t, err)
+
+				_, err = repo.AddMilestoneTasks(task.ProjectId, id, []int{task.ID})
+				assert.NoError(t, err)
+			}
+
+			milestone, err := repo.GetMilestone(task.ProjectId, id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.atRisk, milestone.AtRisk)
+		})
+	}
+}
@@ -0,0 +1,3257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sharifsharifzoda/project-management-system/models"
+)
+
+// MockAuthorization is a mock of Authorization interface.
+type MockAuthorization struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationMockRecorder
+}
+
+// MockAuthorizationMockRecorder is the mock recorder for MockAuthorization.
+type MockAuthorizationMockRecorder struct {
+	mock *MockAuthorization
+}
+
+// NewMockAuthorization creates a new mock instance.
+func NewMockAuthorization(ctrl *gomock.Controller) *MockAuthorization {
+	mock := &MockAuthorization{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorization) EXPECT() *MockAuthorizationMockRecorder {
+	return m.recorder
+}
+
+// CreateImpersonationLog mocks base method.
+func (m *MockAuthorization) CreateImpersonationLog(entry models.ImpersonationLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImpersonationLog", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImpersonationLog indicates an expected call of CreateImpersonationLog.
+func (mr *MockAuthorizationMockRecorder) CreateImpersonationLog(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImpersonationLog", reflect.TypeOf((*MockAuthorization)(nil).CreateImpersonationLog), entry)
+}
+
+// CreateUser mocks base method.
+func (m *MockAuthorization) CreateUser(user *models.User) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockAuthorizationMockRecorder) CreateUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
+}
+
+// GetUser mocks base method.
+func (m *MockAuthorization) GetUser(email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAuthorizationMockRecorder) GetUser(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAuthorization)(nil).GetUser), email)
+}
+
+// GetUserById mocks base method.
+func (m *MockAuthorization) GetUserById(id int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockAuthorizationMockRecorder) GetUserById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockAuthorization)(nil).GetUserById), id)
+}
+
+// IsEmailUsed mocks base method.
+func (m *MockAuthorization) IsEmailUsed(email string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmailUsed", email)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEmailUsed indicates an expected call of IsEmailUsed.
+func (mr *MockAuthorizationMockRecorder) IsEmailUsed(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailUsed", reflect.TypeOf((*MockAuthorization)(nil).IsEmailUsed), email)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockUser) DeleteUser(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserMockRecorder) DeleteUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUser)(nil).DeleteUser), id)
+}
+
+// GetProjects mocks base method.
+func (m *MockUser) GetProjects(orgId, userId int) ([]models.UserProject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", orgId, userId)
+	ret0, _ := ret[0].([]models.UserProject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockUserMockRecorder) GetProjects(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockUser)(nil).GetProjects), orgId, userId)
+}
+
+// GetTasks mocks base method.
+func (m *MockUser) GetTasks(orgId, userId int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", orgId, userId)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockUserMockRecorder) GetTasks(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockUser)(nil).GetTasks), orgId, userId)
+}
+
+// GetUser mocks base method.
+func (m *MockUser) GetUser(id int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserMockRecorder) GetUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUser)(nil).GetUser), id)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUser) RestoreUser(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserMockRecorder) RestoreUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUser)(nil).RestoreUser), id)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUser) UpdateUser(newUser models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", newUser)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserMockRecorder) UpdateUser(newUser interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUser)(nil).UpdateUser), newUser)
+}
+
+// MockProject is a mock of Project interface.
+type MockProject struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectMockRecorder
+}
+
+// MockProjectMockRecorder is the mock recorder for MockProject.
+type MockProjectMockRecorder struct {
+	mock *MockProject
+}
+
+// NewMockProject creates a new mock instance.
+func NewMockProject(ctrl *gomock.Controller) *MockProject {
+	mock := &MockProject{ctrl: ctrl}
+	mock.recorder = &MockProjectMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProject) EXPECT() *MockProjectMockRecorder {
+	return m.recorder
+}
+
+// AddUserToProject mocks base method.
+func (m *MockProject) AddUserToProject(propar models.ProjectParticipant) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToProject", propar)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserToProject indicates an expected call of AddUserToProject.
+func (mr *MockProjectMockRecorder) AddUserToProject(propar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToProject", reflect.TypeOf((*MockProject)(nil).AddUserToProject), propar)
+}
+
+// CreateProject mocks base method.
+func (m *MockProject) CreateProject(project models.Project) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", project)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockProjectMockRecorder) CreateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProject)(nil).CreateProject), project)
+}
+
+// DeleteProject mocks base method.
+func (m *MockProject) DeleteProject(orgId, userId, projectId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", orgId, userId, projectId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockProjectMockRecorder) DeleteProject(orgId, userId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProject)(nil).DeleteProject), orgId, userId, projectId)
+}
+
+// GetAllProjects mocks base method.
+func (m *MockProject) GetAllProjects(orgId, userId int) (models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProjects", orgId, userId)
+	ret0, _ := ret[0].(models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProjects indicates an expected call of GetAllProjects.
+func (mr *MockProjectMockRecorder) GetAllProjects(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProjects", reflect.TypeOf((*MockProject)(nil).GetAllProjects), orgId, userId)
+}
+
+// GetDeletedProjects mocks base method.
+func (m *MockProject) GetDeletedProjects(orgId, userId int) (models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedProjects", orgId, userId)
+	ret0, _ := ret[0].(models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedProjects indicates an expected call of GetDeletedProjects.
+func (mr *MockProjectMockRecorder) GetDeletedProjects(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedProjects", reflect.TypeOf((*MockProject)(nil).GetDeletedProjects), orgId, userId)
+}
+
+// GetProjectById mocks base method.
+func (m *MockProject) GetProjectById(orgId, userId, projectId int) (models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectById", orgId, userId, projectId)
+	ret0, _ := ret[0].(models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectById indicates an expected call of GetProjectById.
+func (mr *MockProjectMockRecorder) GetProjectById(orgId, userId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectById", reflect.TypeOf((*MockProject)(nil).GetProjectById), orgId, userId, projectId)
+}
+
+// RestoreProject mocks base method.
+func (m *MockProject) RestoreProject(orgId, userId, projectId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProject", orgId, userId, projectId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreProject indicates an expected call of RestoreProject.
+func (mr *MockProjectMockRecorder) RestoreProject(orgId, userId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProject", reflect.TypeOf((*MockProject)(nil).RestoreProject), orgId, userId, projectId)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProject) UpdateProject(project models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProject)(nil).UpdateProject), project)
+}
+
+// MockTask is a mock of Task interface.
+type MockTask struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskMockRecorder
+}
+
+// MockTaskMockRecorder is the mock recorder for MockTask.
+type MockTaskMockRecorder struct {
+	mock *MockTask
+}
+
+// NewMockTask creates a new mock instance.
+func NewMockTask(ctrl *gomock.Controller) *MockTask {
+	mock := &MockTask{ctrl: ctrl}
+	mock.recorder = &MockTaskMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTask) EXPECT() *MockTaskMockRecorder {
+	return m.recorder
+}
+
+// BulkUpdateTasks mocks base method.
+func (m *MockTask) BulkUpdateTasks(orgId, userId int, changes []models.TaskChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateTasks", orgId, userId, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkUpdateTasks indicates an expected call of BulkUpdateTasks.
+func (mr *MockTaskMockRecorder) BulkUpdateTasks(orgId, userId, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateTasks", reflect.TypeOf((*MockTask)(nil).BulkUpdateTasks), orgId, userId, changes)
+}
+
+// CopyToProject mocks base method.
+func (m *MockTask) CopyToProject(task models.Task, transfer models.TaskTransfer, options models.TransferOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyToProject", task, transfer, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyToProject indicates an expected call of CopyToProject.
+func (mr *MockTaskMockRecorder) CopyToProject(task, transfer, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyToProject", reflect.TypeOf((*MockTask)(nil).CopyToProject), task, transfer, options)
+}
+
+// CreateTask mocks base method.
+func (m *MockTask) CreateTask(task models.Task) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", task)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockTaskMockRecorder) CreateTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTask)(nil).CreateTask), task)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTask) DeleteTask(orgId, userId, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", orgId, userId, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTaskMockRecorder) DeleteTask(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTask)(nil).DeleteTask), orgId, userId, taskId)
+}
+
+// GetAssignees mocks base method.
+func (m *MockTask) GetAssignees(taskId int) ([]models.TaskAssignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignees", taskId)
+	ret0, _ := ret[0].([]models.TaskAssignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignees indicates an expected call of GetAssignees.
+func (mr *MockTaskMockRecorder) GetAssignees(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignees", reflect.TypeOf((*MockTask)(nil).GetAssignees), taskId)
+}
+
+// GetTaskById mocks base method.
+func (m *MockTask) GetTaskById(orgId, userId, taskId int) (models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskById", orgId, userId, taskId)
+	ret0, _ := ret[0].(models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskById indicates an expected call of GetTaskById.
+func (mr *MockTaskMockRecorder) GetTaskById(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTask)(nil).GetTaskById), orgId, userId, taskId)
+}
+
+// GetTasks mocks base method.
+func (m *MockTask) GetTasks(orgId, userId int, filter models.TaskFilter) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", orgId, userId, filter)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockTaskMockRecorder) GetTasks(orgId, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTask)(nil).GetTasks), orgId, userId, filter)
+}
+
+// GetTransfers mocks base method.
+func (m *MockTask) GetTransfers(taskId int) ([]models.TaskTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfers", taskId)
+	ret0, _ := ret[0].([]models.TaskTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfers indicates an expected call of GetTransfers.
+func (mr *MockTaskMockRecorder) GetTransfers(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfers", reflect.TypeOf((*MockTask)(nil).GetTransfers), taskId)
+}
+
+// IsProjectMember mocks base method.
+func (m *MockTask) IsProjectMember(projectId, userId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProjectMember", projectId, userId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsProjectMember indicates an expected call of IsProjectMember.
+func (mr *MockTaskMockRecorder) IsProjectMember(projectId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProjectMember", reflect.TypeOf((*MockTask)(nil).IsProjectMember), projectId, userId)
+}
+
+// MoveToProject mocks base method.
+func (m *MockTask) MoveToProject(orgId, userId int, change models.TaskChange, transfer models.TaskTransfer, options models.TransferOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToProject", orgId, userId, change, transfer, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToProject indicates an expected call of MoveToProject.
+func (mr *MockTaskMockRecorder) MoveToProject(orgId, userId, change, transfer, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToProject", reflect.TypeOf((*MockTask)(nil).MoveToProject), orgId, userId, change, transfer, options)
+}
+
+// ProjectInOrganization mocks base method.
+func (m *MockTask) ProjectInOrganization(orgId, projectId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectInOrganization", orgId, projectId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ProjectInOrganization indicates an expected call of ProjectInOrganization.
+func (mr *MockTaskMockRecorder) ProjectInOrganization(orgId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectInOrganization", reflect.TypeOf((*MockTask)(nil).ProjectInOrganization), orgId, projectId)
+}
+
+// RemoveAssignee mocks base method.
+func (m *MockTask) RemoveAssignee(taskId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignee", taskId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAssignee indicates an expected call of RemoveAssignee.
+func (mr *MockTaskMockRecorder) RemoveAssignee(taskId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignee", reflect.TypeOf((*MockTask)(nil).RemoveAssignee), taskId, userId)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTask) RestoreTask(orgId, userId, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", orgId, userId, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTaskMockRecorder) RestoreTask(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTask)(nil).RestoreTask), orgId, userId, taskId)
+}
+
+// SetAssignee mocks base method.
+func (m *MockTask) SetAssignee(assignee models.TaskAssignee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAssignee", assignee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAssignee indicates an expected call of SetAssignee.
+func (mr *MockTaskMockRecorder) SetAssignee(assignee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssignee", reflect.TypeOf((*MockTask)(nil).SetAssignee), assignee)
+}
+
+// TeamInOrganization mocks base method.
+func (m *MockTask) TeamInOrganization(orgId, teamId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamInOrganization", orgId, teamId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TeamInOrganization indicates an expected call of TeamInOrganization.
+func (mr *MockTaskMockRecorder) TeamInOrganization(orgId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamInOrganization", reflect.TypeOf((*MockTask)(nil).TeamInOrganization), orgId, teamId)
+}
+
+// TeamInProject mocks base method.
+func (m *MockTask) TeamInProject(projectId, teamId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamInProject", projectId, teamId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TeamInProject indicates an expected call of TeamInProject.
+func (mr *MockTaskMockRecorder) TeamInProject(projectId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamInProject", reflect.TypeOf((*MockTask)(nil).TeamInProject), projectId, teamId)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTask) UpdateTask(task models.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), task)
+}
+
+// MockInvite is a mock of Invite interface.
+type MockInvite struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteMockRecorder
+}
+
+// MockInviteMockRecorder is the mock recorder for MockInvite.
+type MockInviteMockRecorder struct {
+	mock *MockInvite
+}
+
+// NewMockInvite creates a new mock instance.
+func NewMockInvite(ctrl *gomock.Controller) *MockInvite {
+	mock := &MockInvite{ctrl: ctrl}
+	mock.recorder = &MockInviteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvite) EXPECT() *MockInviteMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvite mocks base method.
+func (m *MockInvite) AcceptInvite(invite models.ProjectInvite, user *models.User) (models.ProjectParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", invite, user)
+	ret0, _ := ret[0].(models.ProjectParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockInviteMockRecorder) AcceptInvite(invite, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockInvite)(nil).AcceptInvite), invite, user)
+}
+
+// CreateInvite mocks base method.
+func (m *MockInvite) CreateInvite(invite models.ProjectInvite) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", invite)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockInviteMockRecorder) CreateInvite(invite interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockInvite)(nil).CreateInvite), invite)
+}
+
+// GetInvite mocks base method.
+func (m *MockInvite) GetInvite(id int) (models.ProjectInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvite", id)
+	ret0, _ := ret[0].(models.ProjectInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvite indicates an expected call of GetInvite.
+func (mr *MockInviteMockRecorder) GetInvite(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvite", reflect.TypeOf((*MockInvite)(nil).GetInvite), id)
+}
+
+// GetPendingInvites mocks base method.
+func (m *MockInvite) GetPendingInvites(projectId int) (models.ProjectInvites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvites", projectId)
+	ret0, _ := ret[0].(models.ProjectInvites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvites indicates an expected call of GetPendingInvites.
+func (mr *MockInviteMockRecorder) GetPendingInvites(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvites", reflect.TypeOf((*MockInvite)(nil).GetPendingInvites), projectId)
+}
+
+// HasPendingInvite mocks base method.
+func (m *MockInvite) HasPendingInvite(projectId int, email string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingInvite", projectId, email)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasPendingInvite indicates an expected call of HasPendingInvite.
+func (mr *MockInviteMockRecorder) HasPendingInvite(projectId, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingInvite", reflect.TypeOf((*MockInvite)(nil).HasPendingInvite), projectId, email)
+}
+
+// IsParticipant mocks base method.
+func (m *MockInvite) IsParticipant(projectId int, email string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsParticipant", projectId, email)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsParticipant indicates an expected call of IsParticipant.
+func (mr *MockInviteMockRecorder) IsParticipant(projectId, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsParticipant", reflect.TypeOf((*MockInvite)(nil).IsParticipant), projectId, email)
+}
+
+// RevokeInvite mocks base method.
+func (m *MockInvite) RevokeInvite(projectId, inviteId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvite", projectId, inviteId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvite indicates an expected call of RevokeInvite.
+func (mr *MockInviteMockRecorder) RevokeInvite(projectId, inviteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvite", reflect.TypeOf((*MockInvite)(nil).RevokeInvite), projectId, inviteId)
+}
+
+// MockOrganization is a mock of Organization interface.
+type MockOrganization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMockRecorder
+}
+
+// MockOrganizationMockRecorder is the mock recorder for MockOrganization.
+type MockOrganizationMockRecorder struct {
+	mock *MockOrganization
+}
+
+// NewMockOrganization creates a new mock instance.
+func NewMockOrganization(ctrl *gomock.Controller) *MockOrganization {
+	mock := &MockOrganization{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganization) EXPECT() *MockOrganizationMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockOrganization) AddMember(member models.OrganizationMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationMockRecorder) AddMember(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganization)(nil).AddMember), member)
+}
+
+// CountOwners mocks base method.
+func (m *MockOrganization) CountOwners(orgId int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwners", orgId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwners indicates an expected call of CountOwners.
+func (mr *MockOrganizationMockRecorder) CountOwners(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwners", reflect.TypeOf((*MockOrganization)(nil).CountOwners), orgId)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganization) CreateOrganization(org models.Organization, ownerId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", org, ownerId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationMockRecorder) CreateOrganization(org, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganization)(nil).CreateOrganization), org, ownerId)
+}
+
+// GetMember mocks base method.
+func (m *MockOrganization) GetMember(orgId, userId int) (models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", orgId, userId)
+	ret0, _ := ret[0].(models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockOrganizationMockRecorder) GetMember(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockOrganization)(nil).GetMember), orgId, userId)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganization) GetMembers(orgId int) ([]models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", orgId)
+	ret0, _ := ret[0].([]models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationMockRecorder) GetMembers(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganization)(nil).GetMembers), orgId)
+}
+
+// GetOrganization mocks base method.
+func (m *MockOrganization) GetOrganization(orgId int) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", orgId)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockOrganizationMockRecorder) GetOrganization(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockOrganization)(nil).GetOrganization), orgId)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganization) GetOrganizations(userId int) (models.Organizations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", userId)
+	ret0, _ := ret[0].(models.Organizations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationMockRecorder) GetOrganizations(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganization)(nil).GetOrganizations), userId)
+}
+
+// IsSlugUsed mocks base method.
+func (m *MockOrganization) IsSlugUsed(slug string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSlugUsed", slug)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSlugUsed indicates an expected call of IsSlugUsed.
+func (mr *MockOrganizationMockRecorder) IsSlugUsed(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlugUsed", reflect.TypeOf((*MockOrganization)(nil).IsSlugUsed), slug)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganization) RemoveMember(orgId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", orgId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationMockRecorder) RemoveMember(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganization)(nil).RemoveMember), orgId, userId)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockOrganization) UpdateMemberRole(orgId, userId int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", orgId, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockOrganizationMockRecorder) UpdateMemberRole(orgId, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockOrganization)(nil).UpdateMemberRole), orgId, userId, role)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockOrganization) UpdateOrganization(org models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockOrganizationMockRecorder) UpdateOrganization(org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockOrganization)(nil).UpdateOrganization), org)
+}
+
+// MockDepartment is a mock of Department interface.
+type MockDepartment struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepartmentMockRecorder
+}
+
+// MockDepartmentMockRecorder is the mock recorder for MockDepartment.
+type MockDepartmentMockRecorder struct {
+	mock *MockDepartment
+}
+
+// NewMockDepartment creates a new mock instance.
+func NewMockDepartment(ctrl *gomock.Controller) *MockDepartment {
+	mock := &MockDepartment{ctrl: ctrl}
+	mock.recorder = &MockDepartmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepartment) EXPECT() *MockDepartmentMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockDepartment) AddMember(member models.DepartmentMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockDepartmentMockRecorder) AddMember(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockDepartment)(nil).AddMember), member)
+}
+
+// CreateDepartment mocks base method.
+func (m *MockDepartment) CreateDepartment(department models.Department) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepartment", department)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDepartment indicates an expected call of CreateDepartment.
+func (mr *MockDepartmentMockRecorder) CreateDepartment(department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockDepartment)(nil).CreateDepartment), department)
+}
+
+// DeleteDepartment mocks base method.
+func (m *MockDepartment) DeleteDepartment(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockDepartmentMockRecorder) DeleteDepartment(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*MockDepartment)(nil).DeleteDepartment), orgId, id)
+}
+
+// GetDepartment mocks base method.
+func (m *MockDepartment) GetDepartment(orgId, id int) (models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", orgId, id)
+	ret0, _ := ret[0].(models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockDepartmentMockRecorder) GetDepartment(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockDepartment)(nil).GetDepartment), orgId, id)
+}
+
+// GetDepartmentByKey mocks base method.
+func (m *MockDepartment) GetDepartmentByKey(orgId int, key string) (models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentByKey", orgId, key)
+	ret0, _ := ret[0].(models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentByKey indicates an expected call of GetDepartmentByKey.
+func (mr *MockDepartmentMockRecorder) GetDepartmentByKey(orgId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentByKey", reflect.TypeOf((*MockDepartment)(nil).GetDepartmentByKey), orgId, key)
+}
+
+// GetDepartments mocks base method.
+func (m *MockDepartment) GetDepartments(orgId int) (models.Departments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartments", orgId)
+	ret0, _ := ret[0].(models.Departments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartments indicates an expected call of GetDepartments.
+func (mr *MockDepartmentMockRecorder) GetDepartments(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartments", reflect.TypeOf((*MockDepartment)(nil).GetDepartments), orgId)
+}
+
+// GetMembers mocks base method.
+func (m *MockDepartment) GetMembers(departmentId int) ([]models.DepartmentMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", departmentId)
+	ret0, _ := ret[0].([]models.DepartmentMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockDepartmentMockRecorder) GetMembers(departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockDepartment)(nil).GetMembers), departmentId)
+}
+
+// GetProjects mocks base method.
+func (m *MockDepartment) GetProjects(orgId, departmentId int) (models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", orgId, departmentId)
+	ret0, _ := ret[0].(models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockDepartmentMockRecorder) GetProjects(orgId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockDepartment)(nil).GetProjects), orgId, departmentId)
+}
+
+// GetStats mocks base method.
+func (m *MockDepartment) GetStats(orgId, departmentId int) (models.DepartmentStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", orgId, departmentId)
+	ret0, _ := ret[0].(models.DepartmentStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockDepartmentMockRecorder) GetStats(orgId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockDepartment)(nil).GetStats), orgId, departmentId)
+}
+
+// HasActiveProjects mocks base method.
+func (m *MockDepartment) HasActiveProjects(id int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveProjects", id)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasActiveProjects indicates an expected call of HasActiveProjects.
+func (mr *MockDepartmentMockRecorder) HasActiveProjects(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveProjects", reflect.TypeOf((*MockDepartment)(nil).HasActiveProjects), id)
+}
+
+// RemoveMember mocks base method.
+func (m *MockDepartment) RemoveMember(departmentId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", departmentId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockDepartmentMockRecorder) RemoveMember(departmentId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockDepartment)(nil).RemoveMember), departmentId, userId)
+}
+
+// UpdateDepartment mocks base method.
+func (m *MockDepartment) UpdateDepartment(department models.Department) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepartment", department)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDepartment indicates an expected call of UpdateDepartment.
+func (mr *MockDepartmentMockRecorder) UpdateDepartment(department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepartment", reflect.TypeOf((*MockDepartment)(nil).UpdateDepartment), department)
+}
+
+// MockTeam is a mock of Team interface.
+type MockTeam struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamMockRecorder
+}
+
+// MockTeamMockRecorder is the mock recorder for MockTeam.
+type MockTeamMockRecorder struct {
+	mock *MockTeam
+}
+
+// NewMockTeam creates a new mock instance.
+func NewMockTeam(ctrl *gomock.Controller) *MockTeam {
+	mock := &MockTeam{ctrl: ctrl}
+	mock.recorder = &MockTeamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeam) EXPECT() *MockTeamMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockTeam) AddMember(member models.TeamMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockTeamMockRecorder) AddMember(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockTeam)(nil).AddMember), member)
+}
+
+// AddTeamToProject mocks base method.
+func (m *MockTeam) AddTeamToProject(projectTeam models.ProjectTeam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeamToProject", projectTeam)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTeamToProject indicates an expected call of AddTeamToProject.
+func (mr *MockTeamMockRecorder) AddTeamToProject(projectTeam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamToProject", reflect.TypeOf((*MockTeam)(nil).AddTeamToProject), projectTeam)
+}
+
+// ClaimTask mocks base method.
+func (m *MockTeam) ClaimTask(orgId, teamId, taskId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTask", orgId, teamId, taskId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimTask indicates an expected call of ClaimTask.
+func (mr *MockTeamMockRecorder) ClaimTask(orgId, teamId, taskId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTask", reflect.TypeOf((*MockTeam)(nil).ClaimTask), orgId, teamId, taskId, userId)
+}
+
+// CreateTeam mocks base method.
+func (m *MockTeam) CreateTeam(team models.Team) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", team)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockTeamMockRecorder) CreateTeam(team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeam)(nil).CreateTeam), team)
+}
+
+// DeleteTeam mocks base method.
+func (m *MockTeam) DeleteTeam(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockTeamMockRecorder) DeleteTeam(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeam)(nil).DeleteTeam), orgId, id)
+}
+
+// GetMembers mocks base method.
+func (m *MockTeam) GetMembers(teamId int) ([]models.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", teamId)
+	ret0, _ := ret[0].([]models.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockTeamMockRecorder) GetMembers(teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockTeam)(nil).GetMembers), teamId)
+}
+
+// GetProjectTeams mocks base method.
+func (m *MockTeam) GetProjectTeams(projectId int) ([]models.ProjectTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectTeams", projectId)
+	ret0, _ := ret[0].([]models.ProjectTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectTeams indicates an expected call of GetProjectTeams.
+func (mr *MockTeamMockRecorder) GetProjectTeams(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectTeams", reflect.TypeOf((*MockTeam)(nil).GetProjectTeams), projectId)
+}
+
+// GetQueue mocks base method.
+func (m *MockTeam) GetQueue(orgId, teamId int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueue", orgId, teamId)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueue indicates an expected call of GetQueue.
+func (mr *MockTeamMockRecorder) GetQueue(orgId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockTeam)(nil).GetQueue), orgId, teamId)
+}
+
+// GetTeam mocks base method.
+func (m *MockTeam) GetTeam(orgId, id int) (models.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", orgId, id)
+	ret0, _ := ret[0].(models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockTeamMockRecorder) GetTeam(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeam)(nil).GetTeam), orgId, id)
+}
+
+// GetTeamByKey mocks base method.
+func (m *MockTeam) GetTeamByKey(orgId int, key string) (models.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamByKey", orgId, key)
+	ret0, _ := ret[0].(models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamByKey indicates an expected call of GetTeamByKey.
+func (mr *MockTeamMockRecorder) GetTeamByKey(orgId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByKey", reflect.TypeOf((*MockTeam)(nil).GetTeamByKey), orgId, key)
+}
+
+// GetTeams mocks base method.
+func (m *MockTeam) GetTeams(orgId int) (models.Teams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeams", orgId)
+	ret0, _ := ret[0].(models.Teams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeams indicates an expected call of GetTeams.
+func (mr *MockTeamMockRecorder) GetTeams(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*MockTeam)(nil).GetTeams), orgId)
+}
+
+// HasUnclaimedTasks mocks base method.
+func (m *MockTeam) HasUnclaimedTasks(id int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUnclaimedTasks", id)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasUnclaimedTasks indicates an expected call of HasUnclaimedTasks.
+func (mr *MockTeamMockRecorder) HasUnclaimedTasks(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUnclaimedTasks", reflect.TypeOf((*MockTeam)(nil).HasUnclaimedTasks), id)
+}
+
+// IsMember mocks base method.
+func (m *MockTeam) IsMember(teamId, userId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMember", teamId, userId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsMember indicates an expected call of IsMember.
+func (mr *MockTeamMockRecorder) IsMember(teamId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMember", reflect.TypeOf((*MockTeam)(nil).IsMember), teamId, userId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockTeam) RemoveMember(teamId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", teamId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockTeamMockRecorder) RemoveMember(teamId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockTeam)(nil).RemoveMember), teamId, userId)
+}
+
+// RemoveTeamFromProject mocks base method.
+func (m *MockTeam) RemoveTeamFromProject(projectId, teamId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamFromProject", projectId, teamId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeamFromProject indicates an expected call of RemoveTeamFromProject.
+func (mr *MockTeamMockRecorder) RemoveTeamFromProject(projectId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamFromProject", reflect.TypeOf((*MockTeam)(nil).RemoveTeamFromProject), projectId, teamId)
+}
+
+// UpdateTeam mocks base method.
+func (m *MockTeam) UpdateTeam(team models.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeam", team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTeam indicates an expected call of UpdateTeam.
+func (mr *MockTeamMockRecorder) UpdateTeam(team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockTeam)(nil).UpdateTeam), team)
+}
+
+// MockLabel is a mock of Label interface.
+type MockLabel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelMockRecorder
+}
+
+// MockLabelMockRecorder is the mock recorder for MockLabel.
+type MockLabelMockRecorder struct {
+	mock *MockLabel
+}
+
+// NewMockLabel creates a new mock instance.
+func NewMockLabel(ctrl *gomock.Controller) *MockLabel {
+	mock := &MockLabel{ctrl: ctrl}
+	mock.recorder = &MockLabelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabel) EXPECT() *MockLabelMockRecorder {
+	return m.recorder
+}
+
+// CountProjectLabels mocks base method.
+func (m *MockLabel) CountProjectLabels(projectId int, ids []int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProjectLabels", projectId, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProjectLabels indicates an expected call of CountProjectLabels.
+func (mr *MockLabelMockRecorder) CountProjectLabels(projectId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProjectLabels", reflect.TypeOf((*MockLabel)(nil).CountProjectLabels), projectId, ids)
+}
+
+// CreateLabel mocks base method.
+func (m *MockLabel) CreateLabel(label models.Label) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", label)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockLabelMockRecorder) CreateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockLabel)(nil).CreateLabel), label)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockLabel) DeleteLabel(projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockLabelMockRecorder) DeleteLabel(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockLabel)(nil).DeleteLabel), projectId, id)
+}
+
+// GetLabel mocks base method.
+func (m *MockLabel) GetLabel(projectId, id int) (models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabel", projectId, id)
+	ret0, _ := ret[0].(models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabel indicates an expected call of GetLabel.
+func (mr *MockLabelMockRecorder) GetLabel(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabel", reflect.TypeOf((*MockLabel)(nil).GetLabel), projectId, id)
+}
+
+// GetLabelByKey mocks base method.
+func (m *MockLabel) GetLabelByKey(projectId int, key string) (models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelByKey", projectId, key)
+	ret0, _ := ret[0].(models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelByKey indicates an expected call of GetLabelByKey.
+func (mr *MockLabelMockRecorder) GetLabelByKey(projectId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelByKey", reflect.TypeOf((*MockLabel)(nil).GetLabelByKey), projectId, key)
+}
+
+// GetLabels mocks base method.
+func (m *MockLabel) GetLabels(projectId int) (models.Labels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabels", projectId)
+	ret0, _ := ret[0].(models.Labels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabels indicates an expected call of GetLabels.
+func (mr *MockLabelMockRecorder) GetLabels(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabels", reflect.TypeOf((*MockLabel)(nil).GetLabels), projectId)
+}
+
+// SetTaskLabels mocks base method.
+func (m *MockLabel) SetTaskLabels(taskId int, ids []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskLabels", taskId, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskLabels indicates an expected call of SetTaskLabels.
+func (mr *MockLabelMockRecorder) SetTaskLabels(taskId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskLabels", reflect.TypeOf((*MockLabel)(nil).SetTaskLabels), taskId, ids)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockLabel) UpdateLabel(label models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockLabelMockRecorder) UpdateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockLabel)(nil).UpdateLabel), label)
+}
+
+// MockCustomField is a mock of CustomField interface.
+type MockCustomField struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldMockRecorder
+}
+
+// MockCustomFieldMockRecorder is the mock recorder for MockCustomField.
+type MockCustomFieldMockRecorder struct {
+	mock *MockCustomField
+}
+
+// NewMockCustomField creates a new mock instance.
+func NewMockCustomField(ctrl *gomock.Controller) *MockCustomField {
+	mock := &MockCustomField{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomField) EXPECT() *MockCustomFieldMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomField mocks base method.
+func (m *MockCustomField) CreateCustomField(field models.CustomField) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", field)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockCustomFieldMockRecorder) CreateCustomField(field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockCustomField)(nil).CreateCustomField), field)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockCustomField) DeleteCustomField(projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockCustomFieldMockRecorder) DeleteCustomField(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockCustomField)(nil).DeleteCustomField), projectId, id)
+}
+
+// GetCustomField mocks base method.
+func (m *MockCustomField) GetCustomField(projectId, id int) (models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomField", projectId, id)
+	ret0, _ := ret[0].(models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomField indicates an expected call of GetCustomField.
+func (mr *MockCustomFieldMockRecorder) GetCustomField(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomField", reflect.TypeOf((*MockCustomField)(nil).GetCustomField), projectId, id)
+}
+
+// GetCustomFieldByKey mocks base method.
+func (m *MockCustomField) GetCustomFieldByKey(projectId int, key string) (models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFieldByKey", projectId, key)
+	ret0, _ := ret[0].(models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFieldByKey indicates an expected call of GetCustomFieldByKey.
+func (mr *MockCustomFieldMockRecorder) GetCustomFieldByKey(projectId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFieldByKey", reflect.TypeOf((*MockCustomField)(nil).GetCustomFieldByKey), projectId, key)
+}
+
+// GetCustomFields mocks base method.
+func (m *MockCustomField) GetCustomFields(projectId int) (models.CustomFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFields", projectId)
+	ret0, _ := ret[0].(models.CustomFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFields indicates an expected call of GetCustomFields.
+func (mr *MockCustomFieldMockRecorder) GetCustomFields(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFields", reflect.TypeOf((*MockCustomField)(nil).GetCustomFields), projectId)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockCustomField) UpdateCustomField(field models.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", field)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockCustomFieldMockRecorder) UpdateCustomField(field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockCustomField)(nil).UpdateCustomField), field)
+}
+
+// MockBoard is a mock of Board interface.
+type MockBoard struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMockRecorder
+}
+
+// MockBoardMockRecorder is the mock recorder for MockBoard.
+type MockBoardMockRecorder struct {
+	mock *MockBoard
+}
+
+// NewMockBoard creates a new mock instance.
+func NewMockBoard(ctrl *gomock.Controller) *MockBoard {
+	mock := &MockBoard{ctrl: ctrl}
+	mock.recorder = &MockBoardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoard) EXPECT() *MockBoardMockRecorder {
+	return m.recorder
+}
+
+// CountColumnTasks mocks base method.
+func (m *MockBoard) CountColumnTasks(projectId int, status string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountColumnTasks", projectId, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountColumnTasks indicates an expected call of CountColumnTasks.
+func (mr *MockBoardMockRecorder) CountColumnTasks(projectId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountColumnTasks", reflect.TypeOf((*MockBoard)(nil).CountColumnTasks), projectId, status)
+}
+
+// CreateColumn mocks base method.
+func (m *MockBoard) CreateColumn(column models.BoardColumn) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateColumn", column)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateColumn indicates an expected call of CreateColumn.
+func (mr *MockBoardMockRecorder) CreateColumn(column interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockBoard)(nil).CreateColumn), column)
+}
+
+// CreateDefaultColumns mocks base method.
+func (m *MockBoard) CreateDefaultColumns(projectId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDefaultColumns", projectId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDefaultColumns indicates an expected call of CreateDefaultColumns.
+func (mr *MockBoardMockRecorder) CreateDefaultColumns(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaultColumns", reflect.TypeOf((*MockBoard)(nil).CreateDefaultColumns), projectId)
+}
+
+// DeleteColumn mocks base method.
+func (m *MockBoard) DeleteColumn(projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteColumn", projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteColumn indicates an expected call of DeleteColumn.
+func (mr *MockBoardMockRecorder) DeleteColumn(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockBoard)(nil).DeleteColumn), projectId, id)
+}
+
+// GetBoardTasks mocks base method.
+func (m *MockBoard) GetBoardTasks(projectId int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardTasks", projectId)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardTasks indicates an expected call of GetBoardTasks.
+func (mr *MockBoardMockRecorder) GetBoardTasks(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardTasks", reflect.TypeOf((*MockBoard)(nil).GetBoardTasks), projectId)
+}
+
+// GetColumn mocks base method.
+func (m *MockBoard) GetColumn(projectId, id int) (models.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumn", projectId, id)
+	ret0, _ := ret[0].(models.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumn indicates an expected call of GetColumn.
+func (mr *MockBoardMockRecorder) GetColumn(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumn", reflect.TypeOf((*MockBoard)(nil).GetColumn), projectId, id)
+}
+
+// GetColumns mocks base method.
+func (m *MockBoard) GetColumns(projectId int) ([]models.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumns", projectId)
+	ret0, _ := ret[0].([]models.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumns indicates an expected call of GetColumns.
+func (mr *MockBoardMockRecorder) GetColumns(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockBoard)(nil).GetColumns), projectId)
+}
+
+// MoveTask mocks base method.
+func (m *MockBoard) MoveTask(move models.TaskMove) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", move)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockBoardMockRecorder) MoveTask(move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockBoard)(nil).MoveTask), move)
+}
+
+// UpdateColumn mocks base method.
+func (m *MockBoard) UpdateColumn(column models.BoardColumn, oldStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumn", column, oldStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateColumn indicates an expected call of UpdateColumn.
+func (mr *MockBoardMockRecorder) UpdateColumn(column, oldStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumn", reflect.TypeOf((*MockBoard)(nil).UpdateColumn), column, oldStatus)
+}
+
+// MockSprint is a mock of Sprint interface.
+type MockSprint struct {
+	ctrl     *gomock.Controller
+	recorder *MockSprintMockRecorder
+}
+
+// MockSprintMockRecorder is the mock recorder for MockSprint.
+type MockSprintMockRecorder struct {
+	mock *MockSprint
+}
+
+// NewMockSprint creates a new mock instance.
+func NewMockSprint(ctrl *gomock.Controller) *MockSprint {
+	mock := &MockSprint{ctrl: ctrl}
+	mock.recorder = &MockSprintMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSprint) EXPECT() *MockSprintMockRecorder {
+	return m.recorder
+}
+
+// AddTasks mocks base method.
+func (m *MockSprint) AddTasks(projectId, id int, taskIds []int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTasks", projectId, id, taskIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTasks indicates an expected call of AddTasks.
+func (mr *MockSprintMockRecorder) AddTasks(projectId, id, taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockSprint)(nil).AddTasks), projectId, id, taskIds)
+}
+
+// CompleteSprint mocks base method.
+func (m *MockSprint) CompleteSprint(projectId, id int, nextId *int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSprint", projectId, id, nextId)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteSprint indicates an expected call of CompleteSprint.
+func (mr *MockSprintMockRecorder) CompleteSprint(projectId, id, nextId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSprint", reflect.TypeOf((*MockSprint)(nil).CompleteSprint), projectId, id, nextId)
+}
+
+// CreateSprint mocks base method.
+func (m *MockSprint) CreateSprint(sprint models.Sprint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSprint", sprint)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSprint indicates an expected call of CreateSprint.
+func (mr *MockSprintMockRecorder) CreateSprint(sprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSprint", reflect.TypeOf((*MockSprint)(nil).CreateSprint), sprint)
+}
+
+// DeleteSprint mocks base method.
+func (m *MockSprint) DeleteSprint(projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSprint", projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSprint indicates an expected call of DeleteSprint.
+func (mr *MockSprintMockRecorder) DeleteSprint(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSprint", reflect.TypeOf((*MockSprint)(nil).DeleteSprint), projectId, id)
+}
+
+// GetBacklog mocks base method.
+func (m *MockSprint) GetBacklog(projectId int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklog", projectId)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklog indicates an expected call of GetBacklog.
+func (mr *MockSprintMockRecorder) GetBacklog(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklog", reflect.TypeOf((*MockSprint)(nil).GetBacklog), projectId)
+}
+
+// GetCompletedSprints mocks base method.
+func (m *MockSprint) GetCompletedSprints(projectId int) (models.Sprints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedSprints", projectId)
+	ret0, _ := ret[0].(models.Sprints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedSprints indicates an expected call of GetCompletedSprints.
+func (mr *MockSprintMockRecorder) GetCompletedSprints(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedSprints", reflect.TypeOf((*MockSprint)(nil).GetCompletedSprints), projectId)
+}
+
+// GetSprint mocks base method.
+func (m *MockSprint) GetSprint(projectId, id int) (models.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprint", projectId, id)
+	ret0, _ := ret[0].(models.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprint indicates an expected call of GetSprint.
+func (mr *MockSprintMockRecorder) GetSprint(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprint", reflect.TypeOf((*MockSprint)(nil).GetSprint), projectId, id)
+}
+
+// GetSprintTasks mocks base method.
+func (m *MockSprint) GetSprintTasks(id int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprintTasks", id)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprintTasks indicates an expected call of GetSprintTasks.
+func (mr *MockSprintMockRecorder) GetSprintTasks(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprintTasks", reflect.TypeOf((*MockSprint)(nil).GetSprintTasks), id)
+}
+
+// GetSprints mocks base method.
+func (m *MockSprint) GetSprints(projectId int) (models.Sprints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprints", projectId)
+	ret0, _ := ret[0].(models.Sprints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprints indicates an expected call of GetSprints.
+func (mr *MockSprintMockRecorder) GetSprints(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprints", reflect.TypeOf((*MockSprint)(nil).GetSprints), projectId)
+}
+
+// HasActiveSprint mocks base method.
+func (m *MockSprint) HasActiveSprint(projectId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveSprint", projectId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasActiveSprint indicates an expected call of HasActiveSprint.
+func (mr *MockSprintMockRecorder) HasActiveSprint(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveSprint", reflect.TypeOf((*MockSprint)(nil).HasActiveSprint), projectId)
+}
+
+// RemoveTask mocks base method.
+func (m *MockSprint) RemoveTask(id, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTask", id, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTask indicates an expected call of RemoveTask.
+func (mr *MockSprintMockRecorder) RemoveTask(id, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTask", reflect.TypeOf((*MockSprint)(nil).RemoveTask), id, taskId)
+}
+
+// StartSprint mocks base method.
+func (m *MockSprint) StartSprint(projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSprint", projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartSprint indicates an expected call of StartSprint.
+func (mr *MockSprintMockRecorder) StartSprint(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSprint", reflect.TypeOf((*MockSprint)(nil).StartSprint), projectId, id)
+}
+
+// UpdateSprint mocks base method.
+func (m *MockSprint) UpdateSprint(sprint models.Sprint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSprint", sprint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSprint indicates an expected call of UpdateSprint.
+func (mr *MockSprintMockRecorder) UpdateSprint(sprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSprint", reflect.TypeOf((*MockSprint)(nil).UpdateSprint), sprint)
+}
+
+// MockMilestone is a mock of Milestone interface.
+type MockMilestone struct {
+	ctrl     *gomock.Controller
+	recorder *MockMilestoneMockRecorder
+}
+
+// MockMilestoneMockRecorder is the mock recorder for MockMilestone.
+type MockMilestoneMockRecorder struct {
+	mock *MockMilestone
+}
+
+// NewMockMilestone creates a new mock instance.
+func NewMockMilestone(ctrl *gomock.Controller) *MockMilestone {
+	mock := &MockMilestone{ctrl: ctrl}
+	mock.recorder = &MockMilestoneMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMilestone) EXPECT() *MockMilestoneMockRecorder {
+	return m.recorder
+}
+
+// AddMilestoneTasks mocks base method.
+func (m *MockMilestone) AddMilestoneTasks(projectId, id int, taskIds []int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMilestoneTasks", projectId, id, taskIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMilestoneTasks indicates an expected call of AddMilestoneTasks.
+func (mr *MockMilestoneMockRecorder) AddMilestoneTasks(projectId, id, taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMilestoneTasks", reflect.TypeOf((*MockMilestone)(nil).AddMilestoneTasks), projectId, id, taskIds)
+}
+
+// CreateMilestone mocks base method.
+func (m *MockMilestone) CreateMilestone(milestone models.Milestone) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMilestone", milestone)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMilestone indicates an expected call of CreateMilestone.
+func (mr *MockMilestoneMockRecorder) CreateMilestone(milestone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMilestone", reflect.TypeOf((*MockMilestone)(nil).CreateMilestone), milestone)
+}
+
+// DeleteMilestone mocks base method.
+func (m *MockMilestone) DeleteMilestone(projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMilestone", projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMilestone indicates an expected call of DeleteMilestone.
+func (mr *MockMilestoneMockRecorder) DeleteMilestone(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMilestone", reflect.TypeOf((*MockMilestone)(nil).DeleteMilestone), projectId, id)
+}
+
+// GetMilestone mocks base method.
+func (m *MockMilestone) GetMilestone(projectId, id int) (models.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestone", projectId, id)
+	ret0, _ := ret[0].(models.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestone indicates an expected call of GetMilestone.
+func (mr *MockMilestoneMockRecorder) GetMilestone(projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestone", reflect.TypeOf((*MockMilestone)(nil).GetMilestone), projectId, id)
+}
+
+// GetMilestoneTasks mocks base method.
+func (m *MockMilestone) GetMilestoneTasks(id int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestoneTasks", id)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestoneTasks indicates an expected call of GetMilestoneTasks.
+func (mr *MockMilestoneMockRecorder) GetMilestoneTasks(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestoneTasks", reflect.TypeOf((*MockMilestone)(nil).GetMilestoneTasks), id)
+}
+
+// GetMilestones mocks base method.
+func (m *MockMilestone) GetMilestones(projectId int) (models.Milestones, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestones", projectId)
+	ret0, _ := ret[0].(models.Milestones)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestones indicates an expected call of GetMilestones.
+func (mr *MockMilestoneMockRecorder) GetMilestones(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestones", reflect.TypeOf((*MockMilestone)(nil).GetMilestones), projectId)
+}
+
+// RemoveMilestoneTask mocks base method.
+func (m *MockMilestone) RemoveMilestoneTask(id, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMilestoneTask", id, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMilestoneTask indicates an expected call of RemoveMilestoneTask.
+func (mr *MockMilestoneMockRecorder) RemoveMilestoneTask(id, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMilestoneTask", reflect.TypeOf((*MockMilestone)(nil).RemoveMilestoneTask), id, taskId)
+}
+
+// UpdateMilestone mocks base method.
+func (m *MockMilestone) UpdateMilestone(milestone models.Milestone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMilestone", milestone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMilestone indicates an expected call of UpdateMilestone.
+func (mr *MockMilestoneMockRecorder) UpdateMilestone(milestone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMilestone", reflect.TypeOf((*MockMilestone)(nil).UpdateMilestone), milestone)
+}
+
+// MockWorklog is a mock of Worklog interface.
+type MockWorklog struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorklogMockRecorder
+}
+
+// MockWorklogMockRecorder is the mock recorder for MockWorklog.
+type MockWorklogMockRecorder struct {
+	mock *MockWorklog
+}
+
+// NewMockWorklog creates a new mock instance.
+func NewMockWorklog(ctrl *gomock.Controller) *MockWorklog {
+	mock := &MockWorklog{ctrl: ctrl}
+	mock.recorder = &MockWorklogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorklog) EXPECT() *MockWorklogMockRecorder {
+	return m.recorder
+}
+
+// CanLogWork mocks base method.
+func (m *MockWorklog) CanLogWork(orgId, userId, taskId int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanLogWork", orgId, userId, taskId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanLogWork indicates an expected call of CanLogWork.
+func (mr *MockWorklogMockRecorder) CanLogWork(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanLogWork", reflect.TypeOf((*MockWorklog)(nil).CanLogWork), orgId, userId, taskId)
+}
+
+// CreateWorklog mocks base method.
+func (m *MockWorklog) CreateWorklog(worklog models.Worklog) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorklog", worklog)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorklog indicates an expected call of CreateWorklog.
+func (mr *MockWorklogMockRecorder) CreateWorklog(worklog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorklog", reflect.TypeOf((*MockWorklog)(nil).CreateWorklog), worklog)
+}
+
+// DeleteWorklog mocks base method.
+func (m *MockWorklog) DeleteWorklog(userId, taskId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorklog", userId, taskId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorklog indicates an expected call of DeleteWorklog.
+func (mr *MockWorklogMockRecorder) DeleteWorklog(userId, taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorklog", reflect.TypeOf((*MockWorklog)(nil).DeleteWorklog), userId, taskId, id)
+}
+
+// GetRunningTimer mocks base method.
+func (m *MockWorklog) GetRunningTimer(userId int) (models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", userId)
+	ret0, _ := ret[0].(models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockWorklogMockRecorder) GetRunningTimer(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockWorklog)(nil).GetRunningTimer), userId)
+}
+
+// GetTimesheet mocks base method.
+func (m *MockWorklog) GetTimesheet(filter models.TimesheetFilter) ([]models.TimesheetEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimesheet", filter)
+	ret0, _ := ret[0].([]models.TimesheetEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimesheet indicates an expected call of GetTimesheet.
+func (mr *MockWorklogMockRecorder) GetTimesheet(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimesheet", reflect.TypeOf((*MockWorklog)(nil).GetTimesheet), filter)
+}
+
+// GetWorklogs mocks base method.
+func (m *MockWorklog) GetWorklogs(taskId int) (models.Worklogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogs", taskId)
+	ret0, _ := ret[0].(models.Worklogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogs indicates an expected call of GetWorklogs.
+func (mr *MockWorklogMockRecorder) GetWorklogs(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogs", reflect.TypeOf((*MockWorklog)(nil).GetWorklogs), taskId)
+}
+
+// StartTimer mocks base method.
+func (m *MockWorklog) StartTimer(worklog models.Worklog) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", worklog)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockWorklogMockRecorder) StartTimer(worklog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockWorklog)(nil).StartTimer), worklog)
+}
+
+// StopTimer mocks base method.
+func (m *MockWorklog) StopTimer(userId int, endedAt time.Time) (models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", userId, endedAt)
+	ret0, _ := ret[0].(models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockWorklogMockRecorder) StopTimer(userId, endedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockWorklog)(nil).StopTimer), userId, endedAt)
+}
+
+// MockRecurrence is a mock of Recurrence interface.
+type MockRecurrence struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurrenceMockRecorder
+}
+
+// MockRecurrenceMockRecorder is the mock recorder for MockRecurrence.
+type MockRecurrenceMockRecorder struct {
+	mock *MockRecurrence
+}
+
+// NewMockRecurrence creates a new mock instance.
+func NewMockRecurrence(ctrl *gomock.Controller) *MockRecurrence {
+	mock := &MockRecurrence{ctrl: ctrl}
+	mock.recorder = &MockRecurrenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurrence) EXPECT() *MockRecurrenceMockRecorder {
+	return m.recorder
+}
+
+// CreateOccurrence mocks base method.
+func (m *MockRecurrence) CreateOccurrence(recurrence models.Recurrence, task models.Task, deadline time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOccurrence", recurrence, task, deadline)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOccurrence indicates an expected call of CreateOccurrence.
+func (mr *MockRecurrenceMockRecorder) CreateOccurrence(recurrence, task, deadline interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOccurrence", reflect.TypeOf((*MockRecurrence)(nil).CreateOccurrence), recurrence, task, deadline)
+}
+
+// CreateRecurrence mocks base method.
+func (m *MockRecurrence) CreateRecurrence(recurrence models.Recurrence) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurrence", recurrence)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurrence indicates an expected call of CreateRecurrence.
+func (mr *MockRecurrenceMockRecorder) CreateRecurrence(recurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurrence", reflect.TypeOf((*MockRecurrence)(nil).CreateRecurrence), recurrence)
+}
+
+// GetDueRecurrences mocks base method.
+func (m *MockRecurrence) GetDueRecurrences(now time.Time) ([]models.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRecurrences", now)
+	ret0, _ := ret[0].([]models.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRecurrences indicates an expected call of GetDueRecurrences.
+func (mr *MockRecurrenceMockRecorder) GetDueRecurrences(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRecurrences", reflect.TypeOf((*MockRecurrence)(nil).GetDueRecurrences), now)
+}
+
+// GetRecurrence mocks base method.
+func (m *MockRecurrence) GetRecurrence(orgId, id int) (models.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", orgId, id)
+	ret0, _ := ret[0].(models.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *MockRecurrenceMockRecorder) GetRecurrence(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*MockRecurrence)(nil).GetRecurrence), orgId, id)
+}
+
+// GetTask mocks base method.
+func (m *MockRecurrence) GetTask(id int) (models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", id)
+	ret0, _ := ret[0].(models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockRecurrenceMockRecorder) GetTask(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockRecurrence)(nil).GetTask), id)
+}
+
+// StopRecurrence mocks base method.
+func (m *MockRecurrence) StopRecurrence(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRecurrence", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopRecurrence indicates an expected call of StopRecurrence.
+func (mr *MockRecurrenceMockRecorder) StopRecurrence(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRecurrence", reflect.TypeOf((*MockRecurrence)(nil).StopRecurrence), id)
+}
+
+// UpdateRecurrence mocks base method.
+func (m *MockRecurrence) UpdateRecurrence(recurrence models.Recurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurrence", recurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecurrence indicates an expected call of UpdateRecurrence.
+func (mr *MockRecurrenceMockRecorder) UpdateRecurrence(recurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurrence", reflect.TypeOf((*MockRecurrence)(nil).UpdateRecurrence), recurrence)
+}
+
+// MockChecklist is a mock of Checklist interface.
+type MockChecklist struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistMockRecorder
+}
+
+// MockChecklistMockRecorder is the mock recorder for MockChecklist.
+type MockChecklistMockRecorder struct {
+	mock *MockChecklist
+}
+
+// NewMockChecklist creates a new mock instance.
+func NewMockChecklist(ctrl *gomock.Controller) *MockChecklist {
+	mock := &MockChecklist{ctrl: ctrl}
+	mock.recorder = &MockChecklistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklist) EXPECT() *MockChecklistMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockChecklist) CreateItem(item models.ChecklistItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", item)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockChecklistMockRecorder) CreateItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockChecklist)(nil).CreateItem), item)
+}
+
+// DeleteItem mocks base method.
+func (m *MockChecklist) DeleteItem(taskId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", taskId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockChecklistMockRecorder) DeleteItem(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockChecklist)(nil).DeleteItem), taskId, id)
+}
+
+// GetItem mocks base method.
+func (m *MockChecklist) GetItem(taskId, id int) (models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItem", taskId, id)
+	ret0, _ := ret[0].(models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockChecklistMockRecorder) GetItem(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockChecklist)(nil).GetItem), taskId, id)
+}
+
+// GetItems mocks base method.
+func (m *MockChecklist) GetItems(taskId int) (models.ChecklistItems, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", taskId)
+	ret0, _ := ret[0].(models.ChecklistItems)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockChecklistMockRecorder) GetItems(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockChecklist)(nil).GetItems), taskId)
+}
+
+// MoveItem mocks base method.
+func (m *MockChecklist) MoveItem(move models.ChecklistMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockChecklistMockRecorder) MoveItem(move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockChecklist)(nil).MoveItem), move)
+}
+
+// UpdateItem mocks base method.
+func (m *MockChecklist) UpdateItem(item models.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockChecklistMockRecorder) UpdateItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockChecklist)(nil).UpdateItem), item)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// CreateEntry mocks base method.
+func (m *MockAudit) CreateEntry(entry models.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockAuditMockRecorder) CreateEntry(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockAudit)(nil).CreateEntry), entry)
+}
+
+// GetEntries mocks base method.
+func (m *MockAudit) GetEntries(filter models.AuditFilter) (models.AuditEntries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", filter)
+	ret0, _ := ret[0].(models.AuditEntries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditMockRecorder) GetEntries(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAudit)(nil).GetEntries), filter)
+}
+
+// GetState mocks base method.
+func (m *MockAudit) GetState(entityType string, id int) (models.JSONMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", entityType, id)
+	ret0, _ := ret[0].(models.JSONMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockAuditMockRecorder) GetState(entityType, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockAudit)(nil).GetState), entityType, id)
+}
+
+// MockActivity is a mock of Activity interface.
+type MockActivity struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityMockRecorder
+}
+
+// MockActivityMockRecorder is the mock recorder for MockActivity.
+type MockActivityMockRecorder struct {
+	mock *MockActivity
+}
+
+// NewMockActivity creates a new mock instance.
+func NewMockActivity(ctrl *gomock.Controller) *MockActivity {
+	mock := &MockActivity{ctrl: ctrl}
+	mock.recorder = &MockActivityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivity) EXPECT() *MockActivityMockRecorder {
+	return m.recorder
+}
+
+// CreateActivity mocks base method.
+func (m *MockActivity) CreateActivity(activity models.Activity) (models.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActivity", activity)
+	ret0, _ := ret[0].(models.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActivity indicates an expected call of CreateActivity.
+func (mr *MockActivityMockRecorder) CreateActivity(activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockActivity)(nil).CreateActivity), activity)
+}
+
+// GetActivities mocks base method.
+func (m *MockActivity) GetActivities(filter models.ActivityFilter) (models.Activities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivities", filter)
+	ret0, _ := ret[0].(models.Activities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivities indicates an expected call of GetActivities.
+func (mr *MockActivityMockRecorder) GetActivities(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivities", reflect.TypeOf((*MockActivity)(nil).GetActivities), filter)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(comment models.TaskComment) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", comment)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), comment)
+}
+
+// GetComments mocks base method.
+func (m *MockComment) GetComments(taskId int) (models.TaskComments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", taskId)
+	ret0, _ := ret[0].(models.TaskComments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), taskId)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// ClaimEmails mocks base method.
+func (m *MockNotification) ClaimEmails(mode string, after, before, now time.Time, lease time.Duration, limit int) ([]models.PendingEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEmails", mode, after, before, now, lease, limit)
+	ret0, _ := ret[0].([]models.PendingEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEmails indicates an expected call of ClaimEmails.
+func (mr *MockNotificationMockRecorder) ClaimEmails(mode, after, before, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEmails", reflect.TypeOf((*MockNotification)(nil).ClaimEmails), mode, after, before, now, lease, limit)
+}
+
+// CountUnread mocks base method.
+func (m *MockNotification) CountUnread(userId int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationMockRecorder) CountUnread(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotification)(nil).CountUnread), userId)
+}
+
+// CreateNotifications mocks base method.
+func (m *MockNotification) CreateNotifications(notification models.Notification, userIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", notification, userIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockNotificationMockRecorder) CreateNotifications(notification, userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockNotification)(nil).CreateNotifications), notification, userIds)
+}
+
+// ExpireInstantEmails mocks base method.
+func (m *MockNotification) ExpireInstantEmails(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireInstantEmails", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireInstantEmails indicates an expected call of ExpireInstantEmails.
+func (mr *MockNotificationMockRecorder) ExpireInstantEmails(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireInstantEmails", reflect.TypeOf((*MockNotification)(nil).ExpireInstantEmails), before)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotification) GetNotifications(filter models.NotificationFilter) (models.Notifications, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", filter)
+	ret0, _ := ret[0].(models.Notifications)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationMockRecorder) GetNotifications(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotification)(nil).GetNotifications), filter)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotification) GetPreferences(userId int) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userId)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationMockRecorder) GetPreferences(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotification)(nil).GetPreferences), userId)
+}
+
+// GetTaskFollowers mocks base method.
+func (m *MockNotification) GetTaskFollowers(taskId int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskFollowers", taskId)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskFollowers indicates an expected call of GetTaskFollowers.
+func (mr *MockNotificationMockRecorder) GetTaskFollowers(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskFollowers", reflect.TypeOf((*MockNotification)(nil).GetTaskFollowers), taskId)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userId int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(userId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), userId, id)
+}
+
+// SetEmailStatus mocks base method.
+func (m *MockNotification) SetEmailStatus(ids []int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailStatus", ids, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailStatus indicates an expected call of SetEmailStatus.
+func (mr *MockNotificationMockRecorder) SetEmailStatus(ids, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailStatus", reflect.TypeOf((*MockNotification)(nil).SetEmailStatus), ids, status)
+}
+
+// SetPreferences mocks base method.
+func (m *MockNotification) SetPreferences(preferences []models.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreferences", preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreferences indicates an expected call of SetPreferences.
+func (mr *MockNotificationMockRecorder) SetPreferences(preferences interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreferences", reflect.TypeOf((*MockNotification)(nil).SetPreferences), preferences)
+}
+
+// SkipMutedEmails mocks base method.
+func (m *MockNotification) SkipMutedEmails() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipMutedEmails")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkipMutedEmails indicates an expected call of SkipMutedEmails.
+func (mr *MockNotificationMockRecorder) SkipMutedEmails() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipMutedEmails", reflect.TypeOf((*MockNotification)(nil).SkipMutedEmails))
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhook) ClaimDeliveries(now time.Time, lease time.Duration, limit int) ([]models.DueDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", now, lease, limit)
+	ret0, _ := ret[0].([]models.DueDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookMockRecorder) ClaimDeliveries(now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhook)(nil).ClaimDeliveries), now, lease, limit)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockWebhook) CreateDeliveries(orgId, projectId int, event string, payload models.JSONMap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", orgId, projectId, event, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockWebhookMockRecorder) CreateDeliveries(orgId, projectId, event, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockWebhook)(nil).CreateDeliveries), orgId, projectId, event, payload)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhook) CreateWebhook(webhook models.Webhook) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", webhook)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookMockRecorder) CreateWebhook(webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhook)(nil).CreateWebhook), webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), orgId, id)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(webhookId, beforeId, limit int) (models.WebhookDeliveries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", webhookId, beforeId, limit)
+	ret0, _ := ret[0].(models.WebhookDeliveries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(webhookId, beforeId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), webhookId, beforeId, limit)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhook) GetWebhook(orgId, id int) (models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", orgId, id)
+	ret0, _ := ret[0].(models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookMockRecorder) GetWebhook(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhook)(nil).GetWebhook), orgId, id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(orgId int) (models.Webhooks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", orgId)
+	ret0, _ := ret[0].(models.Webhooks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), orgId)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhook) Redeliver(webhookId, deliveryId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", webhookId, deliveryId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookMockRecorder) Redeliver(webhookId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhook)(nil).Redeliver), webhookId, deliveryId)
+}
+
+// SaveAttempt mocks base method.
+func (m *MockWebhook) SaveAttempt(delivery models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAttempt", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAttempt indicates an expected call of SaveAttempt.
+func (mr *MockWebhookMockRecorder) SaveAttempt(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAttempt", reflect.TypeOf((*MockWebhook)(nil).SaveAttempt), delivery)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhook) UpdateWebhook(webhook models.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookMockRecorder) UpdateWebhook(webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhook)(nil).UpdateWebhook), webhook)
+}
+
+// MockDeadline is a mock of Deadline interface.
+type MockDeadline struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadlineMockRecorder
+}
+
+// MockDeadlineMockRecorder is the mock recorder for MockDeadline.
+type MockDeadlineMockRecorder struct {
+	mock *MockDeadline
+}
+
+// NewMockDeadline creates a new mock instance.
+func NewMockDeadline(ctrl *gomock.Controller) *MockDeadline {
+	mock := &MockDeadline{ctrl: ctrl}
+	mock.recorder = &MockDeadlineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadline) EXPECT() *MockDeadlineMockRecorder {
+	return m.recorder
+}
+
+// MarkOverdue mocks base method.
+func (m *MockDeadline) MarkOverdue(now time.Time) ([]models.OverdueMark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdue", now)
+	ret0, _ := ret[0].([]models.OverdueMark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockDeadlineMockRecorder) MarkOverdue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockDeadline)(nil).MarkOverdue), now)
+}
+
+// NotifyProjects mocks base method.
+func (m *MockDeadline) NotifyProjects(notificationType string, hours int, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyProjects", notificationType, hours, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyProjects indicates an expected call of NotifyProjects.
+func (mr *MockDeadlineMockRecorder) NotifyProjects(notificationType, hours, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyProjects", reflect.TypeOf((*MockDeadline)(nil).NotifyProjects), notificationType, hours, from, to)
+}
+
+// NotifyTasks mocks base method.
+func (m *MockDeadline) NotifyTasks(notificationType string, hours int, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyTasks", notificationType, hours, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyTasks indicates an expected call of NotifyTasks.
+func (mr *MockDeadlineMockRecorder) NotifyTasks(notificationType, hours, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTasks", reflect.TypeOf((*MockDeadline)(nil).NotifyTasks), notificationType, hours, from, to)
+}
+
+// MockTemplate is a mock of Template interface.
+type MockTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateMockRecorder
+}
+
+// MockTemplateMockRecorder is the mock recorder for MockTemplate.
+type MockTemplateMockRecorder struct {
+	mock *MockTemplate
+}
+
+// NewMockTemplate creates a new mock instance.
+func NewMockTemplate(ctrl *gomock.Controller) *MockTemplate {
+	mock := &MockTemplate{ctrl: ctrl}
+	mock.recorder = &MockTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplate) EXPECT() *MockTemplateMockRecorder {
+	return m.recorder
+}
+
+// CreateFromTemplate mocks base method.
+func (m *MockTemplate) CreateFromTemplate(project models.Project, content models.TemplateContent, start time.Time) (models.TemplateProject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFromTemplate", project, content, start)
+	ret0, _ := ret[0].(models.TemplateProject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFromTemplate indicates an expected call of CreateFromTemplate.
+func (mr *MockTemplateMockRecorder) CreateFromTemplate(project, content, start interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromTemplate", reflect.TypeOf((*MockTemplate)(nil).CreateFromTemplate), project, content, start)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockTemplate) CreateTemplate(template models.ProjectTemplate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", template)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockTemplateMockRecorder) CreateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockTemplate)(nil).CreateTemplate), template)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTemplate) DeleteTemplate(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTemplateMockRecorder) DeleteTemplate(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplate)(nil).DeleteTemplate), orgId, id)
+}
+
+// GetProjectSnapshot mocks base method.
+func (m *MockTemplate) GetProjectSnapshot(projectId int) (models.ProjectSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectSnapshot", projectId)
+	ret0, _ := ret[0].(models.ProjectSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectSnapshot indicates an expected call of GetProjectSnapshot.
+func (mr *MockTemplateMockRecorder) GetProjectSnapshot(projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectSnapshot", reflect.TypeOf((*MockTemplate)(nil).GetProjectSnapshot), projectId)
+}
+
+// GetTemplate mocks base method.
+func (m *MockTemplate) GetTemplate(orgId, id int) (models.ProjectTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", orgId, id)
+	ret0, _ := ret[0].(models.ProjectTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockTemplateMockRecorder) GetTemplate(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTemplate)(nil).GetTemplate), orgId, id)
+}
+
+// GetTemplates mocks base method.
+func (m *MockTemplate) GetTemplates(orgId int) (models.ProjectTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", orgId)
+	ret0, _ := ret[0].(models.ProjectTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockTemplateMockRecorder) GetTemplates(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockTemplate)(nil).GetTemplates), orgId)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockTemplate) UpdateTemplate(template models.ProjectTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockTemplateMockRecorder) UpdateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTemplate)(nil).UpdateTemplate), template)
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"time"
+)
+
+type NotificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// CreateNotifications adds the notification to the inbox of every active
+// user of the list. A notification about a task takes its project from the
+// task, and the title of the task and the name of the project into its data,
+// as they are now; one about a project takes the name of the project.
+// Notifications whose key a user already has are skipped.
+func (n *NotificationRepo) CreateNotifications(notification models.Notification, userIds []int) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	if notification.TaskId != nil {
+		return n.db.Exec("INSERT INTO notifications (user_id, type, actor_id, task_id, project_id, data, key, "+
+			"created_at) "+
+			"SELECT users.id, ?, ?, tasks.id, tasks.project_id, "+
+			"?::jsonb || jsonb_build_object('title', tasks.title, 'project', projects.name), ?, now() "+
+			"FROM users, tasks INNER JOIN projects ON projects.id = tasks.project_id "+
+			"WHERE tasks.id = ? AND users.id IN ? AND users.is_active = ? ON CONFLICT DO NOTHING",
+			notification.Type, notification.ActorId, notification.Data, notification.Key, *notification.TaskId,
+			userIds, true).Error
+	}
+
+	if notification.ProjectId != nil {
+		return n.db.Exec("INSERT INTO notifications (user_id, type, actor_id, project_id, data, key, created_at) "+
+			"SELECT users.id, ?, ?, projects.id, ?::jsonb || jsonb_build_object('project', projects.name), ?, now() "+
+			"FROM users, projects WHERE projects.id = ? AND users.id IN ? AND users.is_active = ? "+
+			"ON CONFLICT DO NOTHING",
+			notification.Type, notification.ActorId, notification.Data, notification.Key, *notification.ProjectId,
+			userIds, true).Error
+	}
+
+	return n.db.Exec("INSERT INTO notifications (user_id, type, actor_id, data, key, created_at) "+
+		"SELECT users.id, ?, ?, ?::jsonb, ?, now() FROM users WHERE users.id IN ? AND users.is_active = ? "+
+		"ON CONFLICT DO NOTHING",
+		notification.Type, notification.ActorId, notification.Data, notification.Key, userIds, true).Error
+}
+
+// GetTaskFollowers returns who follows the task: the one who set it, its
+// executor and everyone assigned to it in any role.
+func (n *NotificationRepo) GetTaskFollowers(taskId int) ([]int, error) {
+	var ids []int
+	err := n.db.Raw("SELECT controller_id FROM tasks WHERE id = ? "+
+		"UNION SELECT executor_id FROM tasks WHERE id = ? AND executor_id IS NOT NULL "+
+		"UNION SELECT user_id FROM task_assignees WHERE task_id = ?", taskId, taskId, taskId).
+		Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (n *NotificationRepo) GetNotifications(filter models.NotificationFilter) (models.Notifications, error) {
+	query := n.db.Model(&models.Notification{}).
+		Joins("left join users actors on actors.id = notifications.actor_id").
+		Select([]string{"notifications.id", "notifications.type", "notifications.actor_id",
+			"COALESCE(actors.firstname, '')", "notifications.task_id", "notifications.project_id",
+			"notifications.data", "notifications.read_at", "notifications.created_at"}).
+		Where("notifications.user_id = ?", filter.UserId)
+
+	if filter.UnreadOnly {
+		query = query.Where("notifications.read_at IS NULL")
+	}
+
+	if filter.BeforeId != 0 {
+		query = query.Where("notifications.id < ?", filter.BeforeId)
+	}
+
+	rows, err := query.Order("notifications.id DESC").Limit(filter.Limit).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := models.Notifications{}
+	for rows.Next() {
+		var notification models.Notification
+		err := rows.Scan(&notification.ID, &notification.Type, &notification.ActorId, &notification.ActorName,
+			&notification.TaskId, &notification.ProjectId, &notification.Data, &notification.ReadAt,
+			&notification.CreatedAt)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (n *NotificationRepo) CountUnread(userId int) (int64, error) {
+	var count int64
+	err := n.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (n *NotificationRepo) MarkRead(userId, id int) error {
+	result := n.db.Model(&models.Notification{}).Where("id = ? AND user_id = ?", id, userId).
+		Update("read_at", gorm.Expr("COALESCE(read_at, now())"))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (n *NotificationRepo) MarkAllRead(userId int) (int64, error) {
+	result := n.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now())
+
+	return result.RowsAffected, result.Error
+}
+
+// SkipMutedEmails marks the pending notifications their users don't want
+// emails about as not to be emailed.
+func (n *NotificationRepo) SkipMutedEmails() (int64, error) {
+	result := n.db.Model(&models.Notification{}).
+		Where("email_status = ? AND EXISTS (SELECT 1 FROM notification_preferences WHERE "+
+			"notification_preferences.user_id = notifications.user_id AND "+
+			"notification_preferences.type = notifications.type AND notification_preferences.email = ?)",
+			models.EmailPending, models.EmailOff).
+		Update("email_status", models.EmailSkipped)
+
+	return result.RowsAffected, result.Error
+}
+
+// ExpireInstantEmails gives up on the pending instant emails of the
+// notifications created by before, which are too old to be worth sending.
+func (n *NotificationRepo) ExpireInstantEmails(before time.Time) (int64, error) {
+	result := n.db.Model(&models.Notification{}).
+		Where("email_status = ? AND created_at <= ? AND NOT EXISTS (SELECT 1 FROM notification_preferences "+
+			"WHERE notification_preferences.user_id = notifications.user_id AND "+
+			"notification_preferences.type = notifications.type AND notification_preferences.email <> ?)",
+			models.EmailPending, before, models.EmailInstant).
+		Update("email_status", models.EmailExpired)
+
+	return result.RowsAffected, result.Error
+}
+
+// ClaimEmails leases to the caller, until now plus lease, the notifications
+// created between after and before that are still to be emailed in the given
+// mode, instantly or in the digest, and returns them by user. Without a
+// preference, notifications are emailed instantly. Notifications another
+// sender holds are skipped, so each is emailed once however many senders
+// run. A limit of 0 claims them all.
+func (n *NotificationRepo) ClaimEmails(mode string, after, before, now time.Time, lease time.Duration,
+	limit int) ([]models.PendingEmail, error) {
+	var emails []models.PendingEmail
+	err := n.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Notification{}).
+			Joins("inner join users on users.id = notifications.user_id").
+			Joins("left join notification_preferences on notification_preferences.user_id = "+
+				"notifications.user_id AND notification_preferences.type = notifications.type").
+			Where("notifications.email_status = ? AND COALESCE(notification_preferences.email, ?) = ? AND "+
+				"users.is_active = ? AND notifications.created_at > ? AND notifications.created_at <= ? AND "+
+				"(notifications.email_leased_until IS NULL OR notifications.email_leased_until <= ?)",
+				models.EmailPending, models.EmailInstant, mode, true, after, before, now).
+			Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "notifications"},
+				Options: "SKIP LOCKED"}).
+			Order("notifications.user_id, notifications.id")
+
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+
+		var ids []int
+		if err := query.Pluck("notifications.id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		err := tx.Model(&models.Notification{}).Where("id IN ?", ids).
+			Update("email_leased_until", now.Add(lease)).Error
+		if err != nil {
+			return err
+		}
+
+		emails, err = pendingEmails(tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+// pendingEmails returns the notifications with what they are emailed with,
+// by user.
+func pendingEmails(db *gorm.DB, ids []int) ([]models.PendingEmail, error) {
+	rows, err := db.Model(&models.Notification{}).
+		Joins("inner join users on users.id = notifications.user_id").
+		Joins("left join users actors on actors.id = notifications.actor_id").
+		Select([]string{"notifications.id", "notifications.user_id", "notifications.type", "notifications.actor_id",
+			"COALESCE(actors.firstname, '')", "notifications.task_id", "notifications.data",
+			"notifications.created_at", "users.email", "users.firstname"}).
+		Where("notifications.id IN ?", ids).
+		Order("notifications.user_id, notifications.id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []models.PendingEmail
+	for rows.Next() {
+		var email models.PendingEmail
+		err := rows.Scan(&email.ID, &email.UserId, &email.Type, &email.ActorId, &email.ActorName, &email.TaskId,
+			&email.Data, &email.CreatedAt, &email.Email, &email.Firstname)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		emails = append(emails, email)
+	}
+
+	return emails, rows.Err()
+}
+
+func (n *NotificationRepo) SetEmailStatus(ids []int, status string) error {
+	return n.db.Model(&models.Notification{}).Where("id IN ?", ids).Update("email_status", status).Error
+}
+
+func (n *NotificationRepo) GetPreferences(userId int) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := n.db.Where("user_id = ?", userId).Order("type").Find(&preferences).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (n *NotificationRepo) SetPreferences(preferences []models.NotificationPreference) error {
+	return n.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email"}),
+	}).Create(&preferences).Error
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNotificationRepo_ClaimEmails(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewNotificationRepo(tx)
+
+	fresh := models.Notification{UserId: task.ControllerId, Type: models.NotificationCommented}
+	old := models.Notification{UserId: task.ControllerId, Type: models.NotificationDeadline}
+	assert.NoError(t, tx.Create(&fresh).Error)
+	assert.NoError(t, tx.Create(&old).Error)
+
+	now := time.Now()
+	assert.NoError(t, tx.Model(&old).Update("created_at", now.Add(-48*time.Hour)).Error)
+
+	expired, err := repo.ExpireInstantEmails(now.Add(-24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), expired)
+
+	claim := func(at time.Time) []int {
+		emails, err := repo.ClaimEmails(models.EmailInstant, now.Add(-24*time.Hour), at, at, time.Minute, 10)
+		assert.NoError(t, err)
+
+		var ids []int
+		for _, email := range emails {
+			ids = append(ids, email.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int{fresh.ID}, claim(now))
+	assert.Empty(t, claim(now.Add(30*time.Second)), "claimed emails are left alone")
+	assert.Equal(t, []int{fresh.ID}, claim(now.Add(2*time.Minute)), "the claim runs out")
+
+	assert.NoError(t, repo.SetEmailStatus([]int{fresh.ID}, models.EmailSent))
+	assert.Empty(t, claim(now.Add(5*time.Minute)))
+}
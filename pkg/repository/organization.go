@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+)
+
+type OrganizationRepo struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepo(db *gorm.DB) *OrganizationRepo {
+	return &OrganizationRepo{db: db}
+}
+
+func (o *OrganizationRepo) CreateOrganization(org models.Organization, ownerId int) (int, error) {
+	err := o.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&org).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.OrganizationMember{
+			OrganizationId: org.ID,
+			UserId:         ownerId,
+			Role:           "owner",
+		}).Error
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return org.ID, nil
+}
+
+func (o *OrganizationRepo) GetOrganizations(userId int) (models.Organizations, error) {
+	var orgs models.Organizations
+	rows, err := o.db.Model(&models.Organization{}).
+		Joins("inner join organization_members on organization_members.organization_id = organizations.id").
+		Select([]string{"organizations.id", "organizations.name", "organizations.slug", "organizations.settings",
+			"organization_members.role"}).
+		Where("organization_members.user_id = ? AND organizations.is_active = ?", userId, true).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var org models.Organization
+		err := rows.Scan(&org.ID, &org.Name, &org.Slug, &org.Settings, &org.Role)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		orgs = append(orgs, org)
+	}
+
+	return orgs, nil
+}
+
+func (o *OrganizationRepo) GetOrganization(orgId int) (models.Organization, error) {
+	var org models.Organization
+	err := o.db.Where("id = ? AND is_active = ?", orgId, true).First(&org).Error
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	return org, nil
+}
+
+func (o *OrganizationRepo) IsSlugUsed(slug string) bool {
+	var count int64
+	err := o.db.Model(&models.Organization{}).Where("slug = ?", slug).Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+func (o *OrganizationRepo) UpdateOrganization(org models.Organization) error {
+	return o.db.Model(&models.Organization{}).Where("id = ? AND is_active = ?", org.ID, true).
+		Updates(map[string]any{"name": org.Name, "settings": org.Settings}).Error
+}
+
+func (o *OrganizationRepo) GetMember(orgId, userId int) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := o.db.Joins("inner join organizations on organizations.id = organization_members.organization_id").
+		Where("organization_members.organization_id = ? AND organization_members.user_id = ? AND organizations.is_active = ?",
+			orgId, userId, true).
+		First(&member).Error
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	return member, nil
+}
+
+func (o *OrganizationRepo) GetMembers(orgId int) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	rows, err := o.db.Model(&models.OrganizationMember{}).
+		Joins("inner join users on organization_members.user_id = users.id").
+		Select([]string{"organization_members.id", "organization_members.organization_id", "organization_members.user_id",
+			"organization_members.role", "users.firstname", "users.lastname", "users.email"}).
+		Where("organization_members.organization_id = ? AND users.is_active = ?", orgId, true).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m models.OrganizationMember
+		err := rows.Scan(&m.ID, &m.OrganizationId, &m.UserId, &m.Role, &m.Firstname, &m.Lastname, &m.Email)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+func (o *OrganizationRepo) AddMember(member models.OrganizationMember) error {
+	return o.db.Create(&member).Error
+}
+
+func (o *OrganizationRepo) UpdateMemberRole(orgId, userId int, role string) error {
+	tx := o.db.Model(&models.OrganizationMember{}).Where("organization_id = ? AND user_id = ?", orgId, userId).
+		Update("role", role)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepo) CountOwners(orgId int) (int64, error) {
+	var count int64
+	err := o.db.Model(&models.OrganizationMember{}).Where("organization_id = ? AND role = ?", orgId, "owner").
+		Count(&count).Error
+
+	return count, err
+}
+
+// RemoveMember also drops the user from every project of the organization so
+// nothing in it stays visible to them.
+func (o *OrganizationRepo) RemoveMember(orgId, userId int) error {
+	return o.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("participant_id = ? AND project_id IN (?)", userId,
+			tx.Model(&models.Project{}).Select("id").Where("organization_id = ?", orgId)).
+			Delete(&models.ProjectParticipant{}).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Where("organization_id = ? AND user_id = ?", orgId, userId).Delete(&models.OrganizationMember{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
@@ -23,20 +23,23 @@ func (p *ProjectRepo) CreateProject(project models.Project) (int, error) {
 	return project.ID, nil
 }
 
-func (p *ProjectRepo) GetAllProjects(userId int) (models.Projects, error) {
+func (p *ProjectRepo) GetAllProjects(orgId, userId int) (models.Projects, error) {
 	var projects models.Projects
 	rows, err := p.db.Model(&models.Project{}).Joins("inner join users on projects.manager_id = users.id").
-		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department",
-			"projects.status", "projects.start_date", "projects.deadline", "users.firstname"}).
-		Where("projects.is_active = ? AND projects.manager_id = ?", true, userId).Rows()
+		Joins("left join departments on projects.department_id = departments.id").
+		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department_id",
+			"COALESCE(departments.name, '')", "projects.status", "projects.start_date", "projects.deadline",
+			"projects.is_overdue", "users.firstname"}).
+		Where("projects.organization_id = ? AND projects.is_active = ? AND projects.manager_id = ?",
+			orgId, true, userId).Rows()
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
 		var pro models.Project
-		err := rows.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.Department, &pro.Status, &pro.StartDate,
-			&pro.Deadline, &pro.ManagerName)
+		err := rows.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.DepartmentId, &pro.DepartmentName, &pro.Status,
+			&pro.StartDate, &pro.Deadline, &pro.IsOverdue, &pro.ManagerName)
 		if err != nil {
 			log.Println("error while scanning from row")
 			return nil, err
@@ -48,16 +51,18 @@ func (p *ProjectRepo) GetAllProjects(userId int) (models.Projects, error) {
 	return projects, nil
 }
 
-func (p *ProjectRepo) GetProjectById(userId, projectId int) (models.Project, error) {
+func (p *ProjectRepo) GetProjectById(orgId, userId, projectId int) (models.Project, error) {
 	var pro models.Project
 	row := p.db.Model(&models.Project{}).Joins("inner join users on projects.manager_id = users.id").
-		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department",
-			"projects.status", "projects.start_date", "projects.deadline", "users.firstname"}).
-		Where("projects.is_active = ? AND projects.manager_id = ? AND projects.id = ?",
-			true, userId, projectId).Row()
-
-	if err := row.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.Department, &pro.Status, &pro.StartDate,
-		&pro.Deadline, &pro.ManagerName); err != nil {
+		Joins("left join departments on projects.department_id = departments.id").
+		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department_id",
+			"COALESCE(departments.name, '')", "projects.status", "projects.start_date", "projects.deadline",
+			"projects.is_overdue", "users.firstname"}).
+		Where("projects.organization_id = ? AND projects.is_active = ? AND projects.manager_id = ? AND projects.id = ?",
+			orgId, true, userId, projectId).Row()
+
+	if err := row.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.DepartmentId, &pro.DepartmentName, &pro.Status,
+		&pro.StartDate, &pro.Deadline, &pro.IsOverdue, &pro.ManagerName); err != nil {
 		return models.Project{}, err
 	}
 
@@ -65,7 +70,11 @@ func (p *ProjectRepo) GetProjectById(userId, projectId int) (models.Project, err
 }
 
 func (p *ProjectRepo) UpdateProject(project models.Project) error {
-	err := p.db.Where("projects.id = ? AND projects.manager_id = ?", project.ID, project.ManagerID).
+	// Select("*") keeps Save from falling back to an upsert when nothing matches.
+	// Whether the project is overdue is left to the deadline scheduler.
+	err := p.db.Select("*").Omit("is_overdue").
+		Where("projects.id = ? AND projects.manager_id = ? AND projects.organization_id = ?",
+			project.ID, project.ManagerID, project.OrganizationId).
 		Save(&project).Error
 	if err != nil {
 		return err
@@ -74,9 +83,9 @@ func (p *ProjectRepo) UpdateProject(project models.Project) error {
 	return nil
 }
 
-func (p *ProjectRepo) DeleteProject(userId, projectId int) error {
-	err := p.db.Model(&models.Project{}).Where("id = ? AND manager_id = ? AND is_active = ?",
-		projectId, userId, true).
+func (p *ProjectRepo) DeleteProject(orgId, userId, projectId int) error {
+	err := p.db.Model(&models.Project{}).Where("id = ? AND organization_id = ? AND manager_id = ? AND is_active = ?",
+		projectId, orgId, userId, true).
 		Update("is_active", false).Error
 
 	if err != nil {
@@ -86,20 +95,23 @@ func (p *ProjectRepo) DeleteProject(userId, projectId int) error {
 	return nil
 }
 
-func (p *ProjectRepo) GetDeletedProjects(userId int) (models.Projects, error) {
+func (p *ProjectRepo) GetDeletedProjects(orgId, userId int) (models.Projects, error) {
 	var projects models.Projects
 	rows, err := p.db.Model(&models.Project{}).Joins("inner join users on projects.manager_id = users.id").
-		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department",
-			"projects.status", "projects.start_date", "projects.deadline", "users.firstname"}).
-		Where("projects.is_active = ? AND projects.manager_id = ?", false, userId).Rows()
+		Joins("left join departments on projects.department_id = departments.id").
+		Select([]string{"projects.id", "projects.name", "projects.description", "projects.department_id",
+			"COALESCE(departments.name, '')", "projects.status", "projects.start_date", "projects.deadline",
+			"projects.is_overdue", "users.firstname"}).
+		Where("projects.organization_id = ? AND projects.is_active = ? AND projects.manager_id = ?",
+			orgId, false, userId).Rows()
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
 		var pro models.Project
-		err := rows.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.Department, &pro.Status, &pro.StartDate,
-			&pro.Deadline, &pro.ManagerName)
+		err := rows.Scan(&pro.ID, &pro.Name, &pro.Description, &pro.DepartmentId, &pro.DepartmentName, &pro.Status,
+			&pro.StartDate, &pro.Deadline, &pro.IsOverdue, &pro.ManagerName)
 		if err != nil {
 			log.Println("error while scanning from row")
 			return nil, err
@@ -111,8 +123,9 @@ func (p *ProjectRepo) GetDeletedProjects(userId int) (models.Projects, error) {
 	return projects, nil
 }
 
-func (p *ProjectRepo) RestoreProject(userId, projectId int) error {
-	err := p.db.Model(&models.Project{}).Where("id = ? AND manager_id = ?", projectId, userId).
+func (p *ProjectRepo) RestoreProject(orgId, userId, projectId int) error {
+	err := p.db.Model(&models.Project{}).Where("id = ? AND organization_id = ? AND manager_id = ?",
+		projectId, orgId, userId).
 		Update("is_active", true).Error
 	if err != nil {
 		return err
@@ -121,11 +134,11 @@ func (p *ProjectRepo) RestoreProject(userId, projectId int) error {
 	return nil
 }
 
-func (p *ProjectRepo) AddUserToProject(propar models.ProjectParticipant) error {
+func (p *ProjectRepo) AddUserToProject(propar models.ProjectParticipant) (int, error) {
 	err := p.db.Create(&propar).Error
 	if err != nil {
-		return err
+		return -1, err
 	}
 
-	return nil
+	return propar.ID, nil
 }
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ErrOccurrenceTaken means another scheduler made the occurrence first or
+// the series was stopped meanwhile.
+var ErrOccurrenceTaken = errors.New("occurrence was already created")
+
+type RecurrenceRepo struct {
+	db *gorm.DB
+}
+
+func NewRecurrenceRepo(db *gorm.DB) *RecurrenceRepo {
+	return &RecurrenceRepo{db: db}
+}
+
+// CreateRecurrence saves the rule and makes its last task the first
+// occurrence.
+func (r *RecurrenceRepo) CreateRecurrence(recurrence models.Recurrence) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&recurrence).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Task{}).Where("id = ?", recurrence.LastTaskId).
+			Update("recurrence_id", recurrence.ID).Error
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return recurrence.ID, nil
+}
+
+func (r *RecurrenceRepo) GetRecurrence(orgId, id int) (models.Recurrence, error) {
+	var recurrence models.Recurrence
+	err := r.db.Where("id = ? AND organization_id = ?", id, orgId).First(&recurrence).Error
+	if err != nil {
+		return models.Recurrence{}, err
+	}
+
+	return recurrence, nil
+}
+
+func (r *RecurrenceRepo) UpdateRecurrence(recurrence models.Recurrence) error {
+	return r.db.Model(&models.Recurrence{}).
+		Where("id = ? AND organization_id = ?", recurrence.ID, recurrence.OrganizationId).
+		Updates(map[string]any{"rule": recurrence.Rule, "mode": recurrence.Mode, "start_at": recurrence.StartAt,
+			"occurrences": recurrence.Occurrences, "is_active": true}).Error
+}
+
+func (r *RecurrenceRepo) StopRecurrence(id int) error {
+	return r.db.Model(&models.Recurrence{}).Where("id = ?", id).Update("is_active", false).Error
+}
+
+// GetDueRecurrences returns the active rules whose next occurrence is due:
+// their last task is done, or, for rules on schedule, its deadline has come.
+func (r *RecurrenceRepo) GetDueRecurrences(now time.Time) ([]models.Recurrence, error) {
+	var recurrences []models.Recurrence
+	err := r.db.Model(&models.Recurrence{}).Joins("inner join tasks on recurrences.last_task_id = tasks.id").
+		Where("recurrences.is_active = ?", true).
+		Where("(recurrences.mode = ? AND tasks.status = ?) OR "+
+			"(recurrences.mode = ? AND recurrences.last_deadline <= ?)",
+			models.RecurrenceOnComplete, models.StatusDone, models.RecurrenceOnSchedule, now).
+		Order("recurrences.id").Find(&recurrences).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrences, nil
+}
+
+func (r *RecurrenceRepo) GetTask(id int) (models.Task, error) {
+	var task models.Task
+	err := r.db.Where("id = ?", id).First(&task).Error
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	return task, nil
+}
+
+// CreateOccurrence adds the next task of the series with the labels and
+// assignees of the last one. The series only moves on from the last task it
+// was read with, so each occurrence is made once however many schedulers run.
+// The checklist of the last task is copied unchecked. The task goes to the
+// bottom of its column, unless the column is at its WIP limit.
+func (r *RecurrenceRepo) CreateOccurrence(recurrence models.Recurrence, task models.Task,
+	deadline time.Time) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := placeInColumn(tx, &task); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+
+		err := tx.Exec("INSERT INTO task_labels (task_id, label_id) SELECT ?, label_id FROM task_labels "+
+			"WHERE task_id = ?", task.ID, recurrence.LastTaskId).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("INSERT INTO task_assignees (task_id, user_id, role) SELECT ?, user_id, role FROM task_assignees "+
+			"WHERE task_id = ?", task.ID, recurrence.LastTaskId).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("INSERT INTO checklist_items (task_id, title, is_checked, rank, created_at, updated_at) "+
+			"SELECT ?, title, false, rank, now(), now() FROM checklist_items WHERE task_id = ?",
+			task.ID, recurrence.LastTaskId).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Model(&models.Recurrence{}).
+			Where("id = ? AND last_task_id = ? AND is_active = ?", recurrence.ID, recurrence.LastTaskId, true).
+			Updates(map[string]any{"last_task_id": task.ID, "last_deadline": deadline,
+				"occurrences": gorm.Expr("occurrences + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return ErrOccurrenceTaken
+		}
+
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return task.ID, nil
+}
@@ -3,12 +3,17 @@ package repository
 import (
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"gorm.io/gorm"
+	"time"
 )
 
+//go:generate mockgen -source=repository.go -destination=mocks/mock.go
+
 type Authorization interface {
 	CreateUser(user *models.User) (int, error)
 	GetUser(email string) (models.User, error)
 	IsEmailUsed(email string) bool
+	GetUserById(id int) (models.User, error)
+	CreateImpersonationLog(entry models.ImpersonationLog) error
 }
 
 type User interface {
@@ -16,28 +21,249 @@ type User interface {
 	UpdateUser(newUser models.User) error
 	DeleteUser(id int) error
 	RestoreUser(id int) error
-	GetProjects(userId int) ([]models.ProjectParticipant, error)
-	GetTasks(userId int) (models.Tasks, error)
+	GetProjects(orgId, userId int) ([]models.UserProject, error)
+	GetTasks(orgId, userId int) (models.Tasks, error)
 }
 
 type Project interface {
 	CreateProject(project models.Project) (int, error)
-	GetAllProjects(userId int) (models.Projects, error)
-	GetProjectById(userId, projectId int) (models.Project, error)
+	GetAllProjects(orgId, userId int) (models.Projects, error)
+	GetProjectById(orgId, userId, projectId int) (models.Project, error)
 	UpdateProject(project models.Project) error
-	DeleteProject(userId, projectId int) error
-	GetDeletedProjects(userId int) (models.Projects, error)
-	RestoreProject(userId, projectId int) error
-	AddUserToProject(propar models.ProjectParticipant) error
+	DeleteProject(orgId, userId, projectId int) error
+	GetDeletedProjects(orgId, userId int) (models.Projects, error)
+	RestoreProject(orgId, userId, projectId int) error
+	AddUserToProject(propar models.ProjectParticipant) (int, error)
 }
 
 type Task interface {
 	CreateTask(task models.Task) (int, error)
-	GetTasks(userId int) (models.Tasks, error)
-	GetTaskById(userId, taskId int) (models.Task, error)
+	GetTasks(orgId, userId int, filter models.TaskFilter) (models.Tasks, error)
+	GetTaskById(orgId, userId, taskId int) (models.Task, error)
 	UpdateTask(task models.Task) error
-	DeleteTask(userId, taskId int) error
-	RestoreTask(userId, taskId int) error
+	DeleteTask(orgId, userId, taskId int) error
+	RestoreTask(orgId, userId, taskId int) error
+	ProjectInOrganization(orgId, projectId int) bool
+	TeamInOrganization(orgId, teamId int) bool
+	SetAssignee(assignee models.TaskAssignee) error
+	RemoveAssignee(taskId, userId int) error
+	GetAssignees(taskId int) ([]models.TaskAssignee, error)
+	BulkUpdateTasks(orgId, userId int, changes []models.TaskChange) error
+	IsProjectMember(projectId, userId int) bool
+	TeamInProject(projectId, teamId int) bool
+	MoveToProject(orgId, userId int, change models.TaskChange, transfer models.TaskTransfer,
+		options models.TransferOptions) error
+	CopyToProject(task models.Task, transfer models.TaskTransfer, options models.TransferOptions) (int, error)
+	GetTransfers(taskId int) ([]models.TaskTransfer, error)
+}
+
+type Invite interface {
+	CreateInvite(invite models.ProjectInvite) (int, error)
+	GetInvite(id int) (models.ProjectInvite, error)
+	GetPendingInvites(projectId int) (models.ProjectInvites, error)
+	HasPendingInvite(projectId int, email string) bool
+	IsParticipant(projectId int, email string) bool
+	RevokeInvite(projectId, inviteId int) error
+	AcceptInvite(invite models.ProjectInvite, user *models.User) (models.ProjectParticipant, error)
+}
+
+type Organization interface {
+	CreateOrganization(org models.Organization, ownerId int) (int, error)
+	GetOrganizations(userId int) (models.Organizations, error)
+	GetOrganization(orgId int) (models.Organization, error)
+	IsSlugUsed(slug string) bool
+	UpdateOrganization(org models.Organization) error
+	GetMember(orgId, userId int) (models.OrganizationMember, error)
+	GetMembers(orgId int) ([]models.OrganizationMember, error)
+	AddMember(member models.OrganizationMember) error
+	UpdateMemberRole(orgId, userId int, role string) error
+	CountOwners(orgId int) (int64, error)
+	RemoveMember(orgId, userId int) error
+}
+
+type Department interface {
+	CreateDepartment(department models.Department) (int, error)
+	GetDepartments(orgId int) (models.Departments, error)
+	GetDepartment(orgId, id int) (models.Department, error)
+	GetDepartmentByKey(orgId int, key string) (models.Department, error)
+	UpdateDepartment(department models.Department) error
+	DeleteDepartment(orgId, id int) error
+	HasActiveProjects(id int) bool
+	AddMember(member models.DepartmentMember) error
+	RemoveMember(departmentId, userId int) error
+	GetMembers(departmentId int) ([]models.DepartmentMember, error)
+	GetProjects(orgId, departmentId int) (models.Projects, error)
+	GetStats(orgId, departmentId int) (models.DepartmentStats, error)
+}
+
+type Team interface {
+	CreateTeam(team models.Team) (int, error)
+	GetTeams(orgId int) (models.Teams, error)
+	GetTeam(orgId, id int) (models.Team, error)
+	GetTeamByKey(orgId int, key string) (models.Team, error)
+	UpdateTeam(team models.Team) error
+	DeleteTeam(orgId, id int) error
+	HasUnclaimedTasks(id int) bool
+	AddMember(member models.TeamMember) error
+	RemoveMember(teamId, userId int) error
+	GetMembers(teamId int) ([]models.TeamMember, error)
+	IsMember(teamId, userId int) bool
+	AddTeamToProject(projectTeam models.ProjectTeam) error
+	RemoveTeamFromProject(projectId, teamId int) error
+	GetProjectTeams(projectId int) ([]models.ProjectTeam, error)
+	GetQueue(orgId, teamId int) (models.Tasks, error)
+	ClaimTask(orgId, teamId, taskId, userId int) error
+}
+
+type Label interface {
+	CreateLabel(label models.Label) (int, error)
+	GetLabels(projectId int) (models.Labels, error)
+	GetLabel(projectId, id int) (models.Label, error)
+	GetLabelByKey(projectId int, key string) (models.Label, error)
+	UpdateLabel(label models.Label) error
+	DeleteLabel(projectId, id int) error
+	CountProjectLabels(projectId int, ids []int) (int64, error)
+	SetTaskLabels(taskId int, ids []int) error
+}
+
+type CustomField interface {
+	CreateCustomField(field models.CustomField) (int, error)
+	GetCustomFields(projectId int) (models.CustomFields, error)
+	GetCustomField(projectId, id int) (models.CustomField, error)
+	GetCustomFieldByKey(projectId int, key string) (models.CustomField, error)
+	UpdateCustomField(field models.CustomField) error
+	DeleteCustomField(projectId, id int) error
+}
+
+type Board interface {
+	CreateDefaultColumns(projectId int) error
+	GetColumns(projectId int) ([]models.BoardColumn, error)
+	GetColumn(projectId, id int) (models.BoardColumn, error)
+	CreateColumn(column models.BoardColumn) (int, error)
+	UpdateColumn(column models.BoardColumn, oldStatus string) error
+	DeleteColumn(projectId, id int) error
+	CountColumnTasks(projectId int, status string) (int64, error)
+	GetBoardTasks(projectId int) (models.Tasks, error)
+	MoveTask(move models.TaskMove) (string, error)
+}
+
+type Sprint interface {
+	CreateSprint(sprint models.Sprint) (int, error)
+	GetSprints(projectId int) (models.Sprints, error)
+	GetSprint(projectId, id int) (models.Sprint, error)
+	UpdateSprint(sprint models.Sprint) error
+	DeleteSprint(projectId, id int) error
+	HasActiveSprint(projectId int) bool
+	StartSprint(projectId, id int) error
+	CompleteSprint(projectId, id int, nextId *int) ([]int, error)
+	AddTasks(projectId, id int, taskIds []int) (int64, error)
+	RemoveTask(id, taskId int) error
+	GetSprintTasks(id int) (models.Tasks, error)
+	GetBacklog(projectId int) (models.Tasks, error)
+	GetCompletedSprints(projectId int) (models.Sprints, error)
+}
+
+type Milestone interface {
+	CreateMilestone(milestone models.Milestone) (int, error)
+	GetMilestones(projectId int) (models.Milestones, error)
+	GetMilestone(projectId, id int) (models.Milestone, error)
+	UpdateMilestone(milestone models.Milestone) error
+	DeleteMilestone(projectId, id int) error
+	AddMilestoneTasks(projectId, id int, taskIds []int) (int64, error)
+	RemoveMilestoneTask(id, taskId int) error
+	GetMilestoneTasks(id int) (models.Tasks, error)
+}
+
+type Worklog interface {
+	CanLogWork(orgId, userId, taskId int) bool
+	CreateWorklog(worklog models.Worklog) (int, error)
+	GetWorklogs(taskId int) (models.Worklogs, error)
+	DeleteWorklog(userId, taskId, id int) error
+	GetRunningTimer(userId int) (models.Worklog, error)
+	StartTimer(worklog models.Worklog) (int, error)
+	StopTimer(userId int, endedAt time.Time) (models.Worklog, error)
+	GetTimesheet(filter models.TimesheetFilter) ([]models.TimesheetEntry, error)
+}
+
+type Recurrence interface {
+	CreateRecurrence(recurrence models.Recurrence) (int, error)
+	GetRecurrence(orgId, id int) (models.Recurrence, error)
+	UpdateRecurrence(recurrence models.Recurrence) error
+	StopRecurrence(id int) error
+	GetDueRecurrences(now time.Time) ([]models.Recurrence, error)
+	GetTask(id int) (models.Task, error)
+	CreateOccurrence(recurrence models.Recurrence, task models.Task, deadline time.Time) (int, error)
+}
+
+type Checklist interface {
+	CreateItem(item models.ChecklistItem) (int, error)
+	GetItems(taskId int) (models.ChecklistItems, error)
+	GetItem(taskId, id int) (models.ChecklistItem, error)
+	UpdateItem(item models.ChecklistItem) error
+	DeleteItem(taskId, id int) error
+	MoveItem(move models.ChecklistMove) error
+}
+
+type Audit interface {
+	GetState(entityType string, id int) (models.JSONMap, error)
+	CreateEntry(entry models.AuditEntry) error
+	GetEntries(filter models.AuditFilter) (models.AuditEntries, error)
+}
+
+type Activity interface {
+	CreateActivity(activity models.Activity) (models.Activity, error)
+	GetActivities(filter models.ActivityFilter) (models.Activities, error)
+}
+
+type Comment interface {
+	CreateComment(comment models.TaskComment) (int, error)
+	GetComments(taskId int) (models.TaskComments, error)
+}
+
+type Notification interface {
+	CreateNotifications(notification models.Notification, userIds []int) error
+	GetTaskFollowers(taskId int) ([]int, error)
+	GetNotifications(filter models.NotificationFilter) (models.Notifications, error)
+	CountUnread(userId int) (int64, error)
+	MarkRead(userId, id int) error
+	MarkAllRead(userId int) (int64, error)
+	SkipMutedEmails() (int64, error)
+	ExpireInstantEmails(before time.Time) (int64, error)
+	ClaimEmails(mode string, after, before, now time.Time, lease time.Duration,
+		limit int) ([]models.PendingEmail, error)
+	SetEmailStatus(ids []int, status string) error
+	GetPreferences(userId int) ([]models.NotificationPreference, error)
+	SetPreferences(preferences []models.NotificationPreference) error
+}
+
+type Webhook interface {
+	CreateWebhook(webhook models.Webhook) (int, error)
+	GetWebhooks(orgId int) (models.Webhooks, error)
+	GetWebhook(orgId, id int) (models.Webhook, error)
+	UpdateWebhook(webhook models.Webhook) error
+	DeleteWebhook(orgId, id int) error
+	CreateDeliveries(orgId, projectId int, event string, payload models.JSONMap) error
+	ClaimDeliveries(now time.Time, lease time.Duration, limit int) ([]models.DueDelivery, error)
+	SaveAttempt(delivery models.WebhookDelivery) error
+	GetDeliveries(webhookId, beforeId, limit int) (models.WebhookDeliveries, error)
+	Redeliver(webhookId, deliveryId int) (int, error)
+}
+
+type Deadline interface {
+	MarkOverdue(now time.Time) ([]models.OverdueMark, error)
+	NotifyTasks(notificationType string, hours int, from, to time.Time) (int64, error)
+	NotifyProjects(notificationType string, hours int, from, to time.Time) (int64, error)
+}
+
+type Template interface {
+	CreateTemplate(template models.ProjectTemplate) (int, error)
+	GetTemplates(orgId int) (models.ProjectTemplates, error)
+	GetTemplate(orgId, id int) (models.ProjectTemplate, error)
+	UpdateTemplate(template models.ProjectTemplate) error
+	DeleteTemplate(orgId, id int) error
+	GetProjectSnapshot(projectId int) (models.ProjectSnapshot, error)
+	CreateFromTemplate(project models.Project, content models.TemplateContent,
+		start time.Time) (models.TemplateProject, error)
 }
 
 type Repository struct {
@@ -45,6 +271,25 @@ type Repository struct {
 	User
 	Project
 	Task
+	Invite
+	Organization
+	Department
+	Team
+	Label
+	CustomField
+	Board
+	Sprint
+	Milestone
+	Worklog
+	Recurrence
+	Checklist
+	Template
+	Audit
+	Activity
+	Comment
+	Notification
+	Webhook
+	Deadline
 }
 
 func NewRepository(db *gorm.DB) *Repository {
@@ -53,5 +298,24 @@ func NewRepository(db *gorm.DB) *Repository {
 		User:          NewUserRepository(db),
 		Project:       NewProjectRepo(db),
 		Task:          NewTaskRepo(db),
+		Invite:        NewInviteRepo(db),
+		Organization:  NewOrganizationRepo(db),
+		Department:    NewDepartmentRepo(db),
+		Team:          NewTeamRepo(db),
+		Label:         NewLabelRepo(db),
+		CustomField:   NewCustomFieldRepo(db),
+		Board:         NewBoardRepo(db),
+		Sprint:        NewSprintRepo(db),
+		Milestone:     NewMilestoneRepo(db),
+		Worklog:       NewWorklogRepo(db),
+		Recurrence:    NewRecurrenceRepo(db),
+		Checklist:     NewChecklistRepo(db),
+		Template:      NewTemplateRepo(db),
+		Audit:         NewAuditRepo(db),
+		Activity:      NewActivityRepo(db),
+		Comment:       NewCommentRepo(db),
+		Notification:  NewNotificationRepo(db),
+		Webhook:       NewWebhookRepo(db),
+		Deadline:      NewDeadlineRepo(db),
 	}
 }
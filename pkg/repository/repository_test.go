@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/db"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"sync"
+	"testing"
+)
+
+// The repository tests run against the Postgres database in TEST_DATABASE_DSN,
+// which they migrate first, and are skipped without one. Every test works in
+// a transaction that is rolled back when it ends.
+var (
+	migrateOnce sync.Once
+	testConn    *gorm.DB
+	testConnErr error
+)
+
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	migrateOnce.Do(func() {
+		testConn, testConnErr = gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+		if testConnErr == nil {
+			db.Init(testConn)
+		}
+	})
+	if testConnErr != nil {
+		t.Fatal(testConnErr)
+	}
+
+	tx := testConn.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+
+	return tx
+}
+
+// testTask creates a task, with its organization, user and project, to
+// attach test data to.
+func testTask(t *testing.T, tx *gorm.DB) models.Task {
+	t.Helper()
+
+	org := models.Organization{Name: "Test", Slug: "test-" + t.Name()}
+	if err := tx.Create(&org).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	user := models.User{Firstname: "Test", Lastname: "User", Email: t.Name() + "@example.com", Password: "x",
+		Role: "superuser"}
+	if err := tx.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	project := models.Project{Name: "Test", OrganizationId: org.ID, ManagerID: user.ID,
+		Status: models.StatusNotStarted, Deadline: "2030-01-01 00:00", IsActive: true}
+	if err := tx.Create(&project).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	task := models.Task{Title: "Test", OrganizationId: org.ID, ProjectId: project.ID, ControllerId: user.ID,
+		Status: models.StatusNotStarted, Deadline: "2030-01-01 00:00", IsActive: true}
+	if err := tx.Create(&task).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return task
+}
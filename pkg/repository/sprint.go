@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"time"
+)
+
+type SprintRepo struct {
+	db *gorm.DB
+}
+
+func NewSprintRepo(db *gorm.DB) *SprintRepo {
+	return &SprintRepo{db: db}
+}
+
+func (s *SprintRepo) CreateSprint(sprint models.Sprint) (int, error) {
+	err := s.db.Create(&sprint).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return sprint.ID, nil
+}
+
+func (s *SprintRepo) GetSprints(projectId int) (models.Sprints, error) {
+	var sprints models.Sprints
+	err := s.db.Where("project_id = ?", projectId).Order("start_date, id").Find(&sprints).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sprints, nil
+}
+
+func (s *SprintRepo) GetSprint(projectId, id int) (models.Sprint, error) {
+	var sprint models.Sprint
+	err := s.db.Where("id = ? AND project_id = ?", id, projectId).First(&sprint).Error
+	if err != nil {
+		return models.Sprint{}, err
+	}
+
+	return sprint, nil
+}
+
+func (s *SprintRepo) UpdateSprint(sprint models.Sprint) error {
+	return s.db.Model(&models.Sprint{}).Where("id = ? AND project_id = ?", sprint.ID, sprint.ProjectId).
+		Updates(map[string]any{"name": sprint.Name, "goal": sprint.Goal, "start_date": sprint.StartDate,
+			"end_date": sprint.EndDate}).Error
+}
+
+// DeleteSprint removes a planned sprint and returns its tasks to the backlog.
+func (s *SprintRepo) DeleteSprint(projectId, id int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Task{}).Where("sprint_id = ?", id).Update("sprint_id", nil).Error; err != nil {
+			return err
+		}
+
+		res := tx.Where("id = ? AND project_id = ? AND status = ?", id, projectId, models.SprintPlanned).
+			Delete(&models.Sprint{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+func (s *SprintRepo) HasActiveSprint(projectId int) bool {
+	var count int64
+	err := s.db.Model(&models.Sprint{}).Where("project_id = ? AND status = ?", projectId, models.SprintActive).
+		Count(&count).Error
+	if err != nil {
+		return true
+	}
+
+	return count > 0
+}
+
+// StartSprint activates a planned sprint and records how many tasks it starts
+// with. The unique index on active sprints stops a second one from starting.
+func (s *SprintRepo) StartSprint(projectId, id int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var committed int64
+		err := tx.Model(&models.Task{}).Where("sprint_id = ? AND is_active = ?", id, true).Count(&committed).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Model(&models.Sprint{}).
+			Where("id = ? AND project_id = ? AND status = ?", id, projectId, models.SprintPlanned).
+			Updates(map[string]any{"status": models.SprintActive, "started_at": time.Now(),
+				"committed_tasks": committed})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// CompleteSprint closes the active sprint. Its unfinished tasks move to the
+// next sprint, or to the backlog when nextId is nil; their ids are returned.
+func (s *SprintRepo) CompleteSprint(projectId, id int, nextId *int) ([]int, error) {
+	var carried []int
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var completed int64
+		err := tx.Model(&models.Task{}).Where("sprint_id = ? AND is_active = ? AND status = ?", id, true,
+			models.StatusDone).Count(&completed).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Task{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("sprint_id = ? AND is_active = ? AND status <> ?", id, true, models.StatusDone).
+			Order("id").Pluck("id", &carried).Error
+		if err != nil {
+			return err
+		}
+
+		if len(carried) > 0 {
+			err := tx.Model(&models.Task{}).Where("id IN ?", carried).Update("sprint_id", nextId).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		res := tx.Model(&models.Sprint{}).
+			Where("id = ? AND project_id = ? AND status = ?", id, projectId, models.SprintActive).
+			Updates(map[string]any{"status": models.SprintCompleted, "completed_at": time.Now(),
+				"completed_tasks": completed, "carried_over": len(carried)})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return carried, nil
+}
+
+// AddTasks puts active tasks of the project into the sprint and returns how
+// many of them were found.
+func (s *SprintRepo) AddTasks(projectId, id int, taskIds []int) (int64, error) {
+	res := s.db.Model(&models.Task{}).Where("id IN ? AND project_id = ? AND is_active = ?", taskIds, projectId, true).
+		Update("sprint_id", id)
+
+	return res.RowsAffected, res.Error
+}
+
+func (s *SprintRepo) RemoveTask(id, taskId int) error {
+	tx := s.db.Model(&models.Task{}).Where("id = ? AND sprint_id = ?", taskId, id).Update("sprint_id", nil)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *SprintRepo) GetSprintTasks(id int) (models.Tasks, error) {
+	return s.getTasks(s.db.Where("tasks.sprint_id = ?", id))
+}
+
+// GetBacklog returns the unfinished tasks of the project that are in no
+// sprint.
+func (s *SprintRepo) GetBacklog(projectId int) (models.Tasks, error) {
+	return s.getTasks(s.db.Where("tasks.project_id = ? AND tasks.sprint_id IS NULL AND tasks.status <> ?",
+		projectId, models.StatusDone))
+}
+
+func (s *SprintRepo) getTasks(where *gorm.DB) (models.Tasks, error) {
+	var tasks models.Tasks
+	rows, err := s.db.Model(&models.Task{}).Joins("left join users on tasks.executor_id = users.id").
+		Select([]string{"tasks.id", "tasks.title", "COALESCE(users.firstname, '')", "tasks.status", "tasks.priority",
+			"tasks.sprint_id", "tasks.deadline"}).
+		Where(where).Where("tasks.is_active = ?", true).
+		Order(boardOrder).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+		err := rows.Scan(&task.ID, &task.Title, &task.ExecutorName, &task.Status, &task.Priority, &task.SprintId,
+			&task.Deadline)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func (s *SprintRepo) GetCompletedSprints(projectId int) (models.Sprints, error) {
+	var sprints models.Sprints
+	err := s.db.Where("project_id = ? AND status = ?", projectId, models.SprintCompleted).
+		Order("completed_at DESC").Find(&sprints).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sprints, nil
+}
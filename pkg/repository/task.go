@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"fmt"
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"log"
 )
 
@@ -14,8 +16,15 @@ func NewTaskRepo(db *gorm.DB) *TaskRepo {
 	return &TaskRepo{db: db}
 }
 
+// CreateTask adds the task at the bottom of its status column.
 func (t *TaskRepo) CreateTask(task models.Task) (int, error) {
-	err := t.db.Model(&models.Task{}).Create(&task).Error
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		if err := placeInColumn(tx, &task); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Task{}).Create(&task).Error
+	})
 	if err != nil {
 		return -1, err
 	}
@@ -23,21 +32,77 @@ func (t *TaskRepo) CreateTask(task models.Task) (int, error) {
 	return task.ID, nil
 }
 
-func (t *TaskRepo) GetTasks(userId int) (models.Tasks, error) {
+// timeSpent selects the minutes logged on the task.
+const timeSpent = "COALESCE((SELECT sum(worklogs.minutes) FROM worklogs WHERE worklogs.task_id = tasks.id), 0)"
+
+// checklistDone and checklistTotal select the checklist progress of the task.
+const (
+	checklistDone = "(SELECT count(*) FROM checklist_items WHERE checklist_items.task_id = tasks.id AND " +
+		"checklist_items.is_checked)"
+	checklistTotal = "(SELECT count(*) FROM checklist_items WHERE checklist_items.task_id = tasks.id)"
+)
+
+func (t *TaskRepo) GetTasks(orgId, userId int, filter models.TaskFilter) (models.Tasks, error) {
 	var tasks models.Tasks
-	rows, err := t.db.Model(models.Task{}).Joins("inner join users on tasks.executor_id = users.id").
+	query := t.db.Model(models.Task{}).Joins("left join users on tasks.executor_id = users.id").
+		Joins("left join teams on tasks.team_id = teams.id").
 		Joins("inner join projects on tasks.project_id = projects.id").
-		Select([]string{"tasks.id", "tasks.title", "tasks.description", "users.firstname", "tasks.status",
-			"projects.name", "tasks.deadline"}).
-		Where("tasks.controller_id = ? AND tasks.is_active = ?", userId, true).Rows()
+		Select([]string{"tasks.id", "tasks.title", "tasks.description", "COALESCE(users.firstname, '')",
+			"tasks.team_id", "COALESCE(teams.name, '')", "tasks.status", "tasks.priority", "tasks.sprint_id",
+			"tasks.milestone_id", "tasks.recurrence_id", "tasks.original_estimate", "tasks.remaining_estimate",
+			timeSpent, checklistDone, checklistTotal, "tasks.project_id", "projects.name", "tasks.deadline",
+			"tasks.is_overdue", "tasks.custom_fields"}).
+		Where("tasks.organization_id = ? AND tasks.controller_id = ? AND tasks.is_active = ?",
+			orgId, userId, true)
+
+	if filter.Priority != "" {
+		query = query.Where("tasks.priority = ?", filter.Priority)
+	}
+
+	if filter.LabelId != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM task_labels WHERE task_labels.task_id = tasks.id AND "+
+			"task_labels.label_id = ?)", filter.LabelId)
+	}
+
+	if filter.ProjectId != 0 {
+		query = query.Where("tasks.project_id = ?", filter.ProjectId)
+	}
+
+	if filter.Overdue {
+		query = query.Where("tasks.is_overdue = ?", true)
+	}
+
+	if len(filter.FieldsContain) > 0 {
+		query = query.Where("tasks.custom_fields @> ?::jsonb", filter.FieldsContain)
+	}
+
+	if filter.SortField != "" {
+		order := "ASC"
+		if filter.SortDesc {
+			order = "DESC"
+		}
+		// The cast comes from the field type, never from the request.
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "(tasks.custom_fields ->> ?)::" + filter.SortCast + " " + order + " NULLS LAST, tasks.id",
+			Vars: []any{filter.SortField},
+		}})
+	} else {
+		query = query.Order("tasks.id")
+	}
+
+	rows, err := query.Rows()
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var task models.Task
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.ExecutorName, &task.Status, &task.ProjectName,
-			&task.Deadline)
+		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.ExecutorName, &task.TeamId, &task.TeamName,
+			&task.Status, &task.Priority, &task.SprintId, &task.MilestoneId, &task.RecurrenceId,
+			&task.OriginalEstimate, &task.RemainingEstimate, &task.TimeSpent, &task.ChecklistDone,
+			&task.ChecklistTotal, &task.ProjectId, &task.ProjectName, &task.Deadline, &task.IsOverdue,
+			&task.CustomFields)
 		if err != nil {
 			log.Println("error while scanning from row")
 			return nil, err
@@ -46,37 +111,109 @@ func (t *TaskRepo) GetTasks(userId int) (models.Tasks, error) {
 		tasks = append(tasks, task)
 	}
 
+	if err := t.loadLabels(tasks); err != nil {
+		return nil, err
+	}
+
 	return tasks, nil
 }
 
-func (t *TaskRepo) GetTaskById(userId, taskId int) (models.Task, error) {
+func (t *TaskRepo) GetTaskById(orgId, userId, taskId int) (models.Task, error) {
 	var task models.Task
-	row := t.db.Model(models.Task{}).Joins("inner join users on tasks.executor_id = users.id").
+	row := t.db.Model(models.Task{}).Joins("left join users on tasks.executor_id = users.id").
+		Joins("left join teams on tasks.team_id = teams.id").
 		Joins("inner join projects on tasks.project_id = projects.id").
-		Select([]string{"tasks.id", "tasks.title", "tasks.description", "users.firstname", "tasks.status",
-			"projects.name", "tasks.deadline"}).
-		Where("tasks.controller_id = ? AND tasks.id = ? AND tasks.is_active = ?", userId, taskId, true).Row()
+		Select([]string{"tasks.id", "tasks.title", "tasks.description", "tasks.executor_id",
+			"COALESCE(users.firstname, '')", "tasks.team_id", "COALESCE(teams.name, '')", "tasks.status",
+			"tasks.priority", "tasks.rank", "tasks.sprint_id", "tasks.milestone_id", "tasks.recurrence_id",
+			"tasks.original_estimate", "tasks.remaining_estimate", timeSpent, "tasks.project_id", "projects.name",
+			"tasks.deadline", "tasks.is_overdue", "tasks.custom_fields"}).
+		Where("tasks.organization_id = ? AND tasks.controller_id = ? AND tasks.id = ? AND tasks.is_active = ?",
+			orgId, userId, taskId, true).Row()
 
-	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.ExecutorName, &task.Status, &task.ProjectName,
-		&task.Deadline)
+	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.ExecutorId, &task.ExecutorName, &task.TeamId,
+		&task.TeamName, &task.Status, &task.Priority, &task.Rank, &task.SprintId, &task.MilestoneId, &task.RecurrenceId,
+		&task.OriginalEstimate, &task.RemainingEstimate, &task.TimeSpent, &task.ProjectId, &task.ProjectName,
+		&task.Deadline, &task.IsOverdue, &task.CustomFields)
 	if err != nil {
 		return models.Task{}, err
 	}
 
-	return task, nil
+	tasks := models.Tasks{task}
+	if err := t.loadLabels(tasks); err != nil {
+		return models.Task{}, err
+	}
+
+	return tasks[0], nil
 }
 
-func (t *TaskRepo) UpdateTask(task models.Task) error {
-	err := t.db.Save(&task).Error
+// loadLabels fills in the labels of the tasks with a single query.
+func (t *TaskRepo) loadLabels(tasks models.Tasks) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	index := make(map[int]int, len(tasks))
+	ids := make([]int, 0, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+		ids = append(ids, task.ID)
+	}
+
+	rows, err := t.db.Model(&models.TaskLabel{}).Joins("inner join labels on task_labels.label_id = labels.id").
+		Select([]string{"task_labels.task_id", "labels.id", "labels.project_id", "labels.name", "labels.color"}).
+		Where("task_labels.task_id IN ?", ids).Order("labels.name").Rows()
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskId int
+		var label models.Label
+		err := rows.Scan(&taskId, &label.ID, &label.ProjectId, &label.Name, &label.Color)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return err
+		}
+
+		i := index[taskId]
+		tasks[i].Labels = append(tasks[i].Labels, label)
+	}
 
 	return nil
 }
 
-func (t *TaskRepo) DeleteTask(userId, taskId int) error {
-	err := t.db.Model(&models.Task{}).Where("id = ? AND controller_id = ? AND is_active = ?", taskId, userId, true).
+// UpdateTask saves the task. A task whose status changed goes to the bottom
+// of its new column; otherwise it keeps its place.
+func (t *TaskRepo) UpdateTask(task models.Task) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Task
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("status", "rank").
+			Where("id = ? AND organization_id = ? AND controller_id = ?", task.ID, task.OrganizationId,
+				task.ControllerId).First(&current).Error
+		if err != nil {
+			return err
+		}
+
+		if task.Status == current.Status {
+			task.Rank = current.Rank
+		} else if err := placeInColumn(tx, &task); err != nil {
+			return err
+		}
+
+		// Select("*") keeps Save from falling back to an upsert when nothing
+		// matches. Whether the task is overdue is left to the deadline
+		// scheduler.
+		return tx.Select("*").Omit("is_overdue").
+			Where("organization_id = ? AND controller_id = ?", task.OrganizationId, task.ControllerId).
+			Save(&task).Error
+	})
+}
+
+func (t *TaskRepo) DeleteTask(orgId, userId, taskId int) error {
+	err := t.db.Model(&models.Task{}).Where("id = ? AND organization_id = ? AND controller_id = ? AND is_active = ?",
+		taskId, orgId, userId, true).
 		Update("is_active", false).Error
 	if err != nil {
 		return err
@@ -85,8 +222,9 @@ func (t *TaskRepo) DeleteTask(userId, taskId int) error {
 	return nil
 }
 
-func (t *TaskRepo) RestoreTask(userId, taskId int) error {
-	err := t.db.Model(&models.Task{}).Where("id = ? AND controller_id = ? AND is_active = ?", taskId, userId, false).
+func (t *TaskRepo) RestoreTask(orgId, userId, taskId int) error {
+	err := t.db.Model(&models.Task{}).Where("id = ? AND organization_id = ? AND controller_id = ? AND is_active = ?",
+		taskId, orgId, userId, false).
 		Update("is_active", true).Error
 	if err != nil {
 		return err
@@ -94,3 +232,291 @@ func (t *TaskRepo) RestoreTask(userId, taskId int) error {
 
 	return nil
 }
+
+func (t *TaskRepo) ProjectInOrganization(orgId, projectId int) bool {
+	var count int64
+	err := t.db.Model(&models.Project{}).Where("id = ? AND organization_id = ? AND is_active = ?", projectId, orgId, true).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+func (t *TaskRepo) TeamInOrganization(orgId, teamId int) bool {
+	var count int64
+	err := t.db.Model(&models.Team{}).Where("id = ? AND organization_id = ? AND is_active = ?", teamId, orgId, true).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+// SetAssignee adds the user to the task or changes their role when they are
+// already on it.
+func (t *TaskRepo) SetAssignee(assignee models.TaskAssignee) error {
+	return t.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "task_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(&assignee).Error
+}
+
+func (t *TaskRepo) RemoveAssignee(taskId, userId int) error {
+	tx := t.db.Where("task_id = ? AND user_id = ?", taskId, userId).Delete(&models.TaskAssignee{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (t *TaskRepo) GetAssignees(taskId int) ([]models.TaskAssignee, error) {
+	var assignees []models.TaskAssignee
+	rows, err := t.db.Model(&models.TaskAssignee{}).Joins("inner join users on task_assignees.user_id = users.id").
+		Select([]string{"task_assignees.id", "task_assignees.task_id", "task_assignees.user_id", "task_assignees.role",
+			"users.firstname", "users.lastname"}).
+		Where("task_assignees.task_id = ? AND users.is_active = ?", taskId, true).
+		Order("task_assignees.id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.TaskAssignee
+		err := rows.Scan(&a.ID, &a.TaskId, &a.UserId, &a.Role, &a.Firstname, &a.Lastname)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		assignees = append(assignees, a)
+	}
+
+	return assignees, nil
+}
+
+// BulkUpdateTasks applies the changes in one transaction, so either every
+// task is updated or none is. Tasks that change column are placed only once
+// every task is in its new column, so tasks the batch moves out of a column
+// make room for the ones it moves in.
+func (t *TaskRepo) BulkUpdateTasks(orgId, userId int, changes []models.TaskChange) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			query := tx.Model(&models.Task{}).Where("id = ? AND organization_id = ? AND controller_id = ? AND is_active = ?",
+				change.TaskId, orgId, userId, true)
+
+			if change.Place {
+				// Unranked until placed, so the old rank doesn't count in
+				// the new column.
+				change.Columns["rank"] = ""
+			}
+
+			var res *gorm.DB
+			switch {
+			case change.Delete:
+				res = query.Update("is_active", false)
+			case len(change.Columns) > 0:
+				res = query.Updates(change.Columns)
+			}
+
+			if res != nil {
+				if res.Error != nil {
+					return res.Error
+				}
+
+				if res.RowsAffected == 0 {
+					return fmt.Errorf("task %d doesn't exist", change.TaskId)
+				}
+			}
+
+			if change.Transfer != nil {
+				if err := leaveProject(tx, *change.Transfer, change.Options); err != nil {
+					return err
+				}
+			}
+
+			if !change.SetLabels {
+				continue
+			}
+
+			if err := tx.Where("task_id = ?", change.TaskId).Delete(&models.TaskLabel{}).Error; err != nil {
+				return err
+			}
+
+			for _, labelId := range change.LabelIds {
+				err := tx.Create(&models.TaskLabel{TaskId: change.TaskId, LabelId: labelId}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, change := range changes {
+			if !change.Place {
+				continue
+			}
+
+			var task models.Task
+			err := tx.Select("id", "project_id", "status").Where("id = ?", change.TaskId).First(&task).Error
+			if err != nil {
+				return err
+			}
+
+			if err := placeInColumn(tx, &task); err != nil {
+				return err
+			}
+
+			err = tx.Model(&models.Task{}).Where("id = ?", task.ID).Update("rank", task.Rank).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// projectMember matches the projects the user manages, participates in or
+// is in one of the teams of. It takes the user's id three times.
+const projectMember = "projects.manager_id = ? OR " +
+	"EXISTS (SELECT 1 FROM project_participants WHERE project_participants.project_id = projects.id AND " +
+	"project_participants.participant_id = ?) OR " +
+	"EXISTS (SELECT 1 FROM project_teams INNER JOIN team_members ON team_members.team_id = project_teams.team_id " +
+	"WHERE project_teams.project_id = projects.id AND team_members.user_id = ?)"
+
+// IsProjectMember tells whether the user manages the project, participates
+// in it or is in one of its teams.
+func (t *TaskRepo) IsProjectMember(projectId, userId int) bool {
+	var count int64
+	err := t.db.Model(&models.Project{}).
+		Where("projects.id = ? AND projects.is_active = ?", projectId, true).
+		Where(projectMember, userId, userId, userId).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+func (t *TaskRepo) TeamInProject(projectId, teamId int) bool {
+	var count int64
+	err := t.db.Model(&models.ProjectTeam{}).Where("project_id = ? AND team_id = ?", projectId, teamId).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+// MoveToProject applies the change that moves the task to the bottom of its
+// column in the new project and records the move.
+func (t *TaskRepo) MoveToProject(orgId, userId int, change models.TaskChange, transfer models.TaskTransfer,
+	options models.TransferOptions) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status").
+			Where("id = ? AND organization_id = ? AND controller_id = ? AND is_active = ?",
+				change.TaskId, orgId, userId, true).First(&task).Error
+		if err != nil {
+			return err
+		}
+
+		task.ProjectId = transfer.ToProjectId
+		if err := placeInColumn(tx, &task); err != nil {
+			return err
+		}
+		change.Columns["rank"] = task.Rank
+
+		if err := tx.Model(&models.Task{}).Where("id = ?", change.TaskId).Updates(change.Columns).Error; err != nil {
+			return err
+		}
+
+		return leaveProject(tx, transfer, options)
+	})
+}
+
+// leaveProject clears what a moved task leaves behind in its old project and
+// records the move. The labels always go; the checklist and the comments go
+// unless the options keep them.
+func leaveProject(tx *gorm.DB, transfer models.TaskTransfer, options models.TransferOptions) error {
+	if err := tx.Where("task_id = ?", transfer.TaskId).Delete(&models.TaskLabel{}).Error; err != nil {
+		return err
+	}
+
+	if !options.Checklist {
+		if err := tx.Where("task_id = ?", transfer.TaskId).Delete(&models.ChecklistItem{}).Error; err != nil {
+			return err
+		}
+	}
+
+	if !options.Comments {
+		if err := tx.Where("task_id = ?", transfer.TaskId).Delete(&models.TaskComment{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Create(&transfer).Error
+}
+
+// CopyToProject creates the copy at the bottom of its column, with what the
+// options take along from the original, and records where it came from.
+func (t *TaskRepo) CopyToProject(task models.Task, transfer models.TaskTransfer,
+	options models.TransferOptions) (int, error) {
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		if err := placeInColumn(tx, &task); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+
+		if options.Checklist {
+			err := tx.Exec("INSERT INTO checklist_items (task_id, title, is_checked, rank, created_at, updated_at) "+
+				"SELECT ?, title, is_checked, rank, now(), now() FROM checklist_items WHERE task_id = ?",
+				task.ID, transfer.TaskId).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if options.Comments {
+			err := tx.Exec("INSERT INTO task_comments (task_id, author_id, body, created_at) "+
+				"SELECT ?, author_id, body, created_at FROM task_comments WHERE task_id = ? ORDER BY id",
+				task.ID, transfer.TaskId).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		transfer.CopyId = &task.ID
+		return tx.Create(&transfer).Error
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return task.ID, nil
+}
+
+// GetTransfers lists the moves and copies of the task, including the one it
+// was copied by.
+func (t *TaskRepo) GetTransfers(taskId int) ([]models.TaskTransfer, error) {
+	var transfers []models.TaskTransfer
+	err := t.db.Where("task_id = ? OR copy_id = ?", taskId, taskId).Order("created_at, id").Find(&transfers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTaskRepo_PlaceInColumn(t *testing.T) {
+	tx := testDB(t)
+	first := testTask(t, tx)
+	repo := NewTaskRepo(tx)
+
+	assert.NoError(t, tx.Create(&models.BoardColumn{ProjectId: first.ProjectId, Status: models.StatusInProgress,
+		WipLimit: 2}).Error)
+
+	newTask := func(status string) models.Task {
+		return models.Task{Title: "Test", OrganizationId: first.OrganizationId, ProjectId: first.ProjectId,
+			ControllerId: first.ControllerId, Status: status, Deadline: "2030-01-01 00:00", IsActive: true}
+	}
+	rankOf := func(id int) string {
+		var task models.Task
+		assert.NoError(t, tx.Select("rank").First(&task, id).Error)
+		return task.Rank
+	}
+
+	// A status without a column has no limit.
+	second, err := repo.CreateTask(newTask(models.StatusNotStarted))
+	assert.NoError(t, err)
+	third, err := repo.CreateTask(newTask(models.StatusNotStarted))
+	assert.NoError(t, err)
+	assert.Greater(t, rankOf(third), rankOf(second))
+
+	inProgress, err := repo.CreateTask(newTask(models.StatusInProgress))
+	assert.NoError(t, err)
+
+	// Updating a task that stays in its column keeps its place.
+	task := newTask(models.StatusNotStarted)
+	task.ID, task.Title = second, "Renamed"
+	assert.NoError(t, repo.UpdateTask(task))
+	assert.Less(t, rankOf(second), rankOf(third))
+
+	// Changing its status puts it at the bottom of the new column.
+	task.Status = models.StatusInProgress
+	assert.NoError(t, repo.UpdateTask(task))
+	assert.Greater(t, rankOf(second), rankOf(inProgress))
+
+	// The column is now at its limit.
+	_, err = repo.CreateTask(newTask(models.StatusInProgress))
+	assert.ErrorIs(t, err, ErrWipLimit)
+
+	task.ID = third
+	task.Status = models.StatusInProgress
+	assert.ErrorIs(t, repo.UpdateTask(task), ErrWipLimit)
+}
+
+func TestTaskRepo_CopyToProject(t *testing.T) {
+	tx := testDB(t)
+	original := testTask(t, tx)
+	repo := NewTaskRepo(tx)
+	comments := NewCommentRepo(tx)
+
+	for _, body := range []string{"first", "second"} {
+		_, err := comments.CreateComment(models.TaskComment{TaskId: original.ID, AuthorId: original.ControllerId,
+			Body: body})
+		assert.NoError(t, err)
+	}
+
+	copyTask := func(options models.TransferOptions) int {
+		task := original
+		task.ID = 0
+		id, err := repo.CopyToProject(task, models.TaskTransfer{TaskId: original.ID, Kind: models.TransferCopy,
+			FromProjectId: original.ProjectId, ToProjectId: original.ProjectId, ActorId: original.ControllerId},
+			options)
+		assert.NoError(t, err)
+		return id
+	}
+	bodies := func(taskId int) []string {
+		list, err := comments.GetComments(taskId)
+		assert.NoError(t, err)
+
+		var bodies []string
+		for _, comment := range list {
+			bodies = append(bodies, comment.Body)
+		}
+		return bodies
+	}
+
+	assert.Equal(t, []string{"first", "second"}, bodies(copyTask(models.TransferOptions{Comments: true})))
+	assert.Empty(t, bodies(copyTask(models.TransferOptions{})))
+	assert.Equal(t, []string{"first", "second"}, bodies(original.ID))
+}
+
+func TestTaskRepo_BulkUpdateTasks_Transfer(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewTaskRepo(tx)
+
+	target := models.Project{Name: "Target", OrganizationId: task.OrganizationId, ManagerID: task.ControllerId,
+		Status: models.StatusNotStarted, Deadline: "2030-01-01 00:00", IsActive: true}
+	assert.NoError(t, tx.Create(&target).Error)
+
+	_, err := NewChecklistRepo(tx).CreateItem(models.ChecklistItem{TaskId: task.ID, Title: "a"})
+	assert.NoError(t, err)
+	_, err = NewCommentRepo(tx).CreateComment(models.TaskComment{TaskId: task.ID, AuthorId: task.ControllerId,
+		Body: "first"})
+	assert.NoError(t, err)
+
+	err = repo.BulkUpdateTasks(task.OrganizationId, task.ControllerId, []models.TaskChange{{
+		TaskId:  task.ID,
+		Columns: map[string]any{"project_id": target.ID},
+		Transfer: &models.TaskTransfer{TaskId: task.ID, Kind: models.TransferMove, FromProjectId: task.ProjectId,
+			ToProjectId: target.ID, ActorId: task.ControllerId},
+		Options: models.TransferOptions{Comments: true},
+	}})
+	assert.NoError(t, err)
+
+	transfers, err := repo.GetTransfers(task.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, transfers, 1) {
+		assert.Equal(t, target.ID, transfers[0].ToProjectId)
+	}
+
+	var items, comments int64
+	assert.NoError(t, tx.Model(&models.ChecklistItem{}).Where("task_id = ?", task.ID).Count(&items).Error)
+	assert.NoError(t, tx.Model(&models.TaskComment{}).Where("task_id = ?", task.ID).Count(&comments).Error)
+	assert.Equal(t, int64(0), items)
+	assert.Equal(t, int64(1), comments)
+}
+
+func TestTaskRepo_BulkUpdateTasks_Place(t *testing.T) {
+	tx := testDB(t)
+	first := testTask(t, tx)
+	repo := NewTaskRepo(tx)
+
+	assert.NoError(t, tx.Create(&models.BoardColumn{ProjectId: first.ProjectId, Status: models.StatusInProgress,
+		WipLimit: 1}).Error)
+
+	newTask := func(status string) int {
+		id, err := repo.CreateTask(models.Task{Title: "Test", OrganizationId: first.OrganizationId,
+			ProjectId: first.ProjectId, ControllerId: first.ControllerId, Status: status,
+			Deadline: "2030-01-01 00:00", IsActive: true})
+		assert.NoError(t, err)
+		return id
+	}
+	inProgress, waiting := newTask(models.StatusInProgress), newTask(models.StatusNotStarted)
+	rankOf := func(id int) string {
+		var task models.Task
+		assert.NoError(t, tx.Select("rank").First(&task, id).Error)
+		return task.Rank
+	}
+	toStatus := func(id int, status string) models.TaskChange {
+		return models.TaskChange{TaskId: id, Columns: map[string]any{"status": status}, Place: true}
+	}
+
+	// The task leaving the full column makes room for the one coming in,
+	// though it comes later in the batch.
+	err := repo.BulkUpdateTasks(first.OrganizationId, first.ControllerId, []models.TaskChange{
+		toStatus(waiting, models.StatusInProgress), toStatus(inProgress, models.StatusNotStarted)})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rankOf(waiting))
+	assert.NotEmpty(t, rankOf(inProgress))
+
+	err = repo.BulkUpdateTasks(first.OrganizationId, first.ControllerId, []models.TaskChange{
+		toStatus(inProgress, models.StatusInProgress)})
+	assert.ErrorIs(t, err, ErrWipLimit)
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+)
+
+type TeamRepo struct {
+	db *gorm.DB
+}
+
+func NewTeamRepo(db *gorm.DB) *TeamRepo {
+	return &TeamRepo{db: db}
+}
+
+func (t *TeamRepo) CreateTeam(team models.Team) (int, error) {
+	err := t.db.Create(&team).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return team.ID, nil
+}
+
+func (t *TeamRepo) GetTeams(orgId int) (models.Teams, error) {
+	var teams models.Teams
+	err := t.db.Where("organization_id = ? AND is_active = ?", orgId, true).Order("name").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (t *TeamRepo) GetTeam(orgId, id int) (models.Team, error) {
+	var team models.Team
+	err := t.db.Where("id = ? AND organization_id = ? AND is_active = ?", id, orgId, true).First(&team).Error
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	return team, nil
+}
+
+func (t *TeamRepo) GetTeamByKey(orgId int, key string) (models.Team, error) {
+	var team models.Team
+	err := t.db.Where("organization_id = ? AND key = ? AND is_active = ?", orgId, key, true).First(&team).Error
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	return team, nil
+}
+
+func (t *TeamRepo) UpdateTeam(team models.Team) error {
+	return t.db.Model(&models.Team{}).
+		Where("id = ? AND organization_id = ? AND is_active = ?", team.ID, team.OrganizationId, true).
+		Updates(map[string]any{"name": team.Name, "key": team.Key, "description": team.Description}).Error
+}
+
+// DeleteTeam deactivates the team and drops it from the projects it took
+// part in.
+func (t *TeamRepo) DeleteTeam(orgId, id int) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Team{}).Where("id = ? AND organization_id = ? AND is_active = ?", id, orgId, true).
+			Update("is_active", false)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Where("team_id = ?", id).Delete(&models.ProjectTeam{}).Error
+	})
+}
+
+func (t *TeamRepo) HasUnclaimedTasks(id int) bool {
+	var count int64
+	err := t.db.Model(&models.Task{}).Where("team_id = ? AND executor_id IS NULL AND is_active = ?", id, true).
+		Count(&count).Error
+	if err != nil {
+		return true
+	}
+
+	return count > 0
+}
+
+func (t *TeamRepo) AddMember(member models.TeamMember) error {
+	return t.db.Create(&member).Error
+}
+
+func (t *TeamRepo) RemoveMember(teamId, userId int) error {
+	tx := t.db.Where("team_id = ? AND user_id = ?", teamId, userId).Delete(&models.TeamMember{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (t *TeamRepo) GetMembers(teamId int) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	rows, err := t.db.Model(&models.TeamMember{}).
+		Joins("inner join users on team_members.user_id = users.id").
+		Select([]string{"team_members.id", "team_members.team_id", "team_members.user_id", "users.firstname",
+			"users.lastname"}).
+		Where("team_members.team_id = ? AND users.is_active = ?", teamId, true).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m models.TeamMember
+		err := rows.Scan(&m.ID, &m.TeamId, &m.UserId, &m.Firstname, &m.Lastname)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+func (t *TeamRepo) IsMember(teamId, userId int) bool {
+	var count int64
+	err := t.db.Model(&models.TeamMember{}).Where("team_id = ? AND user_id = ?", teamId, userId).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+func (t *TeamRepo) AddTeamToProject(projectTeam models.ProjectTeam) error {
+	return t.db.Create(&projectTeam).Error
+}
+
+func (t *TeamRepo) RemoveTeamFromProject(projectId, teamId int) error {
+	tx := t.db.Where("project_id = ? AND team_id = ?", projectId, teamId).Delete(&models.ProjectTeam{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (t *TeamRepo) GetProjectTeams(projectId int) ([]models.ProjectTeam, error) {
+	var teams []models.ProjectTeam
+	rows, err := t.db.Model(&models.ProjectTeam{}).Joins("inner join teams on project_teams.team_id = teams.id").
+		Select([]string{"project_teams.id", "project_teams.project_id", "project_teams.team_id", "teams.name",
+			"project_teams.role"}).
+		Where("project_teams.project_id = ? AND teams.is_active = ?", projectId, true).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pt models.ProjectTeam
+		err := rows.Scan(&pt.ID, &pt.ProjectId, &pt.TeamId, &pt.TeamName, &pt.Role)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		teams = append(teams, pt)
+	}
+
+	return teams, nil
+}
+
+// GetQueue returns the team's tasks that nobody has claimed yet, the most
+// urgent first.
+func (t *TeamRepo) GetQueue(orgId, teamId int) (models.Tasks, error) {
+	var tasks models.Tasks
+	rows, err := t.db.Model(&models.Task{}).Joins("inner join teams on tasks.team_id = teams.id").
+		Joins("inner join projects on tasks.project_id = projects.id").
+		Select([]string{"tasks.id", "tasks.title", "tasks.description", "tasks.team_id", "teams.name", "tasks.status",
+			"projects.name", "tasks.deadline"}).
+		Where("tasks.organization_id = ? AND tasks.team_id = ? AND tasks.executor_id IS NULL AND tasks.is_active = ?",
+			orgId, teamId, true).
+		Order("tasks.deadline").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.TeamId, &task.TeamName, &task.Status,
+			&task.ProjectName, &task.Deadline)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// ClaimTask makes the user the executor of an unclaimed task of the team. The
+// executor check is part of the update so two members can't both claim it.
+func (t *TeamRepo) ClaimTask(orgId, teamId, taskId, userId int) error {
+	tx := t.db.Model(&models.Task{}).
+		Where("id = ? AND organization_id = ? AND team_id = ? AND executor_id IS NULL AND is_active = ?",
+			taskId, orgId, teamId, true).
+		Update("executor_id", userId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTeamRepo_GetTeamByKey(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+	repo := NewTeamRepo(tx)
+
+	deleted := models.Team{OrganizationId: task.OrganizationId, Name: "Backend", Key: "backend"}
+	assert.NoError(t, tx.Create(&deleted).Error)
+	assert.NoError(t, repo.DeleteTeam(task.OrganizationId, deleted.ID))
+
+	_, err := repo.GetTeamByKey(task.OrganizationId, "backend")
+	assert.Error(t, err)
+
+	id, err := repo.CreateTeam(models.Team{OrganizationId: task.OrganizationId, Name: "Back-end", Key: "backend"})
+	assert.NoError(t, err)
+
+	team, err := repo.GetTeamByKey(task.OrganizationId, "backend")
+	assert.NoError(t, err)
+	assert.Equal(t, id, team.ID)
+}
+
+func TestUserRepository_GetProjects(t *testing.T) {
+	tx := testDB(t)
+	task := testTask(t, tx)
+
+	team := models.Team{OrganizationId: task.OrganizationId, Name: "Backend", Key: "backend"}
+	assert.NoError(t, tx.Create(&team).Error)
+	assert.NoError(t, tx.Create(&models.TeamMember{TeamId: team.ID, UserId: task.ControllerId}).Error)
+
+	projectTeam := models.ProjectTeam{ProjectId: task.ProjectId, TeamId: team.ID, Role: "participant"}
+	assert.NoError(t, tx.Create(&projectTeam).Error)
+
+	projects, err := NewUserRepository(tx).GetProjects(task.OrganizationId, task.ControllerId)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.UserProject{{ParticipantId: task.ControllerId, Role: "participant",
+		ProjectId: task.ProjectId}}, projects)
+
+	participant := models.ProjectParticipant{ParticipantId: task.ControllerId, Role: "manager",
+		ProjectId: task.ProjectId}
+	assert.NoError(t, tx.Create(&participant).Error)
+
+	projects, err = NewUserRepository(tx).GetProjects(task.OrganizationId, task.ControllerId)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.UserProject{{ID: &participant.ID, ParticipantId: task.ControllerId, Role: "manager",
+		ProjectId: task.ProjectId}}, projects)
+}
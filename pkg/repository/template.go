@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"gorm.io/gorm"
+	"time"
+)
+
+type TemplateRepo struct {
+	db *gorm.DB
+}
+
+func NewTemplateRepo(db *gorm.DB) *TemplateRepo {
+	return &TemplateRepo{db: db}
+}
+
+func (t *TemplateRepo) CreateTemplate(template models.ProjectTemplate) (int, error) {
+	err := t.db.Create(&template).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return template.ID, nil
+}
+
+func (t *TemplateRepo) GetTemplates(orgId int) (models.ProjectTemplates, error) {
+	var templates models.ProjectTemplates
+	err := t.db.Where("organization_id = ?", orgId).Order("name, id").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (t *TemplateRepo) GetTemplate(orgId, id int) (models.ProjectTemplate, error) {
+	var template models.ProjectTemplate
+	err := t.db.Where("id = ? AND organization_id = ?", id, orgId).First(&template).Error
+	if err != nil {
+		return models.ProjectTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (t *TemplateRepo) UpdateTemplate(template models.ProjectTemplate) error {
+	tx := t.db.Model(&models.ProjectTemplate{}).
+		Where("id = ? AND organization_id = ?", template.ID, template.OrganizationId).
+		Updates(map[string]any{"name": template.Name, "description": template.Description})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (t *TemplateRepo) DeleteTemplate(orgId, id int) error {
+	tx := t.db.Where("id = ? AND organization_id = ?", id, orgId).Delete(&models.ProjectTemplate{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetProjectSnapshot reads the structure and the active tasks of the project.
+// The project itself is left to the caller.
+func (t *TemplateRepo) GetProjectSnapshot(projectId int) (models.ProjectSnapshot, error) {
+	var snapshot models.ProjectSnapshot
+	projectTasks := t.db.Model(&models.Task{}).Select("id").Where("project_id = ? AND is_active = ?", projectId, true)
+
+	queries := []*gorm.DB{
+		t.db.Where("project_id = ?", projectId).Order("position, id").Find(&snapshot.Columns),
+		t.db.Where("project_id = ?", projectId).Order("id").Find(&snapshot.Fields),
+		t.db.Where("project_id = ?", projectId).Order("id").Find(&snapshot.Labels),
+		t.db.Where("project_id = ?", projectId).Order("id").Find(&snapshot.Participants),
+		t.db.Joins("inner join teams on project_teams.team_id = teams.id").
+			Where("project_teams.project_id = ? AND teams.is_active = ?", projectId, true).
+			Order("project_teams.id").Find(&snapshot.Teams),
+		t.db.Where("project_id = ?", projectId).Order("due_date, id").Find(&snapshot.Milestones),
+		t.db.Where("project_id = ? AND is_active = ?", projectId, true).Order(boardOrder).Find(&snapshot.Tasks),
+		t.db.Where("task_id IN (?)", projectTasks).Find(&snapshot.TaskLabels),
+		t.db.Where("task_id IN (?)", projectTasks).Order("task_id, rank, id").Find(&snapshot.Checklist),
+	}
+	for _, query := range queries {
+		if query.Error != nil {
+			return models.ProjectSnapshot{}, query.Error
+		}
+	}
+
+	return snapshot, nil
+}
+
+// CreateFromTemplate creates the project and everything in the template in
+// one transaction. Dates are counted from start; the project's manager
+// controls its tasks.
+func (t *TemplateRepo) CreateFromTemplate(project models.Project, content models.TemplateContent,
+	start time.Time) (models.TemplateProject, error) {
+	var created models.TemplateProject
+	day := func(offset int) time.Time {
+		return start.AddDate(0, 0, offset)
+	}
+
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&project).Error; err != nil {
+			return err
+		}
+
+		for _, c := range content.Columns {
+			column := models.BoardColumn{ProjectId: project.ID, Status: c.Status, Position: c.Position,
+				WipLimit: c.WipLimit}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, f := range content.Fields {
+			field := models.CustomField{ProjectId: project.ID, Name: f.Name, Key: f.Key, Type: f.Type,
+				Options: f.Options, Required: f.Required}
+			if err := tx.Create(&field).Error; err != nil {
+				return err
+			}
+		}
+
+		labelIds := make(map[string]int, len(content.Labels))
+		for _, l := range content.Labels {
+			label := models.Label{ProjectId: project.ID, Name: l.Name, Key: l.Key, Color: l.Color}
+			if err := tx.Create(&label).Error; err != nil {
+				return err
+			}
+			labelIds[label.Key] = label.ID
+		}
+
+		for _, p := range content.Participants {
+			participant := models.ProjectParticipant{ProjectId: project.ID, ParticipantId: p.UserId, Role: p.Role}
+			if err := tx.Create(&participant).Error; err != nil {
+				return err
+			}
+			created.ParticipantIds = append(created.ParticipantIds, participant.ID)
+		}
+
+		for _, tm := range content.Teams {
+			team := models.ProjectTeam{ProjectId: project.ID, TeamId: tm.TeamId, Role: tm.Role}
+			if err := tx.Create(&team).Error; err != nil {
+				return err
+			}
+		}
+
+		milestoneIds := make([]int, 0, len(content.Milestones))
+		for _, m := range content.Milestones {
+			milestone := models.Milestone{ProjectId: project.ID, Name: m.Name, Description: m.Description,
+				DueDate: day(m.DueDay)}
+			if err := tx.Create(&milestone).Error; err != nil {
+				return err
+			}
+			milestoneIds = append(milestoneIds, milestone.ID)
+		}
+
+		lastRanks := map[string]string{}
+		for _, tt := range content.Tasks {
+			task := models.Task{
+				OrganizationId:    project.OrganizationId,
+				Title:             tt.Title,
+				Description:       tt.Description,
+				ControllerId:      project.ManagerID,
+				ExecutorId:        tt.ExecutorId,
+				TeamId:            tt.TeamId,
+				CustomFields:      tt.CustomFields,
+				Status:            tt.Status,
+				Priority:          tt.Priority,
+				Rank:              utils.RankBetween(lastRanks[tt.Status], ""),
+				OriginalEstimate:  tt.OriginalEstimate,
+				RemainingEstimate: tt.OriginalEstimate,
+				ProjectId:         project.ID,
+				Deadline:          day(tt.DeadlineDay).Format("2006-01-02"),
+				IsActive:          true,
+			}
+			lastRanks[task.Status] = task.Rank
+			if tt.Milestone != nil && *tt.Milestone >= 0 && *tt.Milestone < len(milestoneIds) {
+				task.MilestoneId = &milestoneIds[*tt.Milestone]
+			}
+
+			if err := tx.Create(&task).Error; err != nil {
+				return err
+			}
+			created.TaskIds = append(created.TaskIds, task.ID)
+
+			for _, key := range tt.Labels {
+				if labelId, ok := labelIds[key]; ok {
+					err := tx.Create(&models.TaskLabel{TaskId: task.ID, LabelId: labelId}).Error
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			for i, rank := range utils.SpreadRanks(len(tt.Checklist)) {
+				err := tx.Create(&models.ChecklistItem{TaskId: task.ID, Title: tt.Checklist[i], Rank: rank}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.TemplateProject{}, err
+	}
+
+	created.ProjectId = project.ID
+	return created, nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"gorm.io/gorm"
 	"log"
+	"strings"
 )
 
 type UserRepository struct {
@@ -55,17 +56,18 @@ func (u *UserRepository) RestoreUser(id int) error {
 	return nil
 }
 
-func (u *UserRepository) GetProjects(userId int) ([]models.ProjectParticipant, error) {
-	var projects []models.ProjectParticipant
+func (u *UserRepository) GetProjects(orgId, userId int) ([]models.UserProject, error) {
+	var projects []models.UserProject
 	rows, err := u.db.Model(&models.ProjectParticipant{}).Joins("inner join users on project_participants.participant_id = users.id").
+		Joins("inner join projects on project_participants.project_id = projects.id").
 		Select([]string{"project_participants.id", "project_participants.role", "project_participants.project_id"}).
-		Where("project_participants.participant_id = ?", userId).Rows()
+		Where("projects.organization_id = ? AND project_participants.participant_id = ?", orgId, userId).Rows()
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
-		var pro models.ProjectParticipant
+		pro := models.UserProject{ParticipantId: userId}
 		err := rows.Scan(&pro.ID, &pro.Role, &pro.ProjectId)
 		if err != nil {
 			log.Println("error while scanning from row")
@@ -75,28 +77,78 @@ func (u *UserRepository) GetProjects(userId int) ([]models.ProjectParticipant, e
 		projects = append(projects, pro)
 	}
 
+	teamRows, err := u.db.Model(&models.ProjectTeam{}).
+		Joins("inner join team_members on project_teams.team_id = team_members.team_id").
+		Joins("inner join teams on project_teams.team_id = teams.id").
+		Joins("inner join projects on project_teams.project_id = projects.id").
+		Select([]string{"project_teams.role", "project_teams.project_id"}).
+		Where("projects.organization_id = ? AND team_members.user_id = ? AND teams.is_active = ?", orgId, userId, true).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer teamRows.Close()
+
+	// Projects joined through a team are listed once, after the direct ones.
+	// They have no participant record, so they have no id.
+	for teamRows.Next() {
+		pro := models.UserProject{ParticipantId: userId}
+		err := teamRows.Scan(&pro.Role, &pro.ProjectId)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		if !containsProject(projects, pro.ProjectId) {
+			projects = append(projects, pro)
+		}
+	}
+
 	return projects, nil
 }
 
-func (u *UserRepository) GetTasks(userId int) (models.Tasks, error) {
+func containsProject(projects []models.UserProject, projectId int) bool {
+	for _, p := range projects {
+		if p.ProjectId == projectId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetTasks returns the tasks the user executes, reviews or watches. The
+// executor of a task counts as its assignee.
+func (u *UserRepository) GetTasks(orgId, userId int) (models.Tasks, error) {
 	var tasks models.Tasks
-	rows, err := u.db.Model(models.Task{}).Joins("inner join users on tasks.executor_id = users.id").
-		Joins("inner join projects on tasks.project_id = projects.id").
-		Select([]string{"tasks.id", "tasks.title", "tasks.description", "users.firstname", "tasks.status",
-			"projects.name", "tasks.deadline"}).
-		Where("tasks.executor_id = ? AND tasks.is_active = ?", userId, true).Rows()
+	rows, err := u.db.Raw(`SELECT tasks.id, tasks.title, tasks.description, COALESCE(users.firstname, ''),
+			tasks.status, projects.name, tasks.deadline, string_agg(DISTINCT r.role, ',')
+		FROM tasks
+		INNER JOIN (
+			SELECT task_id, role FROM task_assignees WHERE user_id = @user
+			UNION SELECT id, @assignee FROM tasks WHERE executor_id = @user
+		) r ON r.task_id = tasks.id
+		INNER JOIN projects ON tasks.project_id = projects.id
+		LEFT JOIN users ON tasks.executor_id = users.id
+		WHERE tasks.organization_id = @org AND tasks.is_active = true
+		GROUP BY tasks.id, users.firstname, projects.name
+		ORDER BY tasks.deadline`,
+		map[string]any{"user": userId, "org": orgId, "assignee": models.TaskRoleAssignee}).Rows()
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var task models.Task
+		var roles string
 		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.ExecutorName, &task.Status, &task.ProjectName,
-			&task.Deadline)
+			&task.Deadline, &roles)
 		if err != nil {
 			log.Println("error while scanning from row")
 			return nil, err
 		}
+		task.Roles = strings.Split(roles, ",")
 
 		tasks = append(tasks, task)
 	}
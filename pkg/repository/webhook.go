@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+type WebhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+func (w *WebhookRepo) CreateWebhook(webhook models.Webhook) (int, error) {
+	err := w.db.Create(&webhook).Error
+	if err != nil {
+		return -1, err
+	}
+
+	return webhook.ID, nil
+}
+
+func (w *WebhookRepo) GetWebhooks(orgId int) (models.Webhooks, error) {
+	var webhooks models.Webhooks
+	err := w.db.Where("organization_id = ?", orgId).Order("id").Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookRepo) GetWebhook(orgId, id int) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := w.db.Where("id = ? AND organization_id = ?", id, orgId).First(&webhook).Error
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (w *WebhookRepo) UpdateWebhook(webhook models.Webhook) error {
+	result := w.db.Model(&models.Webhook{}).
+		Where("id = ? AND organization_id = ?", webhook.ID, webhook.OrganizationId).
+		Updates(map[string]any{"url": webhook.URL, "project_id": webhook.ProjectId, "events": webhook.Events,
+			"is_active": webhook.IsActive})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebhook deletes the webhook together with its delivery log.
+func (w *WebhookRepo) DeleteWebhook(orgId, id int) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id IN (SELECT id FROM webhooks WHERE id = ? AND organization_id = ?)", id, orgId).
+			Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND organization_id = ?", id, orgId).Delete(&models.Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// CreateDeliveries queues the event for every active webhook of the
+// organization that is subscribed to it, whether to the whole organization
+// or to the project.
+func (w *WebhookRepo) CreateDeliveries(orgId, projectId int, event string, payload models.JSONMap) error {
+	return w.db.Exec("INSERT INTO webhook_deliveries (webhook_id, event, payload, status, attempts, "+
+		"next_attempt_at, created_at) "+
+		"SELECT webhooks.id, ?, ?::jsonb, ?, 0, now(), now() FROM webhooks "+
+		"WHERE webhooks.organization_id = ? AND webhooks.is_active = ? "+
+		"AND (webhooks.project_id IS NULL OR webhooks.project_id = ?) "+
+		"AND (webhooks.events = '[]'::jsonb OR webhooks.events @> jsonb_build_array(?::text))",
+		event, payload, models.DeliveryPending, orgId, true, projectId, event).Error
+}
+
+// ClaimDeliveries takes up to limit pending deliveries of active webhooks
+// that are due by now and puts their next attempt off by lease, so that
+// neither another worker nor this one picks them up again while they are
+// being sent. Should the worker stop before saving the attempt, they are
+// tried again once the lease is over.
+func (w *WebhookRepo) ClaimDeliveries(now time.Time, lease time.Duration, limit int) ([]models.DueDelivery, error) {
+	rows, err := w.db.Raw("UPDATE webhook_deliveries SET next_attempt_at = ? FROM webhooks "+
+		"WHERE webhooks.id = webhook_deliveries.webhook_id AND webhook_deliveries.id IN "+
+		"(SELECT webhook_deliveries.id FROM webhook_deliveries "+
+		"INNER JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id "+
+		"WHERE webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ? AND webhooks.is_active = ? "+
+		"ORDER BY webhook_deliveries.next_attempt_at LIMIT ? FOR UPDATE OF webhook_deliveries SKIP LOCKED) "+
+		"RETURNING webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, "+
+		"webhook_deliveries.payload, webhook_deliveries.attempts, webhook_deliveries.created_at, "+
+		"webhooks.url, webhooks.secret",
+		now.Add(lease), models.DeliveryPending, now, true, limit).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.DueDelivery
+	for rows.Next() {
+		var delivery models.DueDelivery
+		err := rows.Scan(&delivery.ID, &delivery.WebhookId, &delivery.Event, &delivery.Payload, &delivery.Attempts,
+			&delivery.CreatedAt, &delivery.URL, &delivery.Secret)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// SaveAttempt records how the last attempt of the delivery went.
+func (w *WebhookRepo) SaveAttempt(delivery models.WebhookDelivery) error {
+	return w.db.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).
+		Updates(map[string]any{"status": delivery.Status, "attempts": delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt, "last_status_code": delivery.LastStatusCode,
+			"last_error": delivery.LastError, "delivered_at": delivery.DeliveredAt}).Error
+}
+
+func (w *WebhookRepo) GetDeliveries(webhookId, beforeId, limit int) (models.WebhookDeliveries, error) {
+	query := w.db.Where("webhook_id = ?", webhookId)
+	if beforeId != 0 {
+		query = query.Where("id < ?", beforeId)
+	}
+
+	deliveries := models.WebhookDeliveries{}
+	err := query.Order("id DESC").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver queues the payload of a past delivery of the webhook again, as
+// a new delivery to be sent now.
+func (w *WebhookRepo) Redeliver(webhookId, deliveryId int) (int, error) {
+	var ids []int
+	err := w.db.Raw("INSERT INTO webhook_deliveries (webhook_id, event, payload, status, attempts, "+
+		"next_attempt_at, created_at) "+
+		"SELECT webhook_id, event, payload, ?, 0, now(), now() FROM webhook_deliveries "+
+		"WHERE id = ? AND webhook_id = ? RETURNING id",
+		models.DeliveryPending, deliveryId, webhookId).Scan(&ids).Error
+	if err != nil {
+		return -1, err
+	}
+
+	if len(ids) == 0 {
+		return -1, gorm.ErrRecordNotFound
+	}
+
+	return ids[0], nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"time"
+)
+
+var ErrTimerRunning = errors.New("you already have a running timer")
+
+type WorklogRepo struct {
+	db *gorm.DB
+}
+
+func NewWorklogRepo(db *gorm.DB) *WorklogRepo {
+	return &WorklogRepo{db: db}
+}
+
+// CanLogWork reports whether the user works on the task: as its controller,
+// executor, one of its assignees or a member of its team.
+func (w *WorklogRepo) CanLogWork(orgId, userId, taskId int) bool {
+	var count int64
+	err := w.db.Model(&models.Task{}).
+		Where("tasks.id = ? AND tasks.organization_id = ? AND tasks.is_active = ?", taskId, orgId, true).
+		Where("tasks.controller_id = ? OR tasks.executor_id = ? OR "+
+			"EXISTS (SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id AND "+
+			"task_assignees.user_id = ?) OR "+
+			"EXISTS (SELECT 1 FROM team_members WHERE team_members.team_id = tasks.team_id AND "+
+			"team_members.user_id = ?)", userId, userId, userId, userId).
+		Count(&count).Error
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
+// spendEstimate takes the logged minutes off the task's remaining estimate,
+// which never goes below zero.
+func spendEstimate(tx *gorm.DB, taskId, minutes int) error {
+	return tx.Model(&models.Task{}).Where("id = ? AND remaining_estimate IS NOT NULL", taskId).
+		Update("remaining_estimate", gorm.Expr("GREATEST(remaining_estimate - ?, 0)", minutes)).Error
+}
+
+func (w *WorklogRepo) CreateWorklog(worklog models.Worklog) (int, error) {
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&worklog).Error; err != nil {
+			return err
+		}
+
+		return spendEstimate(tx, worklog.TaskId, worklog.Minutes)
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return worklog.ID, nil
+}
+
+func (w *WorklogRepo) GetWorklogs(taskId int) (models.Worklogs, error) {
+	var worklogs models.Worklogs
+	rows, err := w.db.Model(&models.Worklog{}).Joins("inner join users on worklogs.user_id = users.id").
+		Select([]string{"worklogs.id", "worklogs.task_id", "worklogs.user_id", "users.firstname",
+			"worklogs.started_at", "worklogs.ended_at", "worklogs.minutes", "worklogs.note"}).
+		Where("worklogs.task_id = ?", taskId).
+		Order("worklogs.started_at, worklogs.id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wl models.Worklog
+		err := rows.Scan(&wl.ID, &wl.TaskId, &wl.UserId, &wl.Firstname, &wl.StartedAt, &wl.EndedAt, &wl.Minutes,
+			&wl.Note)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		worklogs = append(worklogs, wl)
+	}
+
+	return worklogs, nil
+}
+
+// DeleteWorklog removes a finished worklog of the user. The remaining
+// estimate is left alone, since it may have been re-estimated since.
+func (w *WorklogRepo) DeleteWorklog(userId, taskId, id int) error {
+	tx := w.db.Where("id = ? AND task_id = ? AND user_id = ? AND ended_at IS NOT NULL", id, taskId, userId).
+		Delete(&models.Worklog{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (w *WorklogRepo) GetRunningTimer(userId int) (models.Worklog, error) {
+	var worklog models.Worklog
+	err := w.db.Where("user_id = ? AND ended_at IS NULL", userId).First(&worklog).Error
+	if err != nil {
+		return models.Worklog{}, err
+	}
+
+	return worklog, nil
+}
+
+// StartTimer opens a worklog without an end. The unique index on running
+// timers stops a second one from starting, even when two requests race.
+func (w *WorklogRepo) StartTimer(worklog models.Worklog) (int, error) {
+	if _, err := w.GetRunningTimer(worklog.UserId); err == nil {
+		return -1, ErrTimerRunning
+	}
+
+	err := w.db.Create(&worklog).Error
+	if err != nil {
+		if _, running := w.GetRunningTimer(worklog.UserId); running == nil {
+			return -1, ErrTimerRunning
+		}
+		return -1, err
+	}
+
+	return worklog.ID, nil
+}
+
+// StopTimer ends the user's running timer at the given time and logs its
+// minutes against the task.
+func (w *WorklogRepo) StopTimer(userId int, endedAt time.Time) (models.Worklog, error) {
+	var worklog models.Worklog
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND ended_at IS NULL", userId).First(&worklog).Error
+		if err != nil {
+			return err
+		}
+
+		worklog.EndedAt = &endedAt
+		worklog.Minutes = int(endedAt.Sub(worklog.StartedAt).Round(time.Minute).Minutes())
+		err = tx.Model(&worklog).Updates(map[string]any{"ended_at": endedAt, "minutes": worklog.Minutes}).Error
+		if err != nil {
+			return err
+		}
+
+		return spendEstimate(tx, worklog.TaskId, worklog.Minutes)
+	})
+	if err != nil {
+		return models.Worklog{}, err
+	}
+
+	return worklog, nil
+}
+
+// GetTimesheet sums the finished worklogs by user, project and the week they
+// started in.
+func (w *WorklogRepo) GetTimesheet(filter models.TimesheetFilter) ([]models.TimesheetEntry, error) {
+	var entries []models.TimesheetEntry
+	query := w.db.Model(&models.Worklog{}).Joins("inner join tasks on worklogs.task_id = tasks.id").
+		Joins("inner join projects on tasks.project_id = projects.id").
+		Joins("inner join users on worklogs.user_id = users.id").
+		Select([]string{"worklogs.user_id", "users.firstname", "users.lastname", "tasks.project_id", "projects.name",
+			"date_trunc('week', worklogs.started_at)::date AS week", "sum(worklogs.minutes)"}).
+		Where("tasks.organization_id = ? AND worklogs.ended_at IS NOT NULL AND worklogs.started_at >= ? AND "+
+			"worklogs.started_at < ?", filter.OrganizationId, filter.From, filter.To)
+
+	if filter.UserId != 0 {
+		query = query.Where("worklogs.user_id = ?", filter.UserId)
+	}
+
+	if filter.ProjectId != 0 {
+		query = query.Where("tasks.project_id = ?", filter.ProjectId)
+	}
+
+	rows, err := query.Group("worklogs.user_id, users.firstname, users.lastname, tasks.project_id, projects.name, week").
+		Order("week, users.firstname, worklogs.user_id, projects.name").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.TimesheetEntry
+		err := rows.Scan(&e.UserId, &e.Firstname, &e.Lastname, &e.ProjectId, &e.ProjectName, &e.Week, &e.Minutes)
+		if err != nil {
+			log.Println("error while scanning from row")
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
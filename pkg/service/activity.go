@@ -0,0 +1,291 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// ActivityFeed adds to the activity feeds from the services that make the
+// changes, notifies the users an activity concerns, queues it for the
+// webhooks subscribed to it and publishes it to the clients streaming its
+// organization. Failing to do so is only logged: the change
+// itself has already been made.
+type ActivityFeed struct {
+	repo          repository.Activity
+	notifications repository.Notification
+	webhooks      repository.Webhook
+	events        broker.Broker
+}
+
+func NewActivityFeed(activity repository.Activity, notifications repository.Notification,
+	webhooks repository.Webhook, events broker.Broker) *ActivityFeed {
+	return &ActivityFeed{repo: activity, notifications: notifications, webhooks: webhooks, events: events}
+}
+
+func (f *ActivityFeed) add(activity models.Activity) {
+	saved, err := f.repo.CreateActivity(activity)
+	if err != nil {
+		log.Println("failed to add to the activity feed. Error is: ", err.Error())
+	} else {
+		f.publish(saved)
+	}
+
+	actorId := activity.ActorId
+	switch activity.Verb {
+	case models.ActivityTaskCreated, models.ActivityTaskAssigned:
+		if activity.UserId != nil {
+			f.notify(models.Notification{Type: models.NotificationAssigned, ActorId: &actorId,
+				TaskId: activity.TaskId, Data: activity.Data}, without([]int{*activity.UserId}, actorId))
+		}
+	case models.ActivityTaskStatusChanged:
+		f.notify(models.Notification{Type: models.NotificationStatusChanged, ActorId: &actorId,
+			TaskId: activity.TaskId, Data: activity.Data}, f.followers(*activity.TaskId, actorId))
+	}
+}
+
+// publish sends the saved activity out to the webhooks and the streams.
+func (f *ActivityFeed) publish(activity models.Activity) {
+	err := f.webhooks.CreateDeliveries(activity.OrganizationId, activity.ProjectId, activity.Verb,
+		webhookPayload(activity))
+	if err != nil {
+		log.Println("failed to queue the webhook deliveries. Error is: ", err.Error())
+	}
+
+	err = f.events.Publish(broker.Event{
+		ID:             activity.ID,
+		Type:           activity.Verb,
+		OrganizationId: activity.OrganizationId,
+		ProjectId:      activity.ProjectId,
+		TaskId:         activity.TaskId,
+		ActorId:        activity.ActorId,
+		UserId:         activity.UserId,
+		Data:           activity.Data,
+		CreatedAt:      activity.CreatedAt,
+	})
+	if err != nil {
+		log.Println("failed to publish the activity. Error is: ", err.Error())
+	}
+}
+
+func (f *ActivityFeed) task(actorId int, verb string, taskId int, data models.JSONMap) {
+	f.add(models.Activity{ActorId: actorId, Verb: verb, TaskId: &taskId, Data: data})
+}
+
+// updated adds that the fields of the tasks changed.
+func (f *ActivityFeed) updated(actorId int, taskIds []int, fields ...string) {
+	for _, taskId := range taskIds {
+		f.task(actorId, models.ActivityTaskUpdated, taskId, models.JSONMap{"fields": fields})
+	}
+}
+
+func (f *ActivityFeed) project(actorId int, verb string, projectId int, data models.JSONMap) {
+	f.add(models.Activity{ActorId: actorId, Verb: verb, ProjectId: projectId, Data: data})
+}
+
+func (f *ActivityFeed) notify(notification models.Notification, userIds []int) {
+	if err := f.notifications.CreateNotifications(notification, userIds); err != nil {
+		log.Println("failed to send the notifications. Error is: ", err.Error())
+	}
+}
+
+// followers returns who follows the task, leaving out the given users.
+func (f *ActivityFeed) followers(taskId int, exclude ...int) []int {
+	ids, err := f.notifications.GetTaskFollowers(taskId)
+	if err != nil {
+		log.Println("failed to get the followers of the task. Error is: ", err.Error())
+		return nil
+	}
+
+	return without(ids, exclude...)
+}
+
+// without drops the excluded ids from the list.
+func without(ids []int, exclude ...int) []int {
+	excluded := make(map[int]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	kept := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !excluded[id] {
+			kept = append(kept, id)
+		}
+	}
+
+	return kept
+}
+
+// updatedFields lists what an update changes in the task besides its
+// status and its executor, which have activities of their own.
+func updatedFields(current, task models.Task) []string {
+	var fields []string
+	changed := func(field string, from, to any) {
+		if !reflect.DeepEqual(from, to) {
+			fields = append(fields, field)
+		}
+	}
+
+	changed("title", current.Title, task.Title)
+	changed("description", current.Description, task.Description)
+	changed("priority", current.Priority, task.Priority)
+	changed("team", current.TeamId, task.TeamId)
+	changed("original_estimate", current.OriginalEstimate, task.OriginalEstimate)
+	changed("remaining_estimate", current.RemainingEstimate, task.RemainingEstimate)
+
+	if len(current.CustomFields) > 0 || len(task.CustomFields) > 0 {
+		changed("custom_fields", current.CustomFields, task.CustomFields)
+	}
+
+	from, fromErr := utils.ParseDeadline(current.Deadline)
+	to, toErr := utils.ParseDeadline(task.Deadline)
+	if fromErr != nil || toErr != nil {
+		changed("deadline", current.Deadline, task.Deadline)
+	} else if !from.Equal(to) {
+		fields = append(fields, "deadline")
+	}
+
+	return fields
+}
+
+// joinFields lists the fields for a message: "title, priority and deadline".
+func joinFields(fields []string) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = strings.ReplaceAll(field, "_", " ")
+	}
+
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// activityMessage puts the activity into words, like "Ali moved 'Deploy
+// API' to Review".
+func activityMessage(activity models.Activity) string {
+	text := func(key string) string {
+		if value, ok := activity.Data[key].(string); ok {
+			return value
+		}
+		return ""
+	}
+
+	actor, title, project := activity.ActorName, text("title"), text("project")
+
+	switch activity.Verb {
+	case models.ActivityTaskCreated:
+		return fmt.Sprintf("%s created '%s'", actor, title)
+	case models.ActivityTaskUpdated:
+		var fields []string
+		if list, ok := activity.Data["fields"].([]any); ok {
+			for _, field := range list {
+				if name, ok := field.(string); ok {
+					fields = append(fields, name)
+				}
+			}
+		}
+		if len(fields) == 0 {
+			return fmt.Sprintf("%s updated '%s'", actor, title)
+		}
+		return fmt.Sprintf("%s changed the %s of '%s'", actor, joinFields(fields), title)
+	case models.ActivityTaskStatusChanged:
+		return fmt.Sprintf("%s moved '%s' to %s", actor, title, text("to"))
+	case models.ActivityTaskAssigned:
+		if role := text("role"); role != "" && role != models.TaskRoleAssignee {
+			return fmt.Sprintf("%s made %s a %s of '%s'", actor, activity.UserName, role, title)
+		}
+		return fmt.Sprintf("%s assigned '%s' to %s", actor, title, activity.UserName)
+	case models.ActivityTaskUnassigned:
+		return fmt.Sprintf("%s removed %s from '%s'", actor, activity.UserName, title)
+	case models.ActivityTaskClaimed:
+		return fmt.Sprintf("%s claimed '%s'", actor, title)
+	case models.ActivityTaskMoved:
+		return fmt.Sprintf("%s moved '%s' to the project %s", actor, title, project)
+	case models.ActivityTaskCopied:
+		return fmt.Sprintf("%s copied '%s' to the project %s", actor, title, project)
+	case models.ActivityTaskDeleted:
+		return fmt.Sprintf("%s deleted '%s'", actor, title)
+	case models.ActivityTaskRestored:
+		return fmt.Sprintf("%s restored '%s'", actor, title)
+	case models.ActivityTaskCommented:
+		return fmt.Sprintf("%s commented on '%s'", actor, title)
+	case models.ActivityProjectCreated:
+		return fmt.Sprintf("%s created the project %s", actor, project)
+	case models.ActivityProjectUpdated:
+		return fmt.Sprintf("%s updated the project %s", actor, project)
+	case models.ActivityProjectDeleted:
+		return fmt.Sprintf("%s deleted the project %s", actor, project)
+	case models.ActivityProjectRestored:
+		return fmt.Sprintf("%s restored the project %s", actor, project)
+	case models.ActivityParticipantAdded:
+		if team := text("team"); team != "" {
+			return fmt.Sprintf("%s added the team %s to the project %s", actor, team, project)
+		}
+		return fmt.Sprintf("%s added %s to the project %s", actor, activity.UserName, project)
+	case models.ActivityParticipantRemoved:
+		if team := text("team"); team != "" {
+			return fmt.Sprintf("%s removed the team %s from the project %s", actor, team, project)
+		}
+		return fmt.Sprintf("%s removed %s from the project %s", actor, activity.UserName, project)
+	case models.ActivityParticipantJoined:
+		return fmt.Sprintf("%s joined the project %s", actor, project)
+	}
+
+	return fmt.Sprintf("%s: %s", actor, strings.ReplaceAll(activity.Verb, "_", " "))
+}
+
+type ActivityService struct {
+	repo repository.Activity
+	task repository.Task
+}
+
+func NewActivityService(repo repository.Activity, task repository.Task) *ActivityService {
+	return &ActivityService{repo: repo, task: task}
+}
+
+func (a *ActivityService) page(filter models.ActivityFilter) (models.ActivityPage, error) {
+	filter.Limit = pageLimit(filter.Limit)
+
+	activities, err := a.repo.GetActivities(filter)
+	if err != nil {
+		log.Println("failed to get the activity feed. Error is: ", err.Error())
+		return models.ActivityPage{}, err
+	}
+
+	for i := range activities {
+		activities[i].Message = activityMessage(activities[i])
+	}
+
+	page := models.ActivityPage{Activities: activities}
+	if len(activities) == filter.Limit {
+		page.NextBeforeId = &activities[len(activities)-1].ID
+	}
+
+	return page, nil
+}
+
+// GetProjectActivity returns the feed of the project to those who take part
+// in it.
+func (a *ActivityService) GetProjectActivity(userId int, filter models.ActivityFilter) (models.ActivityPage, error) {
+	if !a.task.ProjectInOrganization(filter.OrganizationId, filter.ProjectId) ||
+		!a.task.IsProjectMember(filter.ProjectId, userId) {
+		return models.ActivityPage{}, errors.New("project doesn't exist or you don't take part in it")
+	}
+
+	return a.page(filter)
+}
+
+// GetUserActivity returns the feed of every project of the organization the
+// user takes part in, together with the activities about the user.
+func (a *ActivityService) GetUserActivity(filter models.ActivityFilter) (models.ActivityPage, error) {
+	return a.page(filter)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testFeed builds an activity feed that accepts whatever it is given and
+// publishes it, unchanged, to the returned broker under organization 0.
+func testFeed(c *gomock.Controller) (*ActivityFeed, *broker.Memory) {
+	activity := mock_repository.NewMockActivity(c)
+	activity.EXPECT().CreateActivity(gomock.Any()).DoAndReturn(func(a models.Activity) (models.Activity, error) {
+		return a, nil
+	}).AnyTimes()
+
+	webhooks := mock_repository.NewMockWebhook(c)
+	webhooks.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	notifications := mock_repository.NewMockNotification(c)
+	notifications.EXPECT().GetTaskFollowers(gomock.Any()).Return(nil, nil).AnyTimes()
+	notifications.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	events := broker.NewMemory()
+	return NewActivityFeed(activity, notifications, webhooks, events), events
+}
+
+// updatedTasks drains the events published so far and returns the tasks
+// they report updated, with the fields that changed.
+func updatedTasks(subscription *broker.Subscription) map[int]any {
+	updated := map[int]any{}
+	for {
+		select {
+		case event := <-subscription.C:
+			if event.Type == models.ActivityTaskUpdated && event.TaskId != nil {
+				updated[*event.TaskId] = event.Data["fields"]
+			}
+		default:
+			return updated
+		}
+	}
+}
+
+func TestActivityMessage(t *testing.T) {
+	testTable := []struct {
+		name     string
+		activity models.Activity
+		expected string
+	}{
+		{
+			name: "status changed",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityTaskStatusChanged,
+				Data: models.JSONMap{"title": "Deploy API", "from": "In progress", "to": "Review"}},
+			expected: "Ali moved 'Deploy API' to Review",
+		},
+		{
+			name: "updated fields",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityTaskUpdated,
+				Data: models.JSONMap{"title": "Deploy API", "fields": []any{"title", "priority", "original_estimate"}}},
+			expected: "Ali changed the title, priority and original estimate of 'Deploy API'",
+		},
+		{
+			name: "assigned",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityTaskAssigned, UserName: "Vali",
+				Data: models.JSONMap{"title": "Deploy API", "role": models.TaskRoleAssignee}},
+			expected: "Ali assigned 'Deploy API' to Vali",
+		},
+		{
+			name: "reviewer",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityTaskAssigned, UserName: "Vali",
+				Data: models.JSONMap{"title": "Deploy API", "role": models.TaskRoleReviewer}},
+			expected: "Ali made Vali a reviewer of 'Deploy API'",
+		},
+		{
+			name: "moved to another project",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityTaskMoved,
+				Data: models.JSONMap{"title": "Deploy API", "project": "Backend"}},
+			expected: "Ali moved 'Deploy API' to the project Backend",
+		},
+		{
+			name: "participant added",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityParticipantAdded, UserName: "Vali",
+				Data: models.JSONMap{"project": "Backend"}},
+			expected: "Ali added Vali to the project Backend",
+		},
+		{
+			name: "team added",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityParticipantAdded,
+				Data: models.JSONMap{"project": "Backend", "team": "Platform"}},
+			expected: "Ali added the team Platform to the project Backend",
+		},
+		{
+			name: "team removed",
+			activity: models.Activity{ActorName: "Ali", Verb: models.ActivityParticipantRemoved,
+				Data: models.JSONMap{"project": "Backend", "team": "Platform"}},
+			expected: "Ali removed the team Platform from the project Backend",
+		},
+		{
+			name:     "unknown verb",
+			activity: models.Activity{ActorName: "Ali", Verb: "sprint_started"},
+			expected: "Ali: sprint started",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, activityMessage(test.activity))
+		})
+	}
+}
+
+func TestUpdatedFields(t *testing.T) {
+	teamId, estimate := 3, 60
+	current := models.Task{Title: "Deploy API", Priority: models.PriorityMedium, Status: "In progress",
+		Deadline: "2024-03-04T00:00:00Z", CustomFields: models.JSONMap{}}
+
+	testTable := []struct {
+		name     string
+		change   func(task *models.Task)
+		expected []string
+	}{
+		{
+			name:   "same deadline in another format",
+			change: func(task *models.Task) { task.Deadline = "2024-03-04" },
+		},
+		{
+			name:   "status is left out",
+			change: func(task *models.Task) { task.Status = models.StatusDone },
+		},
+		{
+			name:   "empty custom fields",
+			change: func(task *models.Task) { task.CustomFields = nil },
+		},
+		{
+			name: "several fields",
+			change: func(task *models.Task) {
+				task.Title = "Deploy the API"
+				task.TeamId = &teamId
+				task.OriginalEstimate = &estimate
+				task.Deadline = "2024-03-05"
+			},
+			expected: []string{"title", "team", "original_estimate", "deadline"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			task := current
+			test.change(&task)
+			assert.Equal(t, test.expected, updatedFields(current, task))
+		})
+	}
+}
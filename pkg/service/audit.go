@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"reflect"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// pageLimit bounds the size of a page of the audit log or of a feed.
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+
+	return limit
+}
+
+type AuditService struct {
+	repo repository.Audit
+}
+
+func NewAuditService(repo repository.Audit) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+type auditSourceKey struct{}
+
+// WithAuditSource returns a copy of ctx that carries who makes the changes
+// and from where to the entries of the audit log.
+func WithAuditSource(ctx context.Context, source models.AuditSource) context.Context {
+	return context.WithValue(ctx, auditSourceKey{}, source)
+}
+
+func auditSource(ctx context.Context) models.AuditSource {
+	source, _ := ctx.Value(auditSourceKey{}).(models.AuditSource)
+	return source
+}
+
+// AuditLog records in the audit log the changes the services make to the
+// audited entities, with the source carried by the context of the change.
+// Failing to do so is only logged: the change itself has already been made.
+type AuditLog struct {
+	repo repository.Audit
+}
+
+func NewAuditLog(repo repository.Audit) *AuditLog {
+	return &AuditLog{repo: repo}
+}
+
+// auditChange holds the states of the entities from before a change until
+// it is recorded.
+type auditChange struct {
+	log        *AuditLog
+	entityType string
+	ids        []int
+	before     []models.JSONMap
+}
+
+// track snapshots the entities before they are changed.
+func (a *AuditLog) track(entityType string, ids ...int) *auditChange {
+	change := &auditChange{log: a, entityType: entityType, ids: ids, before: make([]models.JSONMap, len(ids))}
+	for i, id := range ids {
+		change.before[i] = a.snapshot(entityType, id)
+	}
+
+	return change
+}
+
+// record saves an entry for each of the entities with what the change did to
+// them. Updates that changed nothing aren't recorded.
+func (c *auditChange) record(ctx context.Context, action string) {
+	for i, id := range c.ids {
+		c.log.save(ctx, action, c.entityType, id, auditChanges(c.before[i], c.log.snapshot(c.entityType, id)))
+	}
+}
+
+// created records the entities that were made.
+func (a *AuditLog) created(ctx context.Context, entityType string, ids ...int) {
+	for _, id := range ids {
+		a.save(ctx, models.AuditCreate, entityType, id, auditChanges(nil, a.snapshot(entityType, id)))
+	}
+}
+
+// changed records the change of one field of the entities, for changes made
+// in bulk that are known without reading the entities.
+func (a *AuditLog) changed(ctx context.Context, entityType string, ids []int, field string, from, to any) {
+	for _, id := range ids {
+		a.save(ctx, models.AuditUpdate, entityType, id, models.JSONMap{field: map[string]any{"from": from, "to": to}})
+	}
+}
+
+// snapshot reads the entity for a later entry. Failing to read it only
+// leaves the entry incomplete.
+func (a *AuditLog) snapshot(entityType string, id int) models.JSONMap {
+	state, err := a.repo.GetState(entityType, id)
+	if err != nil {
+		log.Println("failed to read the state of the entity for the audit log. Error is: ", err.Error())
+		return nil
+	}
+
+	return state
+}
+
+func (a *AuditLog) save(ctx context.Context, action, entityType string, id int, changes models.JSONMap) {
+	if action == models.AuditUpdate && len(changes) == 0 {
+		return
+	}
+
+	source := auditSource(ctx)
+	entry := models.AuditEntry{
+		OrganizationId: source.OrganizationId,
+		ActorId:        source.ActorId,
+		ImpersonatorId: source.ImpersonatorId,
+		Action:         action,
+		EntityType:     entityType,
+		EntityId:       id,
+		Changes:        changes,
+		IP:             source.IP,
+		RequestId:      source.RequestId,
+	}
+
+	if err := a.repo.CreateEntry(entry); err != nil {
+		log.Println("failed to save the audit entry. Error is: ", err.Error())
+	}
+}
+
+// auditChanges lists the fields that differ between the two states of an
+// entity. A missing state, before a create, counts as having no fields.
+func auditChanges(before, after models.JSONMap) models.JSONMap {
+	changes := models.JSONMap{}
+	change := func(key string) {
+		if key == "updated_at" {
+			return
+		}
+
+		from, to := before[key], after[key]
+		if !reflect.DeepEqual(from, to) {
+			changes[key] = map[string]any{"from": from, "to": to}
+		}
+	}
+
+	for key := range before {
+		change(key)
+	}
+
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			change(key)
+		}
+	}
+
+	return changes
+}
+
+func (a *AuditService) GetEntries(filter models.AuditFilter) (models.AuditPage, error) {
+	filter.Limit = pageLimit(filter.Limit)
+
+	if !filter.To.IsZero() {
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+
+	entries, err := a.repo.GetEntries(filter)
+	if err != nil {
+		log.Println("failed to get the audit log. Error is: ", err.Error())
+		return models.AuditPage{}, err
+	}
+
+	page := models.AuditPage{Entries: entries}
+	if len(entries) == filter.Limit {
+		page.NextBeforeId = &entries[len(entries)-1].ID
+	}
+
+	return page, nil
+}
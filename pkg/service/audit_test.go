@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// auditStore holds the states of the entities, keyed by "<type>:<id>", and
+// the entries saved by the audit log of testAudit.
+type auditStore struct {
+	states  map[string]models.JSONMap
+	entries []models.AuditEntry
+}
+
+func (s *auditStore) set(entityType string, id int, state models.JSONMap) {
+	s.states[fmt.Sprintf("%s:%d", entityType, id)] = state
+}
+
+func testAudit(c *gomock.Controller) (*AuditLog, *auditStore) {
+	store := &auditStore{states: map[string]models.JSONMap{}}
+
+	repo := mock_repository.NewMockAudit(c)
+	repo.EXPECT().GetState(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(entityType string, id int) (models.JSONMap, error) {
+			return store.states[fmt.Sprintf("%s:%d", entityType, id)], nil
+		}).AnyTimes()
+	repo.EXPECT().CreateEntry(gomock.Any()).DoAndReturn(func(entry models.AuditEntry) error {
+		store.entries = append(store.entries, entry)
+		return nil
+	}).AnyTimes()
+
+	return NewAuditLog(repo), store
+}
+
+func TestAuditChanges(t *testing.T) {
+	testTable := []struct {
+		name     string
+		before   models.JSONMap
+		after    models.JSONMap
+		expected models.JSONMap
+	}{
+		{
+			name:   "created",
+			before: nil,
+			after:  models.JSONMap{"id": float64(1), "title": "Draft"},
+			expected: models.JSONMap{
+				"id":    map[string]any{"from": nil, "to": float64(1)},
+				"title": map[string]any{"from": nil, "to": "Draft"},
+			},
+		},
+		{
+			name:   "changed deadline only",
+			before: models.JSONMap{"deadline": "2024-03-01T00:00:00", "title": "Draft", "updated_at": "a"},
+			after:  models.JSONMap{"deadline": "2024-03-08T00:00:00", "title": "Draft", "updated_at": "b"},
+			expected: models.JSONMap{
+				"deadline": map[string]any{"from": "2024-03-01T00:00:00", "to": "2024-03-08T00:00:00"},
+			},
+		},
+		{
+			name:   "nested values",
+			before: models.JSONMap{"assignees": map[string]any{"2": "reviewer"}},
+			after:  models.JSONMap{"assignees": map[string]any{"2": "reviewer", "3": "assignee"}},
+			expected: models.JSONMap{"assignees": map[string]any{
+				"from": map[string]any{"2": "reviewer"},
+				"to":   map[string]any{"2": "reviewer", "3": "assignee"},
+			}},
+		},
+		{
+			name:     "nothing changed",
+			before:   models.JSONMap{"label_ids": []any{float64(1)}},
+			after:    models.JSONMap{"label_ids": []any{float64(1)}},
+			expected: models.JSONMap{},
+		},
+		{
+			name:   "deleted",
+			before: models.JSONMap{"id": float64(1)},
+			after:  nil,
+			expected: models.JSONMap{
+				"id": map[string]any{"from": float64(1), "to": nil},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, auditChanges(test.before, test.after))
+		})
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	audit, store := testAudit(c)
+
+	actorId, orgId := 1, 2
+	ctx := WithAuditSource(context.Background(), models.AuditSource{OrganizationId: &orgId, ActorId: &actorId,
+		IP: "192.0.2.1", RequestId: "req-1"})
+
+	store.set(models.EntityTask, 5, models.JSONMap{"status": "Not started"})
+	store.set(models.EntityTask, 6, models.JSONMap{"status": "Done"})
+	change := audit.track(models.EntityTask, 5, 6)
+	store.set(models.EntityTask, 5, models.JSONMap{"status": "Done"})
+	change.record(ctx, models.AuditUpdate)
+
+	store.set(models.EntityProject, 7, models.JSONMap{"name": "New"})
+	audit.created(ctx, models.EntityProject, 7)
+
+	audit.changed(context.Background(), models.EntityTask, []int{5}, "is_overdue", false, true)
+
+	assert.Equal(t, []models.AuditEntry{
+		{OrganizationId: &orgId, ActorId: &actorId, Action: models.AuditUpdate, EntityType: models.EntityTask,
+			EntityId: 5, Changes: models.JSONMap{"status": map[string]any{"from": "Not started", "to": "Done"}},
+			IP: "192.0.2.1", RequestId: "req-1"},
+		{OrganizationId: &orgId, ActorId: &actorId, Action: models.AuditCreate, EntityType: models.EntityProject,
+			EntityId: 7, Changes: models.JSONMap{"name": map[string]any{"from": nil, "to": "New"}},
+			IP: "192.0.2.1", RequestId: "req-1"},
+		{Action: models.AuditUpdate, EntityType: models.EntityTask, EntityId: 5,
+			Changes: models.JSONMap{"is_overdue": map[string]any{"from": false, "to": true}}},
+	}, store.entries)
+}
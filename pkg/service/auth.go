@@ -1,81 +1,55 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/sharifsharifzoda/project-management-system/logging"
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
-	"os"
 	"strings"
 	"time"
 )
 
 type AuthService struct {
 	repo   repository.Authorization
+	keys   *KeySet
 	logger *logging.Logger
+	audit  *AuditLog
 }
 
-func NewAuthService(repo repository.Authorization, log *logging.Logger) *AuthService {
-	return &AuthService{repo: repo, logger: log}
+func NewAuthService(repo repository.Authorization, keys *KeySet, log *logging.Logger, audit *AuditLog) *AuthService {
+	return &AuthService{repo: repo, keys: keys, logger: log, audit: audit}
 }
 
 type tokenClaims struct {
-	jwt.StandardClaims
-	UserID   int    `json:"user_id"`
-	UserRole string `json:"user_role"`
+	jwt.RegisteredClaims
+	UserID         int    `json:"user_id"`
+	UserRole       string `json:"user_role"`
+	ImpersonatorID int    `json:"impersonator_id,omitempty"`
 }
 
+const impersonationTTL = 5 * time.Minute
+
+// passwordForbidden are the characters a password can't contain.
+const passwordForbidden = `_-@#$%&*():./\,;?"!~`
+
+// ValidateUser returns the rule the email or the password breaks, if any.
 func (s *AuthService) ValidateUser(user models.User) error {
 	if len(user.Email) > 30 || len(user.Email) < 5 {
 		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
+		return errors.New("the email must be 5 to 30 characters long")
 	}
 	if len(user.Password) > 20 || len(user.Password) < 6 {
 		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "_") || strings.Contains(user.Password, "-") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "@") || strings.Contains(user.Password, "#") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "$") || strings.Contains(user.Password, "%") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "&") || strings.Contains(user.Password, "*") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "(") || strings.Contains(user.Password, ")") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, ":") || strings.Contains(user.Password, ".") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
+		return errors.New("the password must be 6 to 20 characters long")
 	}
-	if strings.Contains(user.Password, "/") || strings.Contains(user.Password, `\`) {
+	if i := strings.IndexAny(user.Password, passwordForbidden); i >= 0 {
 		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, ",") || strings.Contains(user.Password, ";") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "?") || strings.Contains(user.Password, `"`) {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
-	}
-	if strings.Contains(user.Password, "!") || strings.Contains(user.Password, "~") {
-		s.logger.Error("forbidden")
-		return fmt.Errorf("forbidden")
+		return fmt.Errorf("the password can't contain %q", user.Password[i])
 	}
 
 	return nil
@@ -90,7 +64,7 @@ func (s *AuthService) IsEmailUsed(email string) bool {
 	return false
 }
 
-func (s *AuthService) CreateUser(user *models.User) (int, error) {
+func (s *AuthService) CreateUser(ctx context.Context, user *models.User) (int, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		s.logger.Error("failed to generate hash from password due to:", err.Error())
@@ -104,6 +78,8 @@ func (s *AuthService) CreateUser(user *models.User) (int, error) {
 		s.logger.Error("failed to create user due to:", err.Error())
 		return 0, err
 	}
+
+	s.audit.created(ctx, models.EntityUser, id)
 	return id, nil
 }
 
@@ -123,39 +99,102 @@ func (s *AuthService) CheckUser(user models.User) (models.User, error) {
 }
 
 func (s *AuthService) GenerateToken(user models.User) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
-		jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(15 * time.Minute).Unix(),
-			IssuedAt:  time.Now().Unix(),
+	now := time.Now()
+	signedString, err := s.keys.sign(&tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
-		user.ID,
-		user.Role,
+		UserID:   user.ID,
+		UserRole: user.Role,
 	})
 
-	signedString, err := token.SignedString([]byte(os.Getenv("SECRET")))
 	return signedString, err
 }
 
-func (s *AuthService) ParseToken(tokenString string) (int, string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &tokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
-		}
-		return []byte(os.Getenv("SECRET")), nil
-	})
+func (s *AuthService) ParseToken(tokenString string) (models.Identity, error) {
+	var claims tokenClaims
+	if err := s.keys.parse(tokenString, &claims); err != nil {
+		return models.Identity{}, err
+	}
+
+	// Other signed tokens (e.g. project invites) carry an audience and no user.
+	if claims.UserID == 0 || len(claims.Audience) != 0 {
+		return models.Identity{}, errors.New("invalid token claims")
+	}
+
+	identity := models.Identity{
+		UserID:         claims.UserID,
+		Role:           claims.UserRole,
+		ImpersonatorID: claims.ImpersonatorID,
+	}
+	if claims.ExpiresAt != nil {
+		identity.ExpiresAt = claims.ExpiresAt.Time
+	}
 
+	return identity, nil
+}
+
+func (s *AuthService) JWKS() models.JWKS {
+	return s.keys.JWKS()
+}
+
+// Impersonate issues a short-lived token that acts as userId while keeping
+// the admin's id in the claims, so every request made with it can be traced
+// back to the admin.
+func (s *AuthService) Impersonate(adminId, userId int, ip string) (string, error) {
+	if adminId == userId {
+		return "", errors.New("you can't impersonate yourself")
+	}
+
+	user, err := s.repo.GetUserById(userId)
 	if err != nil {
-		return 0, "", err
+		s.logger.Error("failed to get the user to impersonate due to:", err.Error())
+		return "", errors.New("user not found")
 	}
 
-	claims, ok := token.Claims.(*tokenClaims)
-	if !ok {
-		return 0, "", errors.New("invalid token claims")
+	if strings.ToLower(user.Role) == "superuser" {
+		s.logger.Error("attempt to impersonate a superuser")
+		return "", errors.New("superusers can't be impersonated")
 	}
 
-	if claims.ExpiresAt < time.Now().Unix() {
-		return 0, "", errors.New("token expired")
+	now := time.Now()
+	token, err := s.keys.sign(&tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(impersonationTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		UserID:         user.ID,
+		UserRole:       user.Role,
+		ImpersonatorID: adminId,
+	})
+	if err != nil {
+		s.logger.Error("failed to sign the impersonation token due to:", err.Error())
+		return "", err
 	}
 
-	return claims.UserID, claims.UserRole, nil
+	s.RecordImpersonation(models.ImpersonationLog{
+		ImpersonatorId: adminId,
+		UserId:         user.ID,
+		Action:         "start",
+		IP:             ip,
+	})
+
+	return token, nil
+}
+
+func (s *AuthService) RecordImpersonation(entry models.ImpersonationLog) {
+	s.logger.WithFields(logrus.Fields{
+		"impersonator_id": entry.ImpersonatorId,
+		"user_id":         entry.UserId,
+		"action":          entry.Action,
+		"method":          entry.Method,
+		"path":            entry.Path,
+		"status":          entry.StatusCode,
+		"ip":              entry.IP,
+	}).Info("impersonation")
+
+	if err := s.repo.CreateImpersonationLog(entry); err != nil {
+		s.logger.Error("failed to save the impersonation log due to:", err.Error())
+	}
 }
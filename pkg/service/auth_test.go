@@ -0,0 +1,144 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sharifsharifzoda/project-management-system/logging"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+	"time"
+)
+
+func newEd25519KeySet(t *testing.T, kid string) *KeySet {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ks, err := NewKeySet(kid, private)
+	require.NoError(t, err)
+
+	return ks
+}
+
+func TestAuthService_TokenRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaKeys, err := NewKeySet("rsa-1", rsaKey)
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name string
+		keys *KeySet
+	}{
+		{name: "EdDSA", keys: newEd25519KeySet(t, "ed-1")},
+		{name: "RS256", keys: rsaKeys},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := NewAuthService(nil, testCase.keys, nil, nil)
+
+			token, err := s.GenerateToken(models.User{ID: 7, Role: "superuser"})
+			require.NoError(t, err)
+
+			identity, err := s.ParseToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, 7, identity.UserID)
+			assert.Equal(t, "superuser", identity.Role)
+			assert.Zero(t, identity.ImpersonatorID)
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), identity.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestAuthService_KeyRotation(t *testing.T) {
+	oldKeys := newEd25519KeySet(t, "old")
+	oldToken, err := NewAuthService(nil, oldKeys, nil, nil).GenerateToken(models.User{ID: 1, Role: "user"})
+	require.NoError(t, err)
+
+	newKeys := newEd25519KeySet(t, "new")
+	s := NewAuthService(nil, newKeys, nil, nil)
+
+	_, err = s.ParseToken(oldToken)
+	assert.Error(t, err, "token signed with an unknown key must be rejected")
+
+	require.NoError(t, newKeys.AddVerificationKey("old", oldKeys.signingKey.Public()))
+
+	identity, err := s.ParseToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, identity.UserID)
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	ks := newEd25519KeySet(t, "ed-1")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	require.NoError(t, ks.AddVerificationKey("rsa-old", &rsaKey.PublicKey))
+
+	jwks := ks.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	assert.Equal(t, "ed-1", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.NotEmpty(t, jwks.Keys[0].X)
+
+	assert.Equal(t, "rsa-old", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestAuthService_ParseToken_RejectsInviteToken(t *testing.T) {
+	keys := newEd25519KeySet(t, "ed-1")
+	s := NewAuthService(nil, keys, nil, nil)
+
+	token, err := keys.sign(&inviteClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "someone@example.com",
+			Audience:  jwt.ClaimStrings{inviteAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		InviteID: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = s.ParseToken(token)
+	assert.Error(t, err)
+}
+
+func TestAuthService_ValidateUser(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	s := NewAuthService(nil, nil, &logging.Logger{Entry: logrus.NewEntry(logger)}, nil)
+
+	testTable := []struct {
+		name string
+		user models.User
+		err  string
+	}{
+		{name: "valid", user: models.User{Email: "ali@example.com", Password: "secret1"}},
+		{name: "short email", user: models.User{Email: "a@b", Password: "secret1"},
+			err: "the email must be 5 to 30 characters long"},
+		{name: "long password", user: models.User{Email: "ali@example.com", Password: "secret1secret1secret1"},
+			err: "the password must be 6 to 20 characters long"},
+		{name: "special character", user: models.User{Email: "ali@example.com", Password: "sec.ret1"},
+			err: "the password can't contain '.'"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := s.ValidateUser(testCase.user)
+			if testCase.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, testCase.err)
+		})
+	}
+}
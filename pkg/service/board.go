@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"gorm.io/gorm"
+	"log"
+	"strings"
+)
+
+type BoardService struct {
+	repo    repository.Board
+	project repository.Project
+	feed    *ActivityFeed
+	audit   *AuditLog
+}
+
+func NewBoardService(repo repository.Board, project repository.Project, feed *ActivityFeed,
+	audit *AuditLog) *BoardService {
+	return &BoardService{repo: repo, project: project, feed: feed, audit: audit}
+}
+
+// columns returns the columns of the project's board, creating the default
+// ones the first time the board is used.
+func (b *BoardService) columns(projectId int) ([]models.BoardColumn, error) {
+	columns, err := b.repo.GetColumns(projectId)
+	if err != nil || len(columns) > 0 {
+		return columns, err
+	}
+
+	if err := b.repo.CreateDefaultColumns(projectId); err != nil {
+		return nil, err
+	}
+
+	return b.repo.GetColumns(projectId)
+}
+
+// checkProject checks that the project exists and that the user manages it,
+// for the boards, sprints and milestones of the project.
+func checkProject(project repository.Project, orgId, managerId, projectId int) error {
+	if _, err := project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	return nil
+}
+
+func (b *BoardService) GetBoard(orgId, managerId, projectId int) (models.Board, error) {
+	if err := checkProject(b.project, orgId, managerId, projectId); err != nil {
+		return models.Board{}, err
+	}
+
+	columns, err := b.columns(projectId)
+	if err != nil {
+		log.Println("failed to get the columns of the board. Error is: ", err.Error())
+		return models.Board{}, err
+	}
+
+	tasks, err := b.repo.GetBoardTasks(projectId)
+	if err != nil {
+		log.Println("failed to get the tasks of the board. Error is: ", err.Error())
+		return models.Board{}, err
+	}
+
+	index := make(map[string]int, len(columns))
+	for i := range columns {
+		index[columns[i].Status] = i
+		columns[i].Tasks = models.Tasks{}
+	}
+
+	for _, task := range tasks {
+		if i, ok := index[task.Status]; ok {
+			columns[i].Tasks = append(columns[i].Tasks, task)
+		}
+	}
+
+	return models.Board{ProjectId: projectId, Columns: columns}, nil
+}
+
+// CreateColumn adds the column at the given position, or after the last one
+// when no position is given.
+func (b *BoardService) CreateColumn(orgId, managerId int, column models.BoardColumn) (int, error) {
+	if err := checkProject(b.project, orgId, managerId, column.ProjectId); err != nil {
+		return -1, err
+	}
+
+	column.Status = strings.TrimSpace(column.Status)
+	if column.Status == "" {
+		return -1, errors.New("status is required")
+	}
+
+	if column.WipLimit < 0 {
+		return -1, errors.New("WIP limit can't be negative")
+	}
+
+	columns, err := b.columns(column.ProjectId)
+	if err != nil {
+		log.Println("failed to get the columns of the board. Error is: ", err.Error())
+		return -1, err
+	}
+
+	for _, c := range columns {
+		if c.Status == column.Status {
+			return -1, errors.New("column with this status already exists")
+		}
+	}
+
+	if column.Position == 0 {
+		column.Position = len(columns)
+	}
+
+	id, err := b.repo.CreateColumn(column)
+	if err != nil {
+		log.Println("failed to create a new column. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (b *BoardService) UpdateColumn(orgId, managerId int, column models.BoardColumn) error {
+	if err := checkProject(b.project, orgId, managerId, column.ProjectId); err != nil {
+		return err
+	}
+
+	current, err := b.repo.GetColumn(column.ProjectId, column.ID)
+	if err != nil {
+		return errors.New("column doesn't exist")
+	}
+
+	column.Status = strings.TrimSpace(column.Status)
+	if column.Status == "" {
+		column.Status = current.Status
+	}
+
+	if column.WipLimit < 0 {
+		return errors.New("WIP limit can't be negative")
+	}
+
+	if err := b.repo.UpdateColumn(column, current.Status); err != nil {
+		log.Println("failed to update the column. Error is: ", err.Error())
+		return errors.New("failed to update the column")
+	}
+
+	return nil
+}
+
+func (b *BoardService) DeleteColumn(orgId, managerId, projectId, id int) error {
+	if err := checkProject(b.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	column, err := b.repo.GetColumn(projectId, id)
+	if err != nil {
+		return errors.New("column doesn't exist")
+	}
+
+	count, err := b.repo.CountColumnTasks(projectId, column.Status)
+	if err != nil {
+		log.Println("failed to count the tasks of the column. Error is: ", err.Error())
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("column still has tasks")
+	}
+
+	if err := b.repo.DeleteColumn(projectId, id); err != nil {
+		log.Println("failed to delete the column. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (b *BoardService) MoveTask(ctx context.Context, orgId, managerId int, move models.TaskMove) error {
+	if err := checkProject(b.project, orgId, managerId, move.ProjectId); err != nil {
+		return err
+	}
+
+	if _, err := b.columns(move.ProjectId); err != nil {
+		log.Println("failed to get the columns of the board. Error is: ", err.Error())
+		return err
+	}
+
+	audit := b.audit.track(models.EntityTask, move.TaskId)
+	status, err := b.repo.MoveTask(move)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("column or task doesn't exist")
+	}
+	if err != nil {
+		if !errors.Is(err, repository.ErrWipLimit) && !errors.Is(err, repository.ErrBadPosition) {
+			log.Println("failed to move the task. Error is: ", err.Error())
+		}
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	if status != move.Status {
+		b.feed.task(managerId, models.ActivityTaskStatusChanged, move.TaskId,
+			models.JSONMap{"from": status, "to": move.Status})
+	} else {
+		b.feed.updated(managerId, []int{move.TaskId}, "rank")
+	}
+
+	return nil
+}
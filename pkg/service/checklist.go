@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"strings"
+)
+
+type ChecklistService struct {
+	repo  repository.Checklist
+	task  repository.Task
+	feed  *ActivityFeed
+	audit *AuditLog
+}
+
+func NewChecklistService(repo repository.Checklist, task repository.Task, feed *ActivityFeed,
+	audit *AuditLog) *ChecklistService {
+	return &ChecklistService{repo: repo, task: task, feed: feed, audit: audit}
+}
+
+func (c *ChecklistService) checkTask(orgId, userId, taskId int) error {
+	if _, err := c.task.GetTaskById(orgId, userId, taskId); err != nil {
+		return errors.New("task doesn't exist")
+	}
+
+	return nil
+}
+
+func (c *ChecklistService) CreateItem(ctx context.Context, orgId, userId int, item models.ChecklistItem) (int, error) {
+	if err := c.checkTask(orgId, userId, item.TaskId); err != nil {
+		return -1, err
+	}
+
+	item.Title = strings.TrimSpace(item.Title)
+	if item.Title == "" {
+		return -1, errors.New("title is required")
+	}
+
+	audit := c.audit.track(models.EntityTask, item.TaskId)
+	id, err := c.repo.CreateItem(item)
+	if err != nil {
+		log.Println("failed to create a new checklist item. Error is: ", err.Error())
+		return -1, err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	c.feed.updated(userId, []int{item.TaskId}, "checklist")
+
+	return id, nil
+}
+
+func (c *ChecklistService) GetItems(orgId, userId, taskId int) (models.ChecklistItems, error) {
+	if err := c.checkTask(orgId, userId, taskId); err != nil {
+		return nil, err
+	}
+
+	items, err := c.repo.GetItems(taskId)
+	if err != nil {
+		log.Println("failed to get the checklist. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UpdateItem renames, checks or unchecks the item. Nil values are left as
+// they are.
+func (c *ChecklistService) UpdateItem(ctx context.Context, orgId, userId, taskId, id int, title *string,
+	isChecked *bool) error {
+	if err := c.checkTask(orgId, userId, taskId); err != nil {
+		return err
+	}
+
+	item, err := c.repo.GetItem(taskId, id)
+	if err != nil {
+		return errors.New("checklist item doesn't exist")
+	}
+
+	if title != nil {
+		item.Title = strings.TrimSpace(*title)
+		if item.Title == "" {
+			return errors.New("title is required")
+		}
+	}
+
+	if isChecked != nil {
+		item.IsChecked = *isChecked
+	}
+
+	audit := c.audit.track(models.EntityTask, taskId)
+	if err := c.repo.UpdateItem(item); err != nil {
+		log.Println("failed to update the checklist item. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	c.feed.updated(userId, []int{taskId}, "checklist")
+
+	return nil
+}
+
+func (c *ChecklistService) DeleteItem(ctx context.Context, orgId, userId, taskId, id int) error {
+	if err := c.checkTask(orgId, userId, taskId); err != nil {
+		return err
+	}
+
+	audit := c.audit.track(models.EntityTask, taskId)
+	if err := c.repo.DeleteItem(taskId, id); err != nil {
+		log.Println("failed to delete the checklist item. Error is: ", err.Error())
+		return errors.New("checklist item doesn't exist")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	c.feed.updated(userId, []int{taskId}, "checklist")
+
+	return nil
+}
+
+func (c *ChecklistService) MoveItem(ctx context.Context, orgId, userId int, move models.ChecklistMove) error {
+	if err := c.checkTask(orgId, userId, move.TaskId); err != nil {
+		return err
+	}
+
+	if move.PrevId == move.ItemId || move.NextId == move.ItemId {
+		return errors.New("item can't be placed next to itself")
+	}
+
+	audit := c.audit.track(models.EntityTask, move.TaskId)
+	err := c.repo.MoveItem(move)
+	if errors.Is(err, repository.ErrBadPosition) {
+		return err
+	}
+	if err != nil {
+		log.Println("failed to move the checklist item. Error is: ", err.Error())
+		return errors.New("checklist item doesn't exist")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	c.feed.updated(userId, []int{move.TaskId}, "checklist")
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestChecklistService_UpdateItem(t *testing.T) {
+	title, blank := "Write the docs", "  "
+	checked, unchecked := true, false
+
+	testTable := []struct {
+		name      string
+		item      models.ChecklistItem
+		title     *string
+		isChecked *bool
+		expected  models.ChecklistItem
+		err       string
+	}{
+		{
+			name:      "checks the item",
+			item:      models.ChecklistItem{ID: 2, TaskId: 1, Title: "Test"},
+			isChecked: &checked,
+			expected:  models.ChecklistItem{ID: 2, TaskId: 1, Title: "Test", IsChecked: true},
+		},
+		{
+			name:      "unchecks the item",
+			item:      models.ChecklistItem{ID: 2, TaskId: 1, Title: "Test", IsChecked: true},
+			isChecked: &unchecked,
+			expected:  models.ChecklistItem{ID: 2, TaskId: 1, Title: "Test"},
+		},
+		{
+			name:     "renaming leaves the check alone",
+			item:     models.ChecklistItem{ID: 2, TaskId: 1, Title: "Test", IsChecked: true},
+			title:    &title,
+			expected: models.ChecklistItem{ID: 2, TaskId: 1, Title: title, IsChecked: true},
+		},
+		{
+			name:  "blank title",
+			item:  models.ChecklistItem{ID: 2, TaskId: 1, Title: "Test"},
+			title: &blank,
+			err:   "title is required",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			task := mock_repository.NewMockTask(c)
+			task.EXPECT().GetTaskById(3, 4, 1).Return(models.Task{ID: 1}, nil)
+
+			audit, store := testAudit(c)
+			checklist := func(item models.ChecklistItem) models.JSONMap {
+				return models.JSONMap{"checklist": []any{map[string]any{"id": float64(item.ID), "title": item.Title,
+					"is_checked": item.IsChecked}}}
+			}
+			store.set(models.EntityTask, 1, checklist(test.item))
+
+			repo := mock_repository.NewMockChecklist(c)
+			repo.EXPECT().GetItem(1, 2).Return(test.item, nil)
+			if test.err == "" {
+				repo.EXPECT().UpdateItem(test.expected).DoAndReturn(func(item models.ChecklistItem) error {
+					store.set(models.EntityTask, 1, checklist(item))
+					return nil
+				})
+			}
+
+			feed, events := testFeed(c)
+			subscription := events.Subscribe(0)
+			defer subscription.Close()
+
+			userId := 4
+			ctx := WithAuditSource(context.Background(), models.AuditSource{ActorId: &userId})
+			err := NewChecklistService(repo, task, feed, audit).UpdateItem(ctx, 3, 4, 1, 2, test.title, test.isChecked)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Empty(t, updatedTasks(subscription))
+				assert.Empty(t, store.entries)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, map[int]any{1: []string{"checklist"}}, updatedTasks(subscription))
+				assert.Equal(t, []models.AuditEntry{{ActorId: &userId, Action: models.AuditUpdate,
+					EntityType: models.EntityTask, EntityId: 1, Changes: models.JSONMap{"checklist": map[string]any{
+						"from": checklist(test.item)["checklist"], "to": checklist(test.expected)["checklist"]}}}},
+					store.entries)
+			}
+		})
+	}
+}
+
+func TestChecklistService_MoveItem(t *testing.T) {
+	testTable := []struct {
+		name    string
+		move    models.ChecklistMove
+		repoErr error
+		moved   bool
+		err     string
+	}{
+		{
+			name:  "between two items",
+			move:  models.ChecklistMove{TaskId: 1, ItemId: 2, PrevId: 5, NextId: 6},
+			moved: true,
+		},
+		{
+			name: "after itself",
+			move: models.ChecklistMove{TaskId: 1, ItemId: 2, PrevId: 2},
+			err:  "item can't be placed next to itself",
+		},
+		{
+			name: "before itself",
+			move: models.ChecklistMove{TaskId: 1, ItemId: 2, NextId: 2},
+			err:  "item can't be placed next to itself",
+		},
+		{
+			name:    "between items that aren't neighbours",
+			move:    models.ChecklistMove{TaskId: 1, ItemId: 2, PrevId: 5, NextId: 7},
+			repoErr: repository.ErrBadPosition,
+			moved:   true,
+			err:     repository.ErrBadPosition.Error(),
+		},
+		{
+			name:    "unknown item",
+			move:    models.ChecklistMove{TaskId: 1, ItemId: 9},
+			repoErr: gorm.ErrRecordNotFound,
+			moved:   true,
+			err:     "checklist item doesn't exist",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			task := mock_repository.NewMockTask(c)
+			task.EXPECT().GetTaskById(3, 4, 1).Return(models.Task{ID: 1}, nil)
+
+			repo := mock_repository.NewMockChecklist(c)
+			if test.moved {
+				repo.EXPECT().MoveItem(test.move).Return(test.repoErr)
+			}
+
+			feed, _ := testFeed(c)
+			audit, _ := testAudit(c)
+			err := NewChecklistService(repo, task, feed, audit).MoveItem(context.Background(), 3, 4, test.move)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChecklistService_UnknownTask(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	task := mock_repository.NewMockTask(c)
+	task.EXPECT().GetTaskById(3, 4, 1).Return(models.Task{}, errors.New("record not found"))
+
+	feed, _ := testFeed(c)
+	audit, _ := testAudit(c)
+	err := NewChecklistService(mock_repository.NewMockChecklist(c), task, feed, audit).
+		MoveItem(context.Background(), 3, 4, models.ChecklistMove{TaskId: 1, ItemId: 2})
+	assert.EqualError(t, err, "task doesn't exist")
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"strings"
+)
+
+type CommentService struct {
+	repo repository.Comment
+	task repository.Task
+	feed *ActivityFeed
+}
+
+func NewCommentService(repo repository.Comment, task repository.Task, feed *ActivityFeed) *CommentService {
+	return &CommentService{repo: repo, task: task, feed: feed}
+}
+
+// AddComment comments on the task. The users it mentions must take part in
+// the task's project; they are notified of the mention, and the task's other
+// followers of the comment.
+func (c *CommentService) AddComment(orgId, userId int, comment models.TaskComment) (int, error) {
+	task, err := c.task.GetTaskById(orgId, userId, comment.TaskId)
+	if err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return -1, errors.New("task doesn't exist")
+	}
+
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" {
+		return -1, errors.New("comment is empty")
+	}
+
+	mentions := uniqueIds(comment.Mentions)
+	for _, id := range mentions {
+		if !c.task.IsProjectMember(task.ProjectId, id) {
+			return -1, errors.New("mentioned user doesn't take part in the project")
+		}
+	}
+
+	comment.AuthorId = userId
+	id, err := c.repo.CreateComment(comment)
+	if err != nil {
+		log.Println("failed to add the comment. Error is: ", err.Error())
+		return -1, err
+	}
+
+	data := models.JSONMap{"comment_id": id}
+	c.feed.add(models.Activity{ActorId: userId, Verb: models.ActivityTaskCommented, TaskId: &comment.TaskId,
+		Data: data})
+	c.feed.notify(models.Notification{Type: models.NotificationMentioned, ActorId: &userId,
+		TaskId: &comment.TaskId, Data: data}, without(mentions, userId))
+	c.feed.notify(models.Notification{Type: models.NotificationCommented, ActorId: &userId,
+		TaskId: &comment.TaskId, Data: data}, c.feed.followers(comment.TaskId, append(mentions, userId)...))
+
+	return id, nil
+}
+
+func (c *CommentService) GetComments(orgId, userId, taskId int) (models.TaskComments, error) {
+	if _, err := c.task.GetTaskById(orgId, userId, taskId); err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return nil, errors.New("task doesn't exist")
+	}
+
+	comments, err := c.repo.GetComments(taskId)
+	if err != nil {
+		log.Println("failed to get the comments of the task. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const customFieldDateLayout = "2006-01-02"
+
+var customFieldTypes = map[string]bool{
+	models.FieldText:         true,
+	models.FieldNumber:       true,
+	models.FieldDate:         true,
+	models.FieldSingleSelect: true,
+	models.FieldMultiSelect:  true,
+	models.FieldUser:         true,
+}
+
+type CustomFieldService struct {
+	repo    repository.CustomField
+	project repository.Project
+}
+
+func NewCustomFieldService(repo repository.CustomField, project repository.Project) *CustomFieldService {
+	return &CustomFieldService{repo: repo, project: project}
+}
+
+// prepare normalizes the name and options of the field. Select fields need
+// at least one option, the other types take none.
+func (c *CustomFieldService) prepare(field *models.CustomField) error {
+	field.Name = strings.TrimSpace(field.Name)
+	if utils.NormalizeName(field.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	isSelect := field.Type == models.FieldSingleSelect || field.Type == models.FieldMultiSelect
+	options := make(models.StringList, 0, len(field.Options))
+	seen := make(map[string]bool, len(field.Options))
+	for _, option := range field.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			continue
+		}
+		seen[option] = true
+		options = append(options, option)
+	}
+
+	if isSelect && len(options) == 0 {
+		return errors.New("select fields need at least one option")
+	}
+
+	if !isSelect && len(options) > 0 {
+		return errors.New("only select fields have options")
+	}
+
+	field.Options = options
+
+	return nil
+}
+
+func (c *CustomFieldService) CreateCustomField(orgId, managerId int, field models.CustomField) (int, error) {
+	if _, err := c.project.GetProjectById(orgId, managerId, field.ProjectId); err != nil {
+		log.Println("failed to get the project while creating a custom field. Error is: ", err.Error())
+		return -1, errors.New("project doesn't exist")
+	}
+
+	if !customFieldTypes[field.Type] {
+		return -1, errors.New("invalid type of custom field")
+	}
+
+	if err := c.prepare(&field); err != nil {
+		return -1, err
+	}
+
+	field.Key = utils.NormalizeName(field.Name)
+	if _, err := c.repo.GetCustomFieldByKey(field.ProjectId, field.Key); err == nil {
+		return -1, errors.New("custom field with this name already exists")
+	}
+
+	id, err := c.repo.CreateCustomField(field)
+	if err != nil {
+		log.Println("failed to create a new custom field. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (c *CustomFieldService) GetCustomFields(orgId, managerId, projectId int) (models.CustomFields, error) {
+	if _, err := c.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while listing custom fields. Error is: ", err.Error())
+		return nil, errors.New("project doesn't exist")
+	}
+
+	fields, err := c.repo.GetCustomFields(projectId)
+	if err != nil {
+		log.Println("failed to get the list of custom fields. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// UpdateCustomField keeps the key and the type of the field, the request can
+// only rename it, change its options or whether it is required.
+func (c *CustomFieldService) UpdateCustomField(orgId, managerId int, field models.CustomField) error {
+	if _, err := c.project.GetProjectById(orgId, managerId, field.ProjectId); err != nil {
+		log.Println("failed to get the project while updating a custom field. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	current, err := c.repo.GetCustomField(field.ProjectId, field.ID)
+	if err != nil {
+		return errors.New("custom field doesn't exist")
+	}
+	field.Type = current.Type
+
+	if err := c.prepare(&field); err != nil {
+		return err
+	}
+
+	if err := c.repo.UpdateCustomField(field); err != nil {
+		log.Println("failed to update the custom field. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (c *CustomFieldService) DeleteCustomField(orgId, managerId, projectId, id int) error {
+	if _, err := c.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while deleting a custom field. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	if err := c.repo.DeleteCustomField(projectId, id); err != nil {
+		log.Println("failed to delete the custom field. Error is: ", err.Error())
+		return errors.New("custom field doesn't exist")
+	}
+
+	return nil
+}
+
+// validateCustomFields checks the values against the field definitions of the
+// project and returns them in the form they are stored in. A nil value clears
+// the field.
+func validateCustomFields(fields models.CustomFields, values models.JSONMap,
+	isMember func(userId int) bool) (models.JSONMap, error) {
+	byKey := make(map[string]models.CustomField, len(fields))
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+
+	result := models.JSONMap{}
+	for key, value := range values {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %s", key)
+		}
+
+		if value == nil {
+			continue
+		}
+
+		v, err := customFieldValue(field, value, isMember)
+		if err != nil {
+			return nil, err
+		}
+
+		if v != nil {
+			result[key] = v
+		}
+	}
+
+	for _, f := range fields {
+		if _, ok := result[f.Key]; f.Required && !ok {
+			return nil, fmt.Errorf("custom field %s is required", f.Key)
+		}
+	}
+
+	return result, nil
+}
+
+func customFieldValue(field models.CustomField, value any, isMember func(userId int) bool) (any, error) {
+	invalid := fmt.Errorf("invalid value of custom field %s", field.Key)
+
+	switch field.Type {
+	case models.FieldText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		if s = strings.TrimSpace(s); s == "" {
+			return nil, nil
+		}
+		return s, nil
+	case models.FieldNumber:
+		n, ok := toNumber(value)
+		if !ok {
+			return nil, invalid
+		}
+		return n, nil
+	case models.FieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		if _, err := time.Parse(customFieldDateLayout, s); err != nil {
+			return nil, fmt.Errorf("custom field %s must be a date in YYYY-MM-DD format", field.Key)
+		}
+		return s, nil
+	case models.FieldSingleSelect:
+		s, ok := value.(string)
+		if !ok || !hasOption(field, s) {
+			return nil, invalid
+		}
+		return s, nil
+	case models.FieldMultiSelect:
+		list, ok := value.([]any)
+		if !ok {
+			return nil, invalid
+		}
+		selected := make([]any, 0, len(list))
+		seen := make(map[string]bool, len(list))
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok || !hasOption(field, s) {
+				return nil, invalid
+			}
+			if !seen[s] {
+				seen[s] = true
+				selected = append(selected, s)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil
+		}
+		return selected, nil
+	case models.FieldUser:
+		n, ok := toNumber(value)
+		if !ok || n != math.Trunc(n) || !isMember(int(n)) {
+			return nil, fmt.Errorf("custom field %s must be a member of the organization", field.Key)
+		}
+		return int(n), nil
+	}
+
+	return nil, invalid
+}
+
+func toNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+func hasOption(field models.CustomField, option string) bool {
+	for _, o := range field.Options {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// customFieldFilter turns the raw filter and sort values of the request into
+// typed ones, using the field definitions of the filtered project.
+func customFieldFilter(fields models.CustomFields, filter *models.TaskFilter) error {
+	byKey := make(map[string]models.CustomField, len(fields))
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+
+	contain := models.JSONMap{}
+	for key, raw := range filter.Fields {
+		field, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("unknown custom field %s", key)
+		}
+
+		var value any = raw
+		switch field.Type {
+		case models.FieldNumber, models.FieldUser:
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value of custom field %s", key)
+			}
+			value = n
+		case models.FieldDate:
+			if _, err := time.Parse(customFieldDateLayout, raw); err != nil {
+				return fmt.Errorf("custom field %s must be a date in YYYY-MM-DD format", key)
+			}
+		case models.FieldMultiSelect:
+			value = []any{raw}
+		}
+		contain[key] = value
+	}
+	filter.FieldsContain = contain
+
+	if filter.SortField != "" {
+		field, ok := byKey[filter.SortField]
+		if !ok {
+			return fmt.Errorf("unknown custom field %s", filter.SortField)
+		}
+
+		switch field.Type {
+		case models.FieldNumber, models.FieldUser:
+			filter.SortCast = "numeric"
+		case models.FieldDate:
+			filter.SortCast = "date"
+		default:
+			filter.SortCast = "text"
+		}
+	}
+
+	return nil
+}
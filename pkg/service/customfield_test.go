@@ -0,0 +1,116 @@
+package service
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var testCustomFields = models.CustomFields{
+	{Key: "storypoints", Type: models.FieldNumber, Required: true},
+	{Key: "customer", Type: models.FieldText},
+	{Key: "releasedate", Type: models.FieldDate},
+	{Key: "environment", Type: models.FieldSingleSelect, Options: models.StringList{"staging", "production"}},
+	{Key: "platforms", Type: models.FieldMultiSelect, Options: models.StringList{"web", "ios", "android"}},
+	{Key: "reviewer", Type: models.FieldUser},
+}
+
+func isTestMember(userId int) bool {
+	return userId == 5
+}
+
+func TestValidateCustomFields(t *testing.T) {
+	testTable := []struct {
+		name          string
+		values        models.JSONMap
+		expected      models.JSONMap
+		expectedError string
+	}{
+		{
+			name: "OK",
+			values: models.JSONMap{
+				"storypoints": float64(3),
+				"customer":    "  ACME ",
+				"releasedate": "2024-05-01",
+				"environment": "staging",
+				"platforms":   []any{"web", "ios", "web"},
+				"reviewer":    float64(5),
+			},
+			expected: models.JSONMap{
+				"storypoints": float64(3),
+				"customer":    "ACME",
+				"releasedate": "2024-05-01",
+				"environment": "staging",
+				"platforms":   []any{"web", "ios"},
+				"reviewer":    5,
+			},
+		},
+		{
+			name:     "Null clears optional field",
+			values:   models.JSONMap{"storypoints": float64(1), "customer": nil},
+			expected: models.JSONMap{"storypoints": float64(1)},
+		},
+		{
+			name:          "Required missing",
+			values:        models.JSONMap{"customer": "ACME"},
+			expectedError: "custom field storypoints is required",
+		},
+		{
+			name:          "Unknown field",
+			values:        models.JSONMap{"storypoints": float64(1), "color": "red"},
+			expectedError: "unknown custom field color",
+		},
+		{
+			name:          "Number as string",
+			values:        models.JSONMap{"storypoints": "3"},
+			expectedError: "invalid value of custom field storypoints",
+		},
+		{
+			name:          "Bad date",
+			values:        models.JSONMap{"storypoints": float64(1), "releasedate": "01.05.2024"},
+			expectedError: "custom field releasedate must be a date in YYYY-MM-DD format",
+		},
+		{
+			name:          "Unknown option",
+			values:        models.JSONMap{"storypoints": float64(1), "platforms": []any{"linux"}},
+			expectedError: "invalid value of custom field platforms",
+		},
+		{
+			name:          "User outside organization",
+			values:        models.JSONMap{"storypoints": float64(1), "reviewer": float64(6)},
+			expectedError: "custom field reviewer must be a member of the organization",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			values, err := validateCustomFields(testCustomFields, testCase.values, isTestMember)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, values)
+		})
+	}
+}
+
+func TestCustomFieldFilter(t *testing.T) {
+	filter := models.TaskFilter{
+		Fields:    map[string]string{"storypoints": "5", "platforms": "web", "environment": "staging"},
+		SortField: "releasedate",
+	}
+
+	require.NoError(t, customFieldFilter(testCustomFields, &filter))
+	assert.Equal(t, models.JSONMap{
+		"storypoints": float64(5),
+		"platforms":   []any{"web"},
+		"environment": "staging",
+	}, filter.FieldsContain)
+	assert.Equal(t, "date", filter.SortCast)
+
+	filter = models.TaskFilter{SortField: "missing"}
+	assert.EqualError(t, customFieldFilter(testCustomFields, &filter), "unknown custom field missing")
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"github.com/sharifsharifzoda/project-management-system/configs"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// deadlineRule is one reminder or escalation and the deadlines it is due for
+// at the time it is checked: those after from, up to to.
+type deadlineRule struct {
+	hours    int
+	from, to time.Time
+}
+
+// rulesHours drops the hours below min and the repeated ones, and sorts the
+// rest, the latest reminder or escalation last. Reminders get later as
+// their hours go down, escalations as they go up.
+func rulesHours(hours []int, min int, reminders bool) []int {
+	seen := make(map[int]bool, len(hours))
+	var kept []int
+	for _, h := range hours {
+		if h >= min && !seen[h] {
+			seen[h] = true
+			kept = append(kept, h)
+		}
+	}
+
+	if reminders {
+		sort.Sort(sort.Reverse(sort.IntSlice(kept)))
+	} else {
+		sort.Ints(kept)
+	}
+
+	return kept
+}
+
+// reminderRules returns when every reminder is due: for the deadlines at
+// most its hours away, but further than those of the next reminder. A
+// deadline that several reminders were missed for, like while the scheduler
+// was down, only gets the latest.
+func reminderRules(now time.Time, hours []int) []deadlineRule {
+	rules := make([]deadlineRule, len(hours))
+	for i, h := range hours {
+		rules[i] = deadlineRule{hours: h, from: now, to: now.Add(time.Duration(h) * time.Hour)}
+		if i+1 < len(hours) {
+			rules[i].from = now.Add(time.Duration(hours[i+1]) * time.Hour)
+		}
+	}
+
+	return rules
+}
+
+// escalationRules returns when every escalation is due: for the deadlines
+// passed at least its hours ago, but less than those of the next one. The
+// latest escalation is due for every deadline passed before.
+func escalationRules(now time.Time, hours []int) []deadlineRule {
+	rules := make([]deadlineRule, len(hours))
+	for i, h := range hours {
+		rules[i] = deadlineRule{hours: h, to: now.Add(-time.Duration(h) * time.Hour)}
+		if i+1 < len(hours) {
+			rules[i].from = now.Add(-time.Duration(hours[i+1]) * time.Hour)
+		}
+	}
+
+	return rules
+}
+
+// DeadlineService reminds of the deadlines of tasks and projects, marks
+// them overdue and escalates them once they are. Reminders and escalations
+// are notifications keyed by the deadline and the rule, so each is sent
+// once however often, or on however many instances, the scheduler runs.
+type DeadlineService struct {
+	repo        repository.Deadline
+	reminders   []int
+	escalations []int
+	audit       *AuditLog
+}
+
+func NewDeadlineService(repo repository.Deadline, cfg configs.DeadlineConfig, audit *AuditLog) *DeadlineService {
+	reminders, escalations := rulesHours(cfg.Reminders, 1, true), rulesHours(cfg.Escalations, 0, false)
+	if len(reminders) == 0 {
+		reminders = []int{24}
+	}
+	if len(escalations) == 0 {
+		escalations = []int{0}
+	}
+
+	return &DeadlineService{repo: repo, reminders: reminders, escalations: escalations, audit: audit}
+}
+
+func (d *DeadlineService) notify(notificationType string, rules []deadlineRule) int64 {
+	var count int64
+	for _, rule := range rules {
+		tasks, err := d.repo.NotifyTasks(notificationType, rule.hours, rule.from, rule.to)
+		if err != nil {
+			log.Println("failed to notify the task deadlines. Error is: ", err.Error())
+		}
+
+		projects, err := d.repo.NotifyProjects(notificationType, rule.hours, rule.from, rule.to)
+		if err != nil {
+			log.Println("failed to notify the project deadlines. Error is: ", err.Error())
+		}
+
+		count += tasks + projects
+	}
+
+	return count
+}
+
+// Check marks what is overdue by now and sends the reminders and the
+// escalations that are due. It returns how many notifications were sent.
+func (d *DeadlineService) Check(now time.Time) (int64, error) {
+	marks, err := d.repo.MarkOverdue(now)
+	if err != nil {
+		log.Println("failed to mark the overdue tasks and projects. Error is: ", err.Error())
+		return 0, err
+	}
+
+	for _, mark := range marks {
+		orgId := mark.OrganizationId
+		ctx := WithAuditSource(context.Background(), models.AuditSource{OrganizationId: &orgId})
+		d.audit.changed(ctx, mark.EntityType, []int{mark.ID}, "is_overdue", !mark.IsOverdue, mark.IsOverdue)
+	}
+
+	count := d.notify(models.NotificationDeadline, reminderRules(now, d.reminders))
+	count += d.notify(models.NotificationOverdue, escalationRules(now, d.escalations))
+
+	return count, nil
+}
+
+// Run checks the deadlines every interval until ctx is done.
+func (d *DeadlineService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if count, err := d.Check(now); err == nil && count > 0 {
+				log.Println("deadline notifications sent:", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/configs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewDeadlineService(t *testing.T) {
+	d := NewDeadlineService(nil, configs.DeadlineConfig{Reminders: []int{1, 24, 0, 1, 72},
+		Escalations: []int{48, 0, -1}}, nil)
+	assert.Equal(t, []int{72, 24, 1}, d.reminders)
+	assert.Equal(t, []int{0, 48}, d.escalations)
+
+	d = NewDeadlineService(nil, configs.DeadlineConfig{}, nil)
+	assert.Equal(t, []int{24}, d.reminders)
+	assert.Equal(t, []int{0}, d.escalations)
+}
+
+func TestDeadlineRules(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return now.Add(time.Duration(hours) * time.Hour)
+	}
+
+	assert.Equal(t, []deadlineRule{
+		{hours: 24, from: at(1), to: at(24)},
+		{hours: 1, from: now, to: at(1)},
+	}, reminderRules(now, []int{24, 1}))
+
+	assert.Equal(t, []deadlineRule{
+		{hours: 0, from: at(-24), to: now},
+		{hours: 24, from: at(-72), to: at(-24)},
+		{hours: 72, to: at(-72)},
+	}, escalationRules(now, []int{0, 24, 72}))
+}
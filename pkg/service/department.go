@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"strings"
+)
+
+type DepartmentService struct {
+	repo repository.Department
+	org  repository.Organization
+}
+
+func NewDepartmentService(repo repository.Department, org repository.Organization) *DepartmentService {
+	return &DepartmentService{repo: repo, org: org}
+}
+
+// prepare normalizes the name and checks that it is not taken by another
+// department and that the head belongs to the organization.
+func (d *DepartmentService) prepare(department *models.Department) error {
+	department.Name = strings.TrimSpace(department.Name)
+	department.Key = utils.NormalizeName(department.Name)
+	if department.Key == "" {
+		return errors.New("name is required")
+	}
+
+	existing, err := d.repo.GetDepartmentByKey(department.OrganizationId, department.Key)
+	if err == nil && existing.ID != department.ID {
+		return errors.New("department with this name already exists")
+	}
+
+	if department.HeadId != nil {
+		if _, err := d.org.GetMember(department.OrganizationId, *department.HeadId); err != nil {
+			return errors.New("head is not a member of the organization")
+		}
+	}
+
+	return nil
+}
+
+func (d *DepartmentService) CreateDepartment(department models.Department) (int, error) {
+	if err := d.prepare(&department); err != nil {
+		return -1, err
+	}
+
+	id, err := d.repo.CreateDepartment(department)
+	if err != nil {
+		log.Println("failed to create a new department. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (d *DepartmentService) GetDepartments(orgId int) (models.Departments, error) {
+	departments, err := d.repo.GetDepartments(orgId)
+	if err != nil {
+		log.Println("failed to get the list of departments. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return departments, nil
+}
+
+func (d *DepartmentService) GetDepartment(orgId, id int) (models.Department, error) {
+	department, err := d.repo.GetDepartment(orgId, id)
+	if err != nil {
+		log.Println("failed to get the department. Error is: ", err.Error())
+		return models.Department{}, err
+	}
+
+	return department, nil
+}
+
+func (d *DepartmentService) UpdateDepartment(department models.Department) error {
+	if _, err := d.repo.GetDepartment(department.OrganizationId, department.ID); err != nil {
+		log.Println("failed to get the department. Error is: ", err.Error())
+		return errors.New("department doesn't exist")
+	}
+
+	if err := d.prepare(&department); err != nil {
+		return err
+	}
+
+	if err := d.repo.UpdateDepartment(department); err != nil {
+		log.Println("failed to update the department. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (d *DepartmentService) DeleteDepartment(orgId, id int) error {
+	if _, err := d.repo.GetDepartment(orgId, id); err != nil {
+		return errors.New("department doesn't exist")
+	}
+
+	if d.repo.HasActiveProjects(id) {
+		return errors.New("department still has projects")
+	}
+
+	if err := d.repo.DeleteDepartment(orgId, id); err != nil {
+		log.Println("failed to delete the department. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (d *DepartmentService) GetMembers(orgId, id int) ([]models.DepartmentMember, error) {
+	if _, err := d.repo.GetDepartment(orgId, id); err != nil {
+		return nil, errors.New("department doesn't exist")
+	}
+
+	members, err := d.repo.GetMembers(id)
+	if err != nil {
+		log.Println("failed to get the members of the department. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (d *DepartmentService) AddMember(orgId int, member models.DepartmentMember) error {
+	if _, err := d.repo.GetDepartment(orgId, member.DepartmentId); err != nil {
+		return errors.New("department doesn't exist")
+	}
+
+	if _, err := d.org.GetMember(orgId, member.UserId); err != nil {
+		return errors.New("user is not a member of the organization")
+	}
+
+	if err := d.repo.AddMember(member); err != nil {
+		log.Println("failed to add a member to the department. Error is: ", err.Error())
+		return errors.New("user is already a member of the department")
+	}
+
+	return nil
+}
+
+func (d *DepartmentService) RemoveMember(orgId, id, userId int) error {
+	if _, err := d.repo.GetDepartment(orgId, id); err != nil {
+		return errors.New("department doesn't exist")
+	}
+
+	if err := d.repo.RemoveMember(id, userId); err != nil {
+		log.Println("failed to remove a member from the department. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (d *DepartmentService) GetProjects(orgId, id int) (models.Projects, error) {
+	projects, err := d.repo.GetProjects(orgId, id)
+	if err != nil {
+		log.Println("failed to get the projects of the department. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (d *DepartmentService) GetStats(orgId, id int) (models.DepartmentStats, error) {
+	if _, err := d.repo.GetDepartment(orgId, id); err != nil {
+		return models.DepartmentStats{}, errors.New("department doesn't exist")
+	}
+
+	stats, err := d.repo.GetStats(orgId, id)
+	if err != nil {
+		log.Println("failed to get the statistics of the department. Error is: ", err.Error())
+		return models.DepartmentStats{}, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/mailer"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"golang.org/x/crypto/bcrypt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	inviteTTL      = 7 * 24 * time.Hour
+	inviteAudience = "project-invite"
+)
+
+// inviteURL is the page the emailed link opens: INVITE_URL when the
+// frontend has one, or else the API's own invite page, which shows the
+// invite and where to post the token to accept it.
+func inviteURL() string {
+	if link := os.Getenv("INVITE_URL"); link != "" {
+		return link
+	}
+
+	return os.Getenv("APP_URL") + "/v1/invites/accept"
+}
+
+type inviteClaims struct {
+	jwt.RegisteredClaims
+	InviteID int `json:"invite_id"`
+}
+
+type InviteService struct {
+	repo    repository.Invite
+	project repository.Project
+	users   repository.Authorization
+	auth    *AuthService
+	keys    *KeySet
+	mailer  mailer.Mailer
+	feed    *ActivityFeed
+	audit   *AuditLog
+}
+
+func NewInviteService(repo repository.Invite, project repository.Project, users repository.Authorization,
+	auth *AuthService, keys *KeySet, mailer mailer.Mailer, feed *ActivityFeed, audit *AuditLog) *InviteService {
+	return &InviteService{
+		repo:    repo,
+		project: project,
+		users:   users,
+		auth:    auth,
+		keys:    keys,
+		mailer:  mailer,
+		feed:    feed,
+		audit:   audit,
+	}
+}
+
+func (i *InviteService) CreateInvite(orgId, managerId int, invite models.ProjectInvite) (int, error) {
+	project, err := i.project.GetProjectById(orgId, managerId, invite.ProjectId)
+	if err != nil {
+		log.Println("failed to get the project while inviting. Error is: ", err.Error())
+		return -1, errors.New("project doesn't exist")
+	}
+
+	invite.Email = strings.ToLower(strings.TrimSpace(invite.Email))
+	if !strings.Contains(invite.Email, "@") {
+		return -1, errors.New("invalid email")
+	}
+
+	if i.repo.IsParticipant(invite.ProjectId, invite.Email) {
+		return -1, errors.New("user is already a participant of the project")
+	}
+
+	if i.repo.HasPendingInvite(invite.ProjectId, invite.Email) {
+		return -1, errors.New("there is already a pending invite for this email")
+	}
+
+	if invite.Role == "" {
+		invite.Role = "participant"
+	}
+	invite.InvitedBy = managerId
+	invite.Status = "pending"
+	invite.ExpiresAt = time.Now().Add(inviteTTL)
+
+	id, err := i.repo.CreateInvite(invite)
+	if err != nil {
+		log.Println("failed to create the invite. Error is: ", err.Error())
+		return -1, err
+	}
+	invite.ID = id
+
+	if err := i.sendInvite(invite, project); err != nil {
+		log.Println("failed to send the invite. Error is: ", err.Error())
+		if err := i.repo.RevokeInvite(invite.ProjectId, id); err != nil {
+			log.Println("failed to revoke the unsent invite. Error is: ", err.Error())
+		}
+		return -1, errors.New("failed to send the invite")
+	}
+
+	// Users who already have an account learn about the invite in the app too.
+	if user, err := i.users.GetUser(invite.Email); err == nil {
+		i.feed.notify(models.Notification{Type: models.NotificationInvited, ActorId: &managerId,
+			ProjectId: &invite.ProjectId, Data: models.JSONMap{"role": invite.Role}}, []int{user.ID})
+	}
+
+	return id, nil
+}
+
+func (i *InviteService) sendInvite(invite models.ProjectInvite, project models.Project) error {
+	token, err := i.keys.sign(&inviteClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   invite.Email,
+			Audience:  jwt.ClaimStrings{inviteAudience},
+			ExpiresAt: jwt.NewNumericDate(invite.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		InviteID: invite.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s?token=%s", inviteURL(), url.QueryEscape(token))
+
+	msg, err := mailer.Render("invite", []string{invite.Email},
+		fmt.Sprintf("You are invited to the project %s", project.Name), mailer.InviteData{
+			Project: project.Name,
+			Role:    invite.Role,
+			Link:    link,
+			Expires: invite.ExpiresAt.Format(time.RFC1123),
+		})
+	if err != nil {
+		return err
+	}
+
+	return i.mailer.Send(msg)
+}
+
+func (i *InviteService) GetPendingInvites(orgId, managerId, projectId int) (models.ProjectInvites, error) {
+	if _, err := i.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while listing invites. Error is: ", err.Error())
+		return nil, errors.New("project doesn't exist")
+	}
+
+	invites, err := i.repo.GetPendingInvites(projectId)
+	if err != nil {
+		log.Println("failed to get the list of invites. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (i *InviteService) RevokeInvite(orgId, managerId, projectId, inviteId int) error {
+	if _, err := i.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while revoking an invite. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	if err := i.repo.RevokeInvite(projectId, inviteId); err != nil {
+		log.Println("failed to revoke the invite. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// pendingInvite returns the invite of the token while it can still be
+// accepted.
+func (i *InviteService) pendingInvite(token string) (models.ProjectInvite, error) {
+	var claims inviteClaims
+	if err := i.keys.parse(token, &claims, jwt.WithAudience(inviteAudience)); err != nil {
+		log.Println("failed to parse the invite token. Error is: ", err.Error())
+		return models.ProjectInvite{}, errors.New("invalid or expired invite")
+	}
+
+	invite, err := i.repo.GetInvite(claims.InviteID)
+	if err != nil || invite.Email != claims.Subject {
+		return models.ProjectInvite{}, errors.New("invalid or expired invite")
+	}
+
+	if invite.Status != "pending" || invite.ExpiresAt.Before(time.Now()) {
+		return models.ProjectInvite{}, errors.New("invite is no longer valid")
+	}
+
+	return invite, nil
+}
+
+// GetInvite shows the invite of the token, and whether the invited email
+// has an account already or has to sign up while accepting it.
+func (i *InviteService) GetInvite(token string) (models.InviteInfo, error) {
+	invite, err := i.pendingInvite(token)
+	if err != nil {
+		return models.InviteInfo{}, err
+	}
+
+	_, err = i.users.GetUser(invite.Email)
+
+	return models.InviteInfo{
+		Project:    invite.ProjectName,
+		Email:      invite.Email,
+		Role:       invite.Role,
+		ExpiresAt:  invite.ExpiresAt,
+		HasAccount: err == nil,
+	}, nil
+}
+
+// AcceptInvite attaches the invited email's account to the project. When no
+// account exists yet, one is created from user's names and password.
+func (i *InviteService) AcceptInvite(ctx context.Context, token string,
+	user models.User) (models.ProjectParticipant, error) {
+	invite, err := i.pendingInvite(token)
+	if err != nil {
+		return models.ProjectParticipant{}, err
+	}
+
+	existing, err := i.users.GetUser(invite.Email)
+	signedUp := err != nil
+	if !signedUp {
+		if !existing.IsActive {
+			return models.ProjectParticipant{}, errors.New("account is deactivated")
+		}
+		user = existing
+	} else {
+		user.Email = invite.Email
+		if user.Firstname == "" || user.Lastname == "" {
+			return models.ProjectParticipant{}, errors.New("firstname and lastname are required to sign up")
+		}
+		if err := i.auth.ValidateUser(user); err != nil {
+			return models.ProjectParticipant{}, err
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Println("failed to hash the password while accepting an invite. Error is: ", err.Error())
+			return models.ProjectParticipant{}, err
+		}
+		user.ID = 0
+		user.Password = string(hash)
+	}
+
+	participant, err := i.repo.AcceptInvite(invite, &user)
+	if errors.Is(err, repository.ErrAlreadyParticipant) {
+		return models.ProjectParticipant{}, err
+	}
+	if err != nil {
+		log.Println("failed to accept the invite. Error is: ", err.Error())
+		return models.ProjectParticipant{}, err
+	}
+
+	if signedUp {
+		i.audit.created(ctx, models.EntityUser, user.ID)
+	}
+	i.audit.created(ctx, models.EntityParticipant, participant.ID)
+
+	i.feed.project(user.ID, models.ActivityParticipantJoined, invite.ProjectId, nil)
+
+	return participant, nil
+}
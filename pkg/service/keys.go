@@ -0,0 +1,202 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sharifsharifzoda/project-management-system/configs"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"math/big"
+	"os"
+)
+
+type verificationKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// KeySet holds the key used to sign new tokens and every key that is still
+// accepted for verification. Keeping retired public keys here lets tokens
+// issued before a rotation stay valid until they expire.
+type KeySet struct {
+	signingKid    string
+	signingMethod jwt.SigningMethod
+	signingKey    crypto.Signer
+	verification  map[string]verificationKey
+	order         []string
+}
+
+func NewKeySet(kid string, signer crypto.Signer) (*KeySet, error) {
+	method, err := signingMethodFor(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{
+		signingKid:    kid,
+		signingMethod: method,
+		signingKey:    signer,
+		verification:  map[string]verificationKey{},
+	}
+
+	if err := ks.AddVerificationKey(kid, signer.Public()); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+func LoadKeySet(cfg configs.JWTConfig) (*KeySet, error) {
+	data, err := os.ReadFile(cfg.SigningKey.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the signing key: %w", err)
+	}
+
+	signer, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the signing key %s: %w", cfg.SigningKey.Kid, err)
+	}
+
+	ks, err := NewKeySet(cfg.SigningKey.Kid, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range cfg.VerificationKeys {
+		data, err := os.ReadFile(k.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the verification key %s: %w", k.Kid, err)
+		}
+
+		pub, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the verification key %s: %w", k.Kid, err)
+		}
+
+		if err := ks.AddVerificationKey(k.Kid, pub); err != nil {
+			return nil, err
+		}
+	}
+
+	return ks, nil
+}
+
+func (ks *KeySet) AddVerificationKey(kid string, pub crypto.PublicKey) error {
+	if kid == "" {
+		return errors.New("key id is empty")
+	}
+	if _, ok := ks.verification[kid]; ok {
+		return fmt.Errorf("duplicate key id %s", kid)
+	}
+
+	method, err := signingMethodFor(pub)
+	if err != nil {
+		return err
+	}
+
+	ks.verification[kid] = verificationKey{kid: kid, method: method, key: pub}
+	ks.order = append(ks.order, kid)
+
+	return nil
+}
+
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	token.Header["kid"] = ks.signingKid
+
+	return token.SignedString(ks.signingKey)
+}
+
+func (ks *KeySet) parse(tokenString string, claims jwt.Claims, opts ...jwt.ParserOption) error {
+	opts = append(opts, jwt.WithExpirationRequired())
+	_, err := jwt.ParseWithClaims(tokenString, claims, ks.keyFunc, opts...)
+	return err
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, errors.New("token has no key id")
+	}
+
+	key, ok := ks.verification[kid]
+	if !ok {
+		return nil, errors.New("unknown key id")
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("invalid signing method")
+	}
+
+	return key.key, nil
+}
+
+func (ks *KeySet) JWKS() models.JWKS {
+	jwks := models.JWKS{Keys: []models.JWK{}}
+
+	for _, kid := range ks.order {
+		key := ks.verification[kid]
+		jwk := models.JWK{
+			Kid: kid,
+			Use: "sig",
+			Alg: key.method.Alg(),
+		}
+
+		switch pub := key.key.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func signingMethodFor(pub crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		return key.(crypto.Signer), nil
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, errors.New("key must be a PEM encoded RSA or Ed25519 private key")
+	}
+
+	return key, nil
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, errors.New("key must be a PEM encoded RSA or Ed25519 public key")
+	}
+
+	return key, nil
+}
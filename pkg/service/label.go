@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"regexp"
+	"strings"
+)
+
+var colorRegexp = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+type LabelService struct {
+	repo    repository.Label
+	project repository.Project
+}
+
+func NewLabelService(repo repository.Label, project repository.Project) *LabelService {
+	return &LabelService{repo: repo, project: project}
+}
+
+// prepare normalizes the name and color and checks that the name is not taken
+// by another label of the project.
+func (l *LabelService) prepare(label *models.Label) error {
+	label.Name = strings.TrimSpace(label.Name)
+	label.Key = utils.NormalizeName(label.Name)
+	if label.Key == "" {
+		return errors.New("name is required")
+	}
+
+	label.Color = strings.ToLower(strings.TrimSpace(label.Color))
+	if !colorRegexp.MatchString(label.Color) {
+		return errors.New("color must be in #rrggbb format")
+	}
+
+	existing, err := l.repo.GetLabelByKey(label.ProjectId, label.Key)
+	if err == nil && existing.ID != label.ID {
+		return errors.New("label with this name already exists")
+	}
+
+	return nil
+}
+
+func (l *LabelService) CreateLabel(orgId, managerId int, label models.Label) (int, error) {
+	if _, err := l.project.GetProjectById(orgId, managerId, label.ProjectId); err != nil {
+		log.Println("failed to get the project while creating a label. Error is: ", err.Error())
+		return -1, errors.New("project doesn't exist")
+	}
+
+	if err := l.prepare(&label); err != nil {
+		return -1, err
+	}
+
+	id, err := l.repo.CreateLabel(label)
+	if err != nil {
+		log.Println("failed to create a new label. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (l *LabelService) GetLabels(orgId, managerId, projectId int) (models.Labels, error) {
+	if _, err := l.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while listing labels. Error is: ", err.Error())
+		return nil, errors.New("project doesn't exist")
+	}
+
+	labels, err := l.repo.GetLabels(projectId)
+	if err != nil {
+		log.Println("failed to get the list of labels. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (l *LabelService) UpdateLabel(orgId, managerId int, label models.Label) error {
+	if _, err := l.project.GetProjectById(orgId, managerId, label.ProjectId); err != nil {
+		log.Println("failed to get the project while updating a label. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	if _, err := l.repo.GetLabel(label.ProjectId, label.ID); err != nil {
+		return errors.New("label doesn't exist")
+	}
+
+	if err := l.prepare(&label); err != nil {
+		return err
+	}
+
+	if err := l.repo.UpdateLabel(label); err != nil {
+		log.Println("failed to update the label. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (l *LabelService) DeleteLabel(orgId, managerId, projectId, id int) error {
+	if _, err := l.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while deleting a label. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	if err := l.repo.DeleteLabel(projectId, id); err != nil {
+		log.Println("failed to delete the label. Error is: ", err.Error())
+		return errors.New("label doesn't exist")
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"strings"
+)
+
+type MilestoneService struct {
+	repo    repository.Milestone
+	project repository.Project
+	feed    *ActivityFeed
+	audit   *AuditLog
+}
+
+func NewMilestoneService(repo repository.Milestone, project repository.Project, feed *ActivityFeed,
+	audit *AuditLog) *MilestoneService {
+	return &MilestoneService{repo: repo, project: project, feed: feed, audit: audit}
+}
+
+func checkMilestone(milestone *models.Milestone) error {
+	milestone.Name = strings.TrimSpace(milestone.Name)
+	if milestone.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if milestone.DueDate.IsZero() {
+		return errors.New("due date is required")
+	}
+
+	return nil
+}
+
+// percentComplete returns the share of done tasks rounded down, so a
+// milestone shows 100 only once every task is done.
+func percentComplete(done, total int64) int {
+	if total == 0 {
+		return 0
+	}
+
+	return int(done * 100 / total)
+}
+
+func (m *MilestoneService) CreateMilestone(orgId, managerId int, milestone models.Milestone) (int, error) {
+	if err := checkProject(m.project, orgId, managerId, milestone.ProjectId); err != nil {
+		return -1, err
+	}
+
+	if err := checkMilestone(&milestone); err != nil {
+		return -1, err
+	}
+
+	id, err := m.repo.CreateMilestone(milestone)
+	if err != nil {
+		log.Println("failed to create a new milestone. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (m *MilestoneService) GetMilestones(orgId, managerId, projectId int) (models.Milestones, error) {
+	if err := checkProject(m.project, orgId, managerId, projectId); err != nil {
+		return nil, err
+	}
+
+	milestones, err := m.repo.GetMilestones(projectId)
+	if err != nil {
+		log.Println("failed to get the list of milestones. Error is: ", err.Error())
+		return nil, err
+	}
+
+	for i := range milestones {
+		milestones[i].PercentComplete = percentComplete(milestones[i].DoneTasks, milestones[i].TotalTasks)
+	}
+
+	return milestones, nil
+}
+
+func (m *MilestoneService) GetMilestone(orgId, managerId, projectId, id int) (models.Milestone, error) {
+	if err := checkProject(m.project, orgId, managerId, projectId); err != nil {
+		return models.Milestone{}, err
+	}
+
+	milestone, err := m.repo.GetMilestone(projectId, id)
+	if err != nil {
+		return models.Milestone{}, errors.New("milestone doesn't exist")
+	}
+	milestone.PercentComplete = percentComplete(milestone.DoneTasks, milestone.TotalTasks)
+
+	milestone.Tasks, err = m.repo.GetMilestoneTasks(id)
+	if err != nil {
+		log.Println("failed to get the tasks of the milestone. Error is: ", err.Error())
+		return models.Milestone{}, err
+	}
+
+	return milestone, nil
+}
+
+func (m *MilestoneService) UpdateMilestone(orgId, managerId int, milestone models.Milestone) error {
+	if err := checkProject(m.project, orgId, managerId, milestone.ProjectId); err != nil {
+		return err
+	}
+
+	if err := checkMilestone(&milestone); err != nil {
+		return err
+	}
+
+	if err := m.repo.UpdateMilestone(milestone); err != nil {
+		log.Println("failed to update the milestone. Error is: ", err.Error())
+		return errors.New("milestone doesn't exist")
+	}
+
+	return nil
+}
+
+func (m *MilestoneService) DeleteMilestone(orgId, managerId, projectId, id int) error {
+	if err := checkProject(m.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	if err := m.repo.DeleteMilestone(projectId, id); err != nil {
+		log.Println("failed to delete the milestone. Error is: ", err.Error())
+		return errors.New("milestone doesn't exist")
+	}
+
+	return nil
+}
+
+func (m *MilestoneService) AddTasks(ctx context.Context, orgId, managerId, projectId, id int, taskIds []int) error {
+	if err := checkProject(m.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	if _, err := m.repo.GetMilestone(projectId, id); err != nil {
+		return errors.New("milestone doesn't exist")
+	}
+
+	audit := m.audit.track(models.EntityTask, uniqueIds(taskIds)...)
+	added, err := addTasks(taskIds, func(ids []int) (int64, error) {
+		return m.repo.AddMilestoneTasks(projectId, id, ids)
+	})
+	// Tasks that were found are added even when others weren't.
+	audit.record(ctx, models.AuditUpdate)
+	if err != nil {
+		return err
+	}
+
+	m.feed.updated(managerId, added, "milestone")
+
+	return nil
+}
+
+func (m *MilestoneService) RemoveTask(ctx context.Context, orgId, managerId, projectId, id, taskId int) error {
+	if err := checkProject(m.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	if _, err := m.repo.GetMilestone(projectId, id); err != nil {
+		return errors.New("milestone doesn't exist")
+	}
+
+	audit := m.audit.track(models.EntityTask, taskId)
+	if err := m.repo.RemoveMilestoneTask(id, taskId); err != nil {
+		log.Println("failed to remove the task from the milestone. Error is: ", err.Error())
+		return errors.New("task is not in the milestone")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	m.feed.updated(managerId, []int{taskId}, "milestone")
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPercentComplete(t *testing.T) {
+	testTable := []struct {
+		name     string
+		done     int64
+		total    int64
+		expected int
+	}{
+		{name: "no tasks", done: 0, total: 0, expected: 0},
+		{name: "nothing done", done: 0, total: 4, expected: 0},
+		{name: "rounded down", done: 2, total: 3, expected: 66},
+		{name: "almost done", done: 199, total: 200, expected: 99},
+		{name: "all done", done: 5, total: 5, expected: 100},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, percentComplete(test.done, test.total))
+		})
+	}
+}
+
+func TestMilestoneService_AddTasks(t *testing.T) {
+	testTable := []struct {
+		name    string
+		taskIds []int
+		added   []int
+		found   int64
+		err     string
+	}{
+		{
+			name:    "each task once",
+			taskIds: []int{5, 6, 5},
+			added:   []int{5, 6},
+			found:   2,
+		},
+		{
+			name: "no tasks",
+			err:  "no tasks to add",
+		},
+		{
+			name:    "tasks of another project",
+			taskIds: []int{5, 7},
+			added:   []int{5, 7},
+			found:   1,
+			err:     "some of the tasks don't exist in the project",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			project := mock_repository.NewMockProject(c)
+			project.EXPECT().GetProjectById(1, 2, 3).Return(models.Project{ID: 3}, nil)
+
+			repo := mock_repository.NewMockMilestone(c)
+			repo.EXPECT().GetMilestone(3, 4).Return(models.Milestone{ID: 4, ProjectId: 3}, nil)
+			if test.added != nil {
+				repo.EXPECT().AddMilestoneTasks(3, 4, test.added).Return(test.found, nil)
+			}
+
+			feed, events := testFeed(c)
+			audit, _ := testAudit(c)
+			subscription := events.Subscribe(0)
+			defer subscription.Close()
+
+			err := NewMilestoneService(repo, project, feed, audit).AddTasks(context.Background(), 1, 2, 3, 4, test.taskIds)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Empty(t, updatedTasks(subscription))
+			} else {
+				assert.NoError(t, err)
+				updated := map[int]any{}
+				for _, taskId := range test.added {
+					updated[taskId] = []string{"milestone"}
+				}
+				assert.Equal(t, updated, updatedTasks(subscription))
+			}
+		})
+	}
+}
+
+func TestMilestoneService_UnknownProject(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	project := mock_repository.NewMockProject(c)
+	project.EXPECT().GetProjectById(1, 2, 3).Return(models.Project{}, errors.New("record not found"))
+
+	feed, _ := testFeed(c)
+	audit, _ := testAudit(c)
+	err := NewMilestoneService(mock_repository.NewMockMilestone(c), project, feed, audit).
+		AddTasks(context.Background(), 1, 2, 3, 4, []int{5})
+	assert.EqualError(t, err, "project doesn't exist")
+}
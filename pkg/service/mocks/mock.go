@@ -5,10 +5,13 @@
 package mock_service
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/sharifsharifzoda/project-management-system/models"
+	broker "github.com/sharifsharifzoda/project-management-system/pkg/broker"
 )
 
 // MockAuthorization is a mock of Authorization interface.
@@ -50,18 +53,18 @@ func (mr *MockAuthorizationMockRecorder) CheckUser(user interface{}) *gomock.Cal
 }
 
 // CreateUser mocks base method.
-func (m *MockAuthorization) CreateUser(user *models.User) (int, error) {
+func (m *MockAuthorization) CreateUser(ctx context.Context, user *models.User) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockAuthorizationMockRecorder) CreateUser(user interface{}) *gomock.Call {
+func (mr *MockAuthorizationMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), ctx, user)
 }
 
 // GenerateToken mocks base method.
@@ -79,6 +82,21 @@ func (mr *MockAuthorizationMockRecorder) GenerateToken(user interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), user)
 }
 
+// Impersonate mocks base method.
+func (m *MockAuthorization) Impersonate(adminId, userId int, ip string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Impersonate", adminId, userId, ip)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Impersonate indicates an expected call of Impersonate.
+func (mr *MockAuthorizationMockRecorder) Impersonate(adminId, userId, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Impersonate", reflect.TypeOf((*MockAuthorization)(nil).Impersonate), adminId, userId, ip)
+}
+
 // IsEmailUsed mocks base method.
 func (m *MockAuthorization) IsEmailUsed(email string) bool {
 	m.ctrl.T.Helper()
@@ -93,14 +111,27 @@ func (mr *MockAuthorizationMockRecorder) IsEmailUsed(email interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailUsed", reflect.TypeOf((*MockAuthorization)(nil).IsEmailUsed), email)
 }
 
+// JWKS mocks base method.
+func (m *MockAuthorization) JWKS() models.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(models.JWKS)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockAuthorizationMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockAuthorization)(nil).JWKS))
+}
+
 // ParseToken mocks base method.
-func (m *MockAuthorization) ParseToken(token string) (int, string, error) {
+func (m *MockAuthorization) ParseToken(token string) (models.Identity, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", token)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(models.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ParseToken indicates an expected call of ParseToken.
@@ -109,6 +140,18 @@ func (mr *MockAuthorizationMockRecorder) ParseToken(token interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), token)
 }
 
+// RecordImpersonation mocks base method.
+func (m *MockAuthorization) RecordImpersonation(entry models.ImpersonationLog) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordImpersonation", entry)
+}
+
+// RecordImpersonation indicates an expected call of RecordImpersonation.
+func (mr *MockAuthorizationMockRecorder) RecordImpersonation(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordImpersonation", reflect.TypeOf((*MockAuthorization)(nil).RecordImpersonation), entry)
+}
+
 // ValidateUser mocks base method.
 func (m *MockAuthorization) ValidateUser(user models.User) error {
 	m.ctrl.T.Helper()
@@ -147,47 +190,47 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 }
 
 // DeleteUser mocks base method.
-func (m *MockUser) DeleteUser(id int) error {
+func (m *MockUser) DeleteUser(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserMockRecorder) DeleteUser(id interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUser)(nil).DeleteUser), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUser)(nil).DeleteUser), ctx, id)
 }
 
 // GetProjects mocks base method.
-func (m *MockUser) GetProjects(userId int) ([]models.ProjectParticipant, error) {
+func (m *MockUser) GetProjects(orgId, userId int) ([]models.UserProject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjects", userId)
-	ret0, _ := ret[0].([]models.ProjectParticipant)
+	ret := m.ctrl.Call(m, "GetProjects", orgId, userId)
+	ret0, _ := ret[0].([]models.UserProject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjects indicates an expected call of GetProjects.
-func (mr *MockUserMockRecorder) GetProjects(userId interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) GetProjects(orgId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockUser)(nil).GetProjects), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockUser)(nil).GetProjects), orgId, userId)
 }
 
 // GetTasks mocks base method.
-func (m *MockUser) GetTasks(userId int) (models.Tasks, error) {
+func (m *MockUser) GetTasks(orgId, userId int) (models.Tasks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", userId)
+	ret := m.ctrl.Call(m, "GetTasks", orgId, userId)
 	ret0, _ := ret[0].(models.Tasks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockUserMockRecorder) GetTasks(userId interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) GetTasks(orgId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockUser)(nil).GetTasks), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockUser)(nil).GetTasks), orgId, userId)
 }
 
 // GetUser mocks base method.
@@ -206,61 +249,61 @@ func (mr *MockUserMockRecorder) GetUser(id interface{}) *gomock.Call {
 }
 
 // Restore mocks base method.
-func (m *MockUser) Restore(id int) error {
+func (m *MockUser) Restore(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Restore", id)
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Restore indicates an expected call of Restore.
-func (mr *MockUserMockRecorder) Restore(id interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUser)(nil).Restore), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUser)(nil).Restore), ctx, id)
 }
 
 // UpdatePictureUser mocks base method.
-func (m *MockUser) UpdatePictureUser(id int, filepath string) (models.User, error) {
+func (m *MockUser) UpdatePictureUser(ctx context.Context, id int, filepath string) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePictureUser", id, filepath)
+	ret := m.ctrl.Call(m, "UpdatePictureUser", ctx, id, filepath)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePictureUser indicates an expected call of UpdatePictureUser.
-func (mr *MockUserMockRecorder) UpdatePictureUser(id, filepath interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) UpdatePictureUser(ctx, id, filepath interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePictureUser", reflect.TypeOf((*MockUser)(nil).UpdatePictureUser), id, filepath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePictureUser", reflect.TypeOf((*MockUser)(nil).UpdatePictureUser), ctx, id, filepath)
 }
 
 // UpdateUser mocks base method.
-func (m *MockUser) UpdateUser(newUser models.User) error {
+func (m *MockUser) UpdateUser(ctx context.Context, newUser models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", newUser)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, newUser)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserMockRecorder) UpdateUser(newUser interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) UpdateUser(ctx, newUser interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUser)(nil).UpdateUser), newUser)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUser)(nil).UpdateUser), ctx, newUser)
 }
 
 // UploadUserPicture mocks base method.
-func (m *MockUser) UploadUserPicture(id int, filepath string) (models.User, error) {
+func (m *MockUser) UploadUserPicture(ctx context.Context, id int, filepath string) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadUserPicture", id, filepath)
+	ret := m.ctrl.Call(m, "UploadUserPicture", ctx, id, filepath)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UploadUserPicture indicates an expected call of UploadUserPicture.
-func (mr *MockUserMockRecorder) UploadUserPicture(id, filepath interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) UploadUserPicture(ctx, id, filepath interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadUserPicture", reflect.TypeOf((*MockUser)(nil).UploadUserPicture), id, filepath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadUserPicture", reflect.TypeOf((*MockUser)(nil).UploadUserPicture), ctx, id, filepath)
 }
 
 // MockProject is a mock of Project interface.
@@ -287,227 +330,2501 @@ func (m *MockProject) EXPECT() *MockProjectMockRecorder {
 }
 
 // AddUserToProject mocks base method.
-func (m *MockProject) AddUserToProject(managerId int, propar models.ProjectParticipant) error {
+func (m *MockProject) AddUserToProject(ctx context.Context, orgId, managerId int, propar models.ProjectParticipant) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUserToProject", managerId, propar)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AddUserToProject", ctx, orgId, managerId, propar)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddUserToProject indicates an expected call of AddUserToProject.
-func (mr *MockProjectMockRecorder) AddUserToProject(managerId, propar interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) AddUserToProject(ctx, orgId, managerId, propar interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToProject", reflect.TypeOf((*MockProject)(nil).AddUserToProject), managerId, propar)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToProject", reflect.TypeOf((*MockProject)(nil).AddUserToProject), ctx, orgId, managerId, propar)
 }
 
 // CreateProject mocks base method.
-func (m *MockProject) CreateProject(project models.Project) (int, error) {
+func (m *MockProject) CreateProject(ctx context.Context, project models.Project) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProject", project)
+	ret := m.ctrl.Call(m, "CreateProject", ctx, project)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProject indicates an expected call of CreateProject.
-func (mr *MockProjectMockRecorder) CreateProject(project interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) CreateProject(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProject)(nil).CreateProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProject)(nil).CreateProject), ctx, project)
 }
 
 // DeleteProject mocks base method.
-func (m *MockProject) DeleteProject(userId, projectId int) error {
+func (m *MockProject) DeleteProject(ctx context.Context, orgId, userId, projectId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProject", userId, projectId)
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, orgId, userId, projectId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProject indicates an expected call of DeleteProject.
-func (mr *MockProjectMockRecorder) DeleteProject(userId, projectId interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) DeleteProject(ctx, orgId, userId, projectId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProject)(nil).DeleteProject), userId, projectId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProject)(nil).DeleteProject), ctx, orgId, userId, projectId)
 }
 
 // GetAllProjects mocks base method.
-func (m *MockProject) GetAllProjects(userId int) (models.Projects, error) {
+func (m *MockProject) GetAllProjects(orgId, userId int) (models.Projects, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProjects", userId)
+	ret := m.ctrl.Call(m, "GetAllProjects", orgId, userId)
 	ret0, _ := ret[0].(models.Projects)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllProjects indicates an expected call of GetAllProjects.
-func (mr *MockProjectMockRecorder) GetAllProjects(userId interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) GetAllProjects(orgId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProjects", reflect.TypeOf((*MockProject)(nil).GetAllProjects), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProjects", reflect.TypeOf((*MockProject)(nil).GetAllProjects), orgId, userId)
 }
 
 // GetDeletedProjects mocks base method.
-func (m *MockProject) GetDeletedProjects(userId int) (models.Projects, error) {
+func (m *MockProject) GetDeletedProjects(orgId, userId int) (models.Projects, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDeletedProjects", userId)
+	ret := m.ctrl.Call(m, "GetDeletedProjects", orgId, userId)
 	ret0, _ := ret[0].(models.Projects)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDeletedProjects indicates an expected call of GetDeletedProjects.
-func (mr *MockProjectMockRecorder) GetDeletedProjects(userId interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) GetDeletedProjects(orgId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedProjects", reflect.TypeOf((*MockProject)(nil).GetDeletedProjects), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedProjects", reflect.TypeOf((*MockProject)(nil).GetDeletedProjects), orgId, userId)
 }
 
 // GetProjectById mocks base method.
-func (m *MockProject) GetProjectById(userId, projectId int) (models.Project, error) {
+func (m *MockProject) GetProjectById(orgId, userId, projectId int) (models.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjectById", userId, projectId)
+	ret := m.ctrl.Call(m, "GetProjectById", orgId, userId, projectId)
 	ret0, _ := ret[0].(models.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjectById indicates an expected call of GetProjectById.
-func (mr *MockProjectMockRecorder) GetProjectById(userId, projectId interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) GetProjectById(orgId, userId, projectId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectById", reflect.TypeOf((*MockProject)(nil).GetProjectById), userId, projectId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectById", reflect.TypeOf((*MockProject)(nil).GetProjectById), orgId, userId, projectId)
 }
 
 // Restore mocks base method.
-func (m *MockProject) Restore(userId, projectId int) error {
+func (m *MockProject) Restore(ctx context.Context, orgId, userId, projectId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Restore", userId, projectId)
+	ret := m.ctrl.Call(m, "Restore", ctx, orgId, userId, projectId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Restore indicates an expected call of Restore.
-func (mr *MockProjectMockRecorder) Restore(userId, projectId interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) Restore(ctx, orgId, userId, projectId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProject)(nil).Restore), userId, projectId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProject)(nil).Restore), ctx, orgId, userId, projectId)
 }
 
 // UpdateProject mocks base method.
-func (m *MockProject) UpdateProject(project models.Project) error {
+func (m *MockProject) UpdateProject(ctx context.Context, project models.Project) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, project)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateProject indicates an expected call of UpdateProject.
-func (mr *MockProjectMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) UpdateProject(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProject)(nil).UpdateProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProject)(nil).UpdateProject), ctx, project)
 }
 
-// MockTask is a mock of Task interface.
-type MockTask struct {
+// MockActivity is a mock of Activity interface.
+type MockActivity struct {
 	ctrl     *gomock.Controller
-	recorder *MockTaskMockRecorder
+	recorder *MockActivityMockRecorder
 }
 
-// MockTaskMockRecorder is the mock recorder for MockTask.
-type MockTaskMockRecorder struct {
-	mock *MockTask
+// MockActivityMockRecorder is the mock recorder for MockActivity.
+type MockActivityMockRecorder struct {
+	mock *MockActivity
 }
 
-// NewMockTask creates a new mock instance.
-func NewMockTask(ctrl *gomock.Controller) *MockTask {
-	mock := &MockTask{ctrl: ctrl}
-	mock.recorder = &MockTaskMockRecorder{mock}
+// NewMockActivity creates a new mock instance.
+func NewMockActivity(ctrl *gomock.Controller) *MockActivity {
+	mock := &MockActivity{ctrl: ctrl}
+	mock.recorder = &MockActivityMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTask) EXPECT() *MockTaskMockRecorder {
+func (m *MockActivity) EXPECT() *MockActivityMockRecorder {
 	return m.recorder
 }
 
-// CreateTask mocks base method.
-func (m *MockTask) CreateTask(task models.Task) (int, error) {
+// GetProjectActivity mocks base method.
+func (m *MockActivity) GetProjectActivity(userId int, filter models.ActivityFilter) (models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectActivity", userId, filter)
+	ret0, _ := ret[0].(models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectActivity indicates an expected call of GetProjectActivity.
+func (mr *MockActivityMockRecorder) GetProjectActivity(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectActivity", reflect.TypeOf((*MockActivity)(nil).GetProjectActivity), userId, filter)
+}
+
+// GetUserActivity mocks base method.
+func (m *MockActivity) GetUserActivity(filter models.ActivityFilter) (models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserActivity", filter)
+	ret0, _ := ret[0].(models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserActivity indicates an expected call of GetUserActivity.
+func (mr *MockActivityMockRecorder) GetUserActivity(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActivity", reflect.TypeOf((*MockActivity)(nil).GetUserActivity), filter)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// AddComment mocks base method.
+func (m *MockComment) AddComment(orgId, userId int, comment models.TaskComment) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", task)
+	ret := m.ctrl.Call(m, "AddComment", orgId, userId, comment)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskMockRecorder) CreateTask(task interface{}) *gomock.Call {
+// AddComment indicates an expected call of AddComment.
+func (mr *MockCommentMockRecorder) AddComment(orgId, userId, comment interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTask)(nil).CreateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockComment)(nil).AddComment), orgId, userId, comment)
 }
 
-// DeleteTask mocks base method.
-func (m *MockTask) DeleteTask(userId, taskId int) error {
+// GetComments mocks base method.
+func (m *MockComment) GetComments(orgId, userId, taskId int) (models.TaskComments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", orgId, userId, taskId)
+	ret0, _ := ret[0].(models.TaskComments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), orgId, userId, taskId)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotification) CountUnread(userId int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationMockRecorder) CountUnread(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotification)(nil).CountUnread), userId)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotification) GetNotifications(filter models.NotificationFilter) (models.NotificationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", filter)
+	ret0, _ := ret[0].(models.NotificationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationMockRecorder) GetNotifications(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotification)(nil).GetNotifications), filter)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotification) GetPreferences(userId int) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userId)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationMockRecorder) GetPreferences(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotification)(nil).GetPreferences), userId)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userId int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(userId, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", userId, taskId)
+	ret := m.ctrl.Call(m, "MarkRead", userId, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskMockRecorder) DeleteTask(userId, taskId interface{}) *gomock.Call {
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(userId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTask)(nil).DeleteTask), userId, taskId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), userId, id)
 }
 
-// GetAllTasks mocks base method.
-func (m *MockTask) GetAllTasks(userId int) (models.Tasks, error) {
+// Run mocks base method.
+func (m *MockNotification) Run(ctx context.Context, interval time.Duration) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", userId)
-	ret0, _ := ret[0].(models.Tasks)
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockNotificationMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockNotification)(nil).Run), ctx, interval)
+}
+
+// SendEmails mocks base method.
+func (m *MockNotification) SendEmails(now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmails", now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmails indicates an expected call of SendEmails.
+func (mr *MockNotificationMockRecorder) SendEmails(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmails", reflect.TypeOf((*MockNotification)(nil).SendEmails), now)
+}
+
+// SetPreferences mocks base method.
+func (m *MockNotification) SetPreferences(userId int, modes map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreferences", userId, modes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreferences indicates an expected call of SetPreferences.
+func (mr *MockNotificationMockRecorder) SetPreferences(userId, modes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreferences", reflect.TypeOf((*MockNotification)(nil).SetPreferences), userId, modes)
+}
+
+// MockDeadline is a mock of Deadline interface.
+type MockDeadline struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadlineMockRecorder
+}
+
+// MockDeadlineMockRecorder is the mock recorder for MockDeadline.
+type MockDeadlineMockRecorder struct {
+	mock *MockDeadline
+}
+
+// NewMockDeadline creates a new mock instance.
+func NewMockDeadline(ctrl *gomock.Controller) *MockDeadline {
+	mock := &MockDeadline{ctrl: ctrl}
+	mock.recorder = &MockDeadlineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadline) EXPECT() *MockDeadlineMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockDeadline) Check(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", now)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockTaskMockRecorder) GetAllTasks(userId interface{}) *gomock.Call {
+// Check indicates an expected call of Check.
+func (mr *MockDeadlineMockRecorder) Check(now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockDeadline)(nil).Check), now)
 }
 
-// GetTaskById mocks base method.
-func (m *MockTask) GetTaskById(userId, taskId int) (models.Task, error) {
+// Run mocks base method.
+func (m *MockDeadline) Run(ctx context.Context, interval time.Duration) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskById", userId, taskId)
-	ret0, _ := ret[0].(models.Task)
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDeadlineMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDeadline)(nil).Run), ctx, interval)
+}
+
+// MockStream is a mock of Stream interface.
+type MockStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamMockRecorder
+}
+
+// MockStreamMockRecorder is the mock recorder for MockStream.
+type MockStreamMockRecorder struct {
+	mock *MockStream
+}
+
+// NewMockStream creates a new mock instance.
+func NewMockStream(ctrl *gomock.Controller) *MockStream {
+	mock := &MockStream{ctrl: ctrl}
+	mock.recorder = &MockStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStream) EXPECT() *MockStreamMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockStream) Subscribe(ctx context.Context, orgId, userId int) <-chan broker.Event {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, orgId, userId)
+	ret0, _ := ret[0].(<-chan broker.Event)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStreamMockRecorder) Subscribe(ctx, orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStream)(nil).Subscribe), ctx, orgId, userId)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhook) CreateWebhook(webhook models.Webhook) (models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", webhook)
+	ret0, _ := ret[0].(models.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTaskById indicates an expected call of GetTaskById.
-func (mr *MockTaskMockRecorder) GetTaskById(userId, taskId interface{}) *gomock.Call {
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookMockRecorder) CreateWebhook(webhook interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTask)(nil).GetTaskById), userId, taskId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhook)(nil).CreateWebhook), webhook)
 }
 
-// RestoreTask mocks base method.
-func (m *MockTask) RestoreTask(userId, taskId int) error {
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(orgId, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RestoreTask", userId, taskId)
+	ret := m.ctrl.Call(m, "DeleteWebhook", orgId, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RestoreTask indicates an expected call of RestoreTask.
-func (mr *MockTaskMockRecorder) RestoreTask(userId, taskId interface{}) *gomock.Call {
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(orgId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTask)(nil).RestoreTask), userId, taskId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), orgId, id)
 }
 
-// UpdateTask mocks base method.
-func (m *MockTask) UpdateTask(task models.Task) error {
+// Deliver mocks base method.
+func (m *MockWebhook) Deliver(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookMockRecorder) Deliver(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhook)(nil).Deliver), now)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(orgId, id, beforeId, limit int) (models.WebhookDeliveryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", orgId, id, beforeId, limit)
+	ret0, _ := ret[0].(models.WebhookDeliveryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(orgId, id, beforeId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), orgId, id, beforeId, limit)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhook) GetWebhook(orgId, id int) (models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", orgId, id)
+	ret0, _ := ret[0].(models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookMockRecorder) GetWebhook(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhook)(nil).GetWebhook), orgId, id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(orgId int) (models.Webhooks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", orgId)
+	ret0, _ := ret[0].(models.Webhooks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), orgId)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhook) Redeliver(orgId, id, deliveryId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", orgId, id, deliveryId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookMockRecorder) Redeliver(orgId, id, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhook)(nil).Redeliver), orgId, id, deliveryId)
+}
+
+// Run mocks base method.
+func (m *MockWebhook) Run(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockWebhookMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockWebhook)(nil).Run), ctx, interval)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhook) UpdateWebhook(webhook models.Webhook) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", task)
+	ret := m.ctrl.Call(m, "UpdateWebhook", webhook)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookMockRecorder) UpdateWebhook(webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhook)(nil).UpdateWebhook), webhook)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// GetEntries mocks base method.
+func (m *MockAudit) GetEntries(filter models.AuditFilter) (models.AuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", filter)
+	ret0, _ := ret[0].(models.AuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditMockRecorder) GetEntries(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAudit)(nil).GetEntries), filter)
+}
+
+// MockTemplate is a mock of Template interface.
+type MockTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateMockRecorder
+}
+
+// MockTemplateMockRecorder is the mock recorder for MockTemplate.
+type MockTemplateMockRecorder struct {
+	mock *MockTemplate
+}
+
+// NewMockTemplate creates a new mock instance.
+func NewMockTemplate(ctrl *gomock.Controller) *MockTemplate {
+	mock := &MockTemplate{ctrl: ctrl}
+	mock.recorder = &MockTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplate) EXPECT() *MockTemplateMockRecorder {
+	return m.recorder
+}
+
+// CloneProject mocks base method.
+func (m *MockTemplate) CloneProject(ctx context.Context, orgId, userId, projectId int, project models.Project) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneProject", ctx, orgId, userId, projectId, project)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneProject indicates an expected call of CloneProject.
+func (mr *MockTemplateMockRecorder) CloneProject(ctx, orgId, userId, projectId, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneProject", reflect.TypeOf((*MockTemplate)(nil).CloneProject), ctx, orgId, userId, projectId, project)
+}
+
+// CreateProject mocks base method.
+func (m *MockTemplate) CreateProject(ctx context.Context, orgId, templateId int, project models.Project) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", ctx, orgId, templateId, project)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockTemplateMockRecorder) CreateProject(ctx, orgId, templateId, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockTemplate)(nil).CreateProject), ctx, orgId, templateId, project)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTemplate) DeleteTemplate(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTemplateMockRecorder) DeleteTemplate(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplate)(nil).DeleteTemplate), orgId, id)
+}
+
+// GetTemplate mocks base method.
+func (m *MockTemplate) GetTemplate(orgId, id int) (models.ProjectTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", orgId, id)
+	ret0, _ := ret[0].(models.ProjectTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockTemplateMockRecorder) GetTemplate(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTemplate)(nil).GetTemplate), orgId, id)
+}
+
+// GetTemplates mocks base method.
+func (m *MockTemplate) GetTemplates(orgId int) (models.ProjectTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", orgId)
+	ret0, _ := ret[0].(models.ProjectTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockTemplateMockRecorder) GetTemplates(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockTemplate)(nil).GetTemplates), orgId)
+}
+
+// SaveTemplate mocks base method.
+func (m *MockTemplate) SaveTemplate(orgId, userId, projectId int, template models.ProjectTemplate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTemplate", orgId, userId, projectId, template)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTemplate indicates an expected call of SaveTemplate.
+func (mr *MockTemplateMockRecorder) SaveTemplate(orgId, userId, projectId, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTemplate", reflect.TypeOf((*MockTemplate)(nil).SaveTemplate), orgId, userId, projectId, template)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockTemplate) UpdateTemplate(template models.ProjectTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockTemplateMockRecorder) UpdateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTemplate)(nil).UpdateTemplate), template)
+}
+
+// MockTask is a mock of Task interface.
+type MockTask struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskMockRecorder
+}
+
+// MockTaskMockRecorder is the mock recorder for MockTask.
+type MockTaskMockRecorder struct {
+	mock *MockTask
+}
+
+// NewMockTask creates a new mock instance.
+func NewMockTask(ctrl *gomock.Controller) *MockTask {
+	mock := &MockTask{ctrl: ctrl}
+	mock.recorder = &MockTaskMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTask) EXPECT() *MockTaskMockRecorder {
+	return m.recorder
+}
+
+// BulkUpdate mocks base method.
+func (m *MockTask) BulkUpdate(ctx context.Context, orgId, userId int, bulk models.BulkTaskUpdate) (models.BulkTaskResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdate", ctx, orgId, userId, bulk)
+	ret0, _ := ret[0].(models.BulkTaskResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdate indicates an expected call of BulkUpdate.
+func (mr *MockTaskMockRecorder) BulkUpdate(ctx, orgId, userId, bulk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockTask)(nil).BulkUpdate), ctx, orgId, userId, bulk)
+}
+
+// CopyTask mocks base method.
+func (m *MockTask) CopyTask(ctx context.Context, orgId, userId, taskId, projectId int, options models.TransferOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyTask", ctx, orgId, userId, taskId, projectId, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyTask indicates an expected call of CopyTask.
+func (mr *MockTaskMockRecorder) CopyTask(ctx, orgId, userId, taskId, projectId, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyTask", reflect.TypeOf((*MockTask)(nil).CopyTask), ctx, orgId, userId, taskId, projectId, options)
+}
+
+// CreateTask mocks base method.
+func (m *MockTask) CreateTask(ctx context.Context, task models.Task) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, task)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockTaskMockRecorder) CreateTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTask)(nil).CreateTask), ctx, task)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTask) DeleteTask(ctx context.Context, orgId, userId, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, orgId, userId, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTaskMockRecorder) DeleteTask(ctx, orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTask)(nil).DeleteTask), ctx, orgId, userId, taskId)
+}
+
+// GetAllTasks mocks base method.
+func (m *MockTask) GetAllTasks(orgId, userId int, filter models.TaskFilter) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTasks", orgId, userId, filter)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTasks indicates an expected call of GetAllTasks.
+func (mr *MockTaskMockRecorder) GetAllTasks(orgId, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks), orgId, userId, filter)
+}
+
+// GetAssignees mocks base method.
+func (m *MockTask) GetAssignees(orgId, userId, taskId int) ([]models.TaskAssignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignees", orgId, userId, taskId)
+	ret0, _ := ret[0].([]models.TaskAssignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignees indicates an expected call of GetAssignees.
+func (mr *MockTaskMockRecorder) GetAssignees(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignees", reflect.TypeOf((*MockTask)(nil).GetAssignees), orgId, userId, taskId)
+}
+
+// GetTaskById mocks base method.
+func (m *MockTask) GetTaskById(orgId, userId, taskId int) (models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskById", orgId, userId, taskId)
+	ret0, _ := ret[0].(models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskById indicates an expected call of GetTaskById.
+func (mr *MockTaskMockRecorder) GetTaskById(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTask)(nil).GetTaskById), orgId, userId, taskId)
+}
+
+// GetTransfers mocks base method.
+func (m *MockTask) GetTransfers(orgId, userId, taskId int) ([]models.TaskTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfers", orgId, userId, taskId)
+	ret0, _ := ret[0].([]models.TaskTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfers indicates an expected call of GetTransfers.
+func (mr *MockTaskMockRecorder) GetTransfers(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfers", reflect.TypeOf((*MockTask)(nil).GetTransfers), orgId, userId, taskId)
+}
+
+// MoveTask mocks base method.
+func (m *MockTask) MoveTask(ctx context.Context, orgId, userId, taskId, projectId int, options models.TransferOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, orgId, userId, taskId, projectId, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTaskMockRecorder) MoveTask(ctx, orgId, userId, taskId, projectId, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTask)(nil).MoveTask), ctx, orgId, userId, taskId, projectId, options)
+}
+
+// RemoveAssignee mocks base method.
+func (m *MockTask) RemoveAssignee(ctx context.Context, orgId, userId, taskId, assigneeId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignee", ctx, orgId, userId, taskId, assigneeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAssignee indicates an expected call of RemoveAssignee.
+func (mr *MockTaskMockRecorder) RemoveAssignee(ctx, orgId, userId, taskId, assigneeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignee", reflect.TypeOf((*MockTask)(nil).RemoveAssignee), ctx, orgId, userId, taskId, assigneeId)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTask) RestoreTask(ctx context.Context, orgId, userId, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, orgId, userId, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTaskMockRecorder) RestoreTask(ctx, orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTask)(nil).RestoreTask), ctx, orgId, userId, taskId)
+}
+
+// SetAssignee mocks base method.
+func (m *MockTask) SetAssignee(ctx context.Context, orgId, userId int, assignee models.TaskAssignee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAssignee", ctx, orgId, userId, assignee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAssignee indicates an expected call of SetAssignee.
+func (mr *MockTaskMockRecorder) SetAssignee(ctx, orgId, userId, assignee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssignee", reflect.TypeOf((*MockTask)(nil).SetAssignee), ctx, orgId, userId, assignee)
+}
+
+// SetLabels mocks base method.
+func (m *MockTask) SetLabels(ctx context.Context, orgId, userId, taskId int, labelIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabels", ctx, orgId, userId, taskId, labelIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLabels indicates an expected call of SetLabels.
+func (mr *MockTaskMockRecorder) SetLabels(ctx, orgId, userId, taskId, labelIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabels", reflect.TypeOf((*MockTask)(nil).SetLabels), ctx, orgId, userId, taskId, labelIds)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTask) UpdateTask(ctx context.Context, task models.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskMockRecorder) UpdateTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), ctx, task)
+}
+
+// MockInvite is a mock of Invite interface.
+type MockInvite struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteMockRecorder
+}
+
+// MockInviteMockRecorder is the mock recorder for MockInvite.
+type MockInviteMockRecorder struct {
+	mock *MockInvite
+}
+
+// NewMockInvite creates a new mock instance.
+func NewMockInvite(ctrl *gomock.Controller) *MockInvite {
+	mock := &MockInvite{ctrl: ctrl}
+	mock.recorder = &MockInviteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvite) EXPECT() *MockInviteMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvite mocks base method.
+func (m *MockInvite) AcceptInvite(ctx context.Context, token string, user models.User) (models.ProjectParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", ctx, token, user)
+	ret0, _ := ret[0].(models.ProjectParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockInviteMockRecorder) AcceptInvite(ctx, token, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockInvite)(nil).AcceptInvite), ctx, token, user)
+}
+
+// CreateInvite mocks base method.
+func (m *MockInvite) CreateInvite(orgId, managerId int, invite models.ProjectInvite) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", orgId, managerId, invite)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockInviteMockRecorder) CreateInvite(orgId, managerId, invite interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockInvite)(nil).CreateInvite), orgId, managerId, invite)
+}
+
+// GetInvite mocks base method.
+func (m *MockInvite) GetInvite(token string) (models.InviteInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvite", token)
+	ret0, _ := ret[0].(models.InviteInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvite indicates an expected call of GetInvite.
+func (mr *MockInviteMockRecorder) GetInvite(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvite", reflect.TypeOf((*MockInvite)(nil).GetInvite), token)
+}
+
+// GetPendingInvites mocks base method.
+func (m *MockInvite) GetPendingInvites(orgId, managerId, projectId int) (models.ProjectInvites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvites", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.ProjectInvites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvites indicates an expected call of GetPendingInvites.
+func (mr *MockInviteMockRecorder) GetPendingInvites(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvites", reflect.TypeOf((*MockInvite)(nil).GetPendingInvites), orgId, managerId, projectId)
+}
+
+// RevokeInvite mocks base method.
+func (m *MockInvite) RevokeInvite(orgId, managerId, projectId, inviteId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvite", orgId, managerId, projectId, inviteId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvite indicates an expected call of RevokeInvite.
+func (mr *MockInviteMockRecorder) RevokeInvite(orgId, managerId, projectId, inviteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvite", reflect.TypeOf((*MockInvite)(nil).RevokeInvite), orgId, managerId, projectId, inviteId)
+}
+
+// MockOrganization is a mock of Organization interface.
+type MockOrganization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMockRecorder
+}
+
+// MockOrganizationMockRecorder is the mock recorder for MockOrganization.
+type MockOrganizationMockRecorder struct {
+	mock *MockOrganization
+}
+
+// NewMockOrganization creates a new mock instance.
+func NewMockOrganization(ctrl *gomock.Controller) *MockOrganization {
+	mock := &MockOrganization{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganization) EXPECT() *MockOrganizationMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockOrganization) AddMember(ctx context.Context, actorRole string, member models.OrganizationMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, actorRole, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationMockRecorder) AddMember(ctx, actorRole, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganization)(nil).AddMember), ctx, actorRole, member)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganization) CreateOrganization(userId int, org models.Organization) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", userId, org)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationMockRecorder) CreateOrganization(userId, org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganization)(nil).CreateOrganization), userId, org)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganization) GetMembers(orgId int) ([]models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", orgId)
+	ret0, _ := ret[0].([]models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationMockRecorder) GetMembers(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganization)(nil).GetMembers), orgId)
+}
+
+// GetMembership mocks base method.
+func (m *MockOrganization) GetMembership(orgId, userId int) (models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", orgId, userId)
+	ret0, _ := ret[0].(models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockOrganizationMockRecorder) GetMembership(orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockOrganization)(nil).GetMembership), orgId, userId)
+}
+
+// GetOrganization mocks base method.
+func (m *MockOrganization) GetOrganization(orgId int) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", orgId)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockOrganizationMockRecorder) GetOrganization(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockOrganization)(nil).GetOrganization), orgId)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganization) GetOrganizations(userId int) (models.Organizations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", userId)
+	ret0, _ := ret[0].(models.Organizations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationMockRecorder) GetOrganizations(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganization)(nil).GetOrganizations), userId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganization) RemoveMember(ctx context.Context, actorRole string, orgId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, actorRole, orgId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationMockRecorder) RemoveMember(ctx, actorRole, orgId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganization)(nil).RemoveMember), ctx, actorRole, orgId, userId)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockOrganization) UpdateMemberRole(ctx context.Context, actorRole string, orgId, userId int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, actorRole, orgId, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockOrganizationMockRecorder) UpdateMemberRole(ctx, actorRole, orgId, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockOrganization)(nil).UpdateMemberRole), ctx, actorRole, orgId, userId, role)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockOrganization) UpdateOrganization(org models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockOrganizationMockRecorder) UpdateOrganization(org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockOrganization)(nil).UpdateOrganization), org)
+}
+
+// MockDepartment is a mock of Department interface.
+type MockDepartment struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepartmentMockRecorder
+}
+
+// MockDepartmentMockRecorder is the mock recorder for MockDepartment.
+type MockDepartmentMockRecorder struct {
+	mock *MockDepartment
+}
+
+// NewMockDepartment creates a new mock instance.
+func NewMockDepartment(ctrl *gomock.Controller) *MockDepartment {
+	mock := &MockDepartment{ctrl: ctrl}
+	mock.recorder = &MockDepartmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepartment) EXPECT() *MockDepartmentMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockDepartment) AddMember(orgId int, member models.DepartmentMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", orgId, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockDepartmentMockRecorder) AddMember(orgId, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockDepartment)(nil).AddMember), orgId, member)
+}
+
+// CreateDepartment mocks base method.
+func (m *MockDepartment) CreateDepartment(department models.Department) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepartment", department)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDepartment indicates an expected call of CreateDepartment.
+func (mr *MockDepartmentMockRecorder) CreateDepartment(department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockDepartment)(nil).CreateDepartment), department)
+}
+
+// DeleteDepartment mocks base method.
+func (m *MockDepartment) DeleteDepartment(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockDepartmentMockRecorder) DeleteDepartment(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*MockDepartment)(nil).DeleteDepartment), orgId, id)
+}
+
+// GetDepartment mocks base method.
+func (m *MockDepartment) GetDepartment(orgId, id int) (models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", orgId, id)
+	ret0, _ := ret[0].(models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockDepartmentMockRecorder) GetDepartment(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockDepartment)(nil).GetDepartment), orgId, id)
+}
+
+// GetDepartments mocks base method.
+func (m *MockDepartment) GetDepartments(orgId int) (models.Departments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartments", orgId)
+	ret0, _ := ret[0].(models.Departments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartments indicates an expected call of GetDepartments.
+func (mr *MockDepartmentMockRecorder) GetDepartments(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartments", reflect.TypeOf((*MockDepartment)(nil).GetDepartments), orgId)
+}
+
+// GetMembers mocks base method.
+func (m *MockDepartment) GetMembers(orgId, id int) ([]models.DepartmentMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", orgId, id)
+	ret0, _ := ret[0].([]models.DepartmentMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockDepartmentMockRecorder) GetMembers(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockDepartment)(nil).GetMembers), orgId, id)
+}
+
+// GetProjects mocks base method.
+func (m *MockDepartment) GetProjects(orgId, id int) (models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", orgId, id)
+	ret0, _ := ret[0].(models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockDepartmentMockRecorder) GetProjects(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockDepartment)(nil).GetProjects), orgId, id)
+}
+
+// GetStats mocks base method.
+func (m *MockDepartment) GetStats(orgId, id int) (models.DepartmentStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", orgId, id)
+	ret0, _ := ret[0].(models.DepartmentStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockDepartmentMockRecorder) GetStats(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockDepartment)(nil).GetStats), orgId, id)
+}
+
+// RemoveMember mocks base method.
+func (m *MockDepartment) RemoveMember(orgId, id, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", orgId, id, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockDepartmentMockRecorder) RemoveMember(orgId, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockDepartment)(nil).RemoveMember), orgId, id, userId)
+}
+
+// UpdateDepartment mocks base method.
+func (m *MockDepartment) UpdateDepartment(department models.Department) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepartment", department)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDepartment indicates an expected call of UpdateDepartment.
+func (mr *MockDepartmentMockRecorder) UpdateDepartment(department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepartment", reflect.TypeOf((*MockDepartment)(nil).UpdateDepartment), department)
+}
+
+// MockTeam is a mock of Team interface.
+type MockTeam struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamMockRecorder
+}
+
+// MockTeamMockRecorder is the mock recorder for MockTeam.
+type MockTeamMockRecorder struct {
+	mock *MockTeam
+}
+
+// NewMockTeam creates a new mock instance.
+func NewMockTeam(ctrl *gomock.Controller) *MockTeam {
+	mock := &MockTeam{ctrl: ctrl}
+	mock.recorder = &MockTeamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeam) EXPECT() *MockTeamMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockTeam) AddMember(orgId int, member models.TeamMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", orgId, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockTeamMockRecorder) AddMember(orgId, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockTeam)(nil).AddMember), orgId, member)
+}
+
+// AddTeamToProject mocks base method.
+func (m *MockTeam) AddTeamToProject(ctx context.Context, orgId, managerId int, projectTeam models.ProjectTeam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeamToProject", ctx, orgId, managerId, projectTeam)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTeamToProject indicates an expected call of AddTeamToProject.
+func (mr *MockTeamMockRecorder) AddTeamToProject(ctx, orgId, managerId, projectTeam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamToProject", reflect.TypeOf((*MockTeam)(nil).AddTeamToProject), ctx, orgId, managerId, projectTeam)
+}
+
+// ClaimTask mocks base method.
+func (m *MockTeam) ClaimTask(ctx context.Context, orgId, userId, id, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTask", ctx, orgId, userId, id, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimTask indicates an expected call of ClaimTask.
+func (mr *MockTeamMockRecorder) ClaimTask(ctx, orgId, userId, id, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTask", reflect.TypeOf((*MockTeam)(nil).ClaimTask), ctx, orgId, userId, id, taskId)
+}
+
+// CreateTeam mocks base method.
+func (m *MockTeam) CreateTeam(team models.Team) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", team)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockTeamMockRecorder) CreateTeam(team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeam)(nil).CreateTeam), team)
+}
+
+// DeleteTeam mocks base method.
+func (m *MockTeam) DeleteTeam(orgId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", orgId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockTeamMockRecorder) DeleteTeam(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeam)(nil).DeleteTeam), orgId, id)
+}
+
+// GetMembers mocks base method.
+func (m *MockTeam) GetMembers(orgId, id int) ([]models.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", orgId, id)
+	ret0, _ := ret[0].([]models.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockTeamMockRecorder) GetMembers(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockTeam)(nil).GetMembers), orgId, id)
+}
+
+// GetProjectTeams mocks base method.
+func (m *MockTeam) GetProjectTeams(orgId, managerId, projectId int) ([]models.ProjectTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectTeams", orgId, managerId, projectId)
+	ret0, _ := ret[0].([]models.ProjectTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectTeams indicates an expected call of GetProjectTeams.
+func (mr *MockTeamMockRecorder) GetProjectTeams(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectTeams", reflect.TypeOf((*MockTeam)(nil).GetProjectTeams), orgId, managerId, projectId)
+}
+
+// GetQueue mocks base method.
+func (m *MockTeam) GetQueue(orgId, userId, id int, isAdmin bool) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueue", orgId, userId, id, isAdmin)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueue indicates an expected call of GetQueue.
+func (mr *MockTeamMockRecorder) GetQueue(orgId, userId, id, isAdmin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockTeam)(nil).GetQueue), orgId, userId, id, isAdmin)
+}
+
+// GetTeam mocks base method.
+func (m *MockTeam) GetTeam(orgId, id int) (models.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", orgId, id)
+	ret0, _ := ret[0].(models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockTeamMockRecorder) GetTeam(orgId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeam)(nil).GetTeam), orgId, id)
+}
+
+// GetTeams mocks base method.
+func (m *MockTeam) GetTeams(orgId int) (models.Teams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeams", orgId)
+	ret0, _ := ret[0].(models.Teams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeams indicates an expected call of GetTeams.
+func (mr *MockTeamMockRecorder) GetTeams(orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*MockTeam)(nil).GetTeams), orgId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockTeam) RemoveMember(orgId, id, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", orgId, id, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockTeamMockRecorder) RemoveMember(orgId, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockTeam)(nil).RemoveMember), orgId, id, userId)
+}
+
+// RemoveTeamFromProject mocks base method.
+func (m *MockTeam) RemoveTeamFromProject(ctx context.Context, orgId, managerId, projectId, teamId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamFromProject", ctx, orgId, managerId, projectId, teamId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeamFromProject indicates an expected call of RemoveTeamFromProject.
+func (mr *MockTeamMockRecorder) RemoveTeamFromProject(ctx, orgId, managerId, projectId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamFromProject", reflect.TypeOf((*MockTeam)(nil).RemoveTeamFromProject), ctx, orgId, managerId, projectId, teamId)
+}
+
+// UpdateTeam mocks base method.
+func (m *MockTeam) UpdateTeam(team models.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeam", team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTeam indicates an expected call of UpdateTeam.
+func (mr *MockTeamMockRecorder) UpdateTeam(team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockTeam)(nil).UpdateTeam), team)
+}
+
+// MockLabel is a mock of Label interface.
+type MockLabel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelMockRecorder
+}
+
+// MockLabelMockRecorder is the mock recorder for MockLabel.
+type MockLabelMockRecorder struct {
+	mock *MockLabel
+}
+
+// NewMockLabel creates a new mock instance.
+func NewMockLabel(ctrl *gomock.Controller) *MockLabel {
+	mock := &MockLabel{ctrl: ctrl}
+	mock.recorder = &MockLabelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabel) EXPECT() *MockLabelMockRecorder {
+	return m.recorder
+}
+
+// CreateLabel mocks base method.
+func (m *MockLabel) CreateLabel(orgId, managerId int, label models.Label) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", orgId, managerId, label)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockLabelMockRecorder) CreateLabel(orgId, managerId, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockLabel)(nil).CreateLabel), orgId, managerId, label)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockLabel) DeleteLabel(orgId, managerId, projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockLabelMockRecorder) DeleteLabel(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockLabel)(nil).DeleteLabel), orgId, managerId, projectId, id)
+}
+
+// GetLabels mocks base method.
+func (m *MockLabel) GetLabels(orgId, managerId, projectId int) (models.Labels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabels", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.Labels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabels indicates an expected call of GetLabels.
+func (mr *MockLabelMockRecorder) GetLabels(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabels", reflect.TypeOf((*MockLabel)(nil).GetLabels), orgId, managerId, projectId)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockLabel) UpdateLabel(orgId, managerId int, label models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", orgId, managerId, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockLabelMockRecorder) UpdateLabel(orgId, managerId, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockLabel)(nil).UpdateLabel), orgId, managerId, label)
+}
+
+// MockCustomField is a mock of CustomField interface.
+type MockCustomField struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldMockRecorder
+}
+
+// MockCustomFieldMockRecorder is the mock recorder for MockCustomField.
+type MockCustomFieldMockRecorder struct {
+	mock *MockCustomField
+}
+
+// NewMockCustomField creates a new mock instance.
+func NewMockCustomField(ctrl *gomock.Controller) *MockCustomField {
+	mock := &MockCustomField{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomField) EXPECT() *MockCustomFieldMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomField mocks base method.
+func (m *MockCustomField) CreateCustomField(orgId, managerId int, field models.CustomField) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", orgId, managerId, field)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockCustomFieldMockRecorder) CreateCustomField(orgId, managerId, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockCustomField)(nil).CreateCustomField), orgId, managerId, field)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockCustomField) DeleteCustomField(orgId, managerId, projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockCustomFieldMockRecorder) DeleteCustomField(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockCustomField)(nil).DeleteCustomField), orgId, managerId, projectId, id)
+}
+
+// GetCustomFields mocks base method.
+func (m *MockCustomField) GetCustomFields(orgId, managerId, projectId int) (models.CustomFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFields", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.CustomFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFields indicates an expected call of GetCustomFields.
+func (mr *MockCustomFieldMockRecorder) GetCustomFields(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFields", reflect.TypeOf((*MockCustomField)(nil).GetCustomFields), orgId, managerId, projectId)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockCustomField) UpdateCustomField(orgId, managerId int, field models.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", orgId, managerId, field)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockCustomFieldMockRecorder) UpdateCustomField(orgId, managerId, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockCustomField)(nil).UpdateCustomField), orgId, managerId, field)
+}
+
+// MockBoard is a mock of Board interface.
+type MockBoard struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMockRecorder
+}
+
+// MockBoardMockRecorder is the mock recorder for MockBoard.
+type MockBoardMockRecorder struct {
+	mock *MockBoard
+}
+
+// NewMockBoard creates a new mock instance.
+func NewMockBoard(ctrl *gomock.Controller) *MockBoard {
+	mock := &MockBoard{ctrl: ctrl}
+	mock.recorder = &MockBoardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoard) EXPECT() *MockBoardMockRecorder {
+	return m.recorder
+}
+
+// CreateColumn mocks base method.
+func (m *MockBoard) CreateColumn(orgId, managerId int, column models.BoardColumn) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateColumn", orgId, managerId, column)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateColumn indicates an expected call of CreateColumn.
+func (mr *MockBoardMockRecorder) CreateColumn(orgId, managerId, column interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockBoard)(nil).CreateColumn), orgId, managerId, column)
+}
+
+// DeleteColumn mocks base method.
+func (m *MockBoard) DeleteColumn(orgId, managerId, projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteColumn", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteColumn indicates an expected call of DeleteColumn.
+func (mr *MockBoardMockRecorder) DeleteColumn(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockBoard)(nil).DeleteColumn), orgId, managerId, projectId, id)
+}
+
+// GetBoard mocks base method.
+func (m *MockBoard) GetBoard(orgId, managerId, projectId int) (models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockBoardMockRecorder) GetBoard(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockBoard)(nil).GetBoard), orgId, managerId, projectId)
+}
+
+// MoveTask mocks base method.
+func (m *MockBoard) MoveTask(ctx context.Context, orgId, managerId int, move models.TaskMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, orgId, managerId, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockBoardMockRecorder) MoveTask(ctx, orgId, managerId, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockBoard)(nil).MoveTask), ctx, orgId, managerId, move)
+}
+
+// UpdateColumn mocks base method.
+func (m *MockBoard) UpdateColumn(orgId, managerId int, column models.BoardColumn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumn", orgId, managerId, column)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateColumn indicates an expected call of UpdateColumn.
+func (mr *MockBoardMockRecorder) UpdateColumn(orgId, managerId, column interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumn", reflect.TypeOf((*MockBoard)(nil).UpdateColumn), orgId, managerId, column)
+}
+
+// MockSprint is a mock of Sprint interface.
+type MockSprint struct {
+	ctrl     *gomock.Controller
+	recorder *MockSprintMockRecorder
+}
+
+// MockSprintMockRecorder is the mock recorder for MockSprint.
+type MockSprintMockRecorder struct {
+	mock *MockSprint
+}
+
+// NewMockSprint creates a new mock instance.
+func NewMockSprint(ctrl *gomock.Controller) *MockSprint {
+	mock := &MockSprint{ctrl: ctrl}
+	mock.recorder = &MockSprintMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSprint) EXPECT() *MockSprintMockRecorder {
+	return m.recorder
+}
+
+// AddTasks mocks base method.
+func (m *MockSprint) AddTasks(ctx context.Context, orgId, managerId, projectId, id int, taskIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTasks", ctx, orgId, managerId, projectId, id, taskIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTasks indicates an expected call of AddTasks.
+func (mr *MockSprintMockRecorder) AddTasks(ctx, orgId, managerId, projectId, id, taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockSprint)(nil).AddTasks), ctx, orgId, managerId, projectId, id, taskIds)
+}
+
+// CompleteSprint mocks base method.
+func (m *MockSprint) CompleteSprint(ctx context.Context, orgId, managerId, projectId, id int, nextId *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSprint", ctx, orgId, managerId, projectId, id, nextId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteSprint indicates an expected call of CompleteSprint.
+func (mr *MockSprintMockRecorder) CompleteSprint(ctx, orgId, managerId, projectId, id, nextId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSprint", reflect.TypeOf((*MockSprint)(nil).CompleteSprint), ctx, orgId, managerId, projectId, id, nextId)
+}
+
+// CreateSprint mocks base method.
+func (m *MockSprint) CreateSprint(orgId, managerId int, sprint models.Sprint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSprint", orgId, managerId, sprint)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSprint indicates an expected call of CreateSprint.
+func (mr *MockSprintMockRecorder) CreateSprint(orgId, managerId, sprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSprint", reflect.TypeOf((*MockSprint)(nil).CreateSprint), orgId, managerId, sprint)
+}
+
+// DeleteSprint mocks base method.
+func (m *MockSprint) DeleteSprint(orgId, managerId, projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSprint", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSprint indicates an expected call of DeleteSprint.
+func (mr *MockSprintMockRecorder) DeleteSprint(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSprint", reflect.TypeOf((*MockSprint)(nil).DeleteSprint), orgId, managerId, projectId, id)
+}
+
+// GetBacklog mocks base method.
+func (m *MockSprint) GetBacklog(orgId, managerId, projectId int) (models.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklog", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklog indicates an expected call of GetBacklog.
+func (mr *MockSprintMockRecorder) GetBacklog(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklog", reflect.TypeOf((*MockSprint)(nil).GetBacklog), orgId, managerId, projectId)
+}
+
+// GetSprint mocks base method.
+func (m *MockSprint) GetSprint(orgId, managerId, projectId, id int) (models.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprint", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(models.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprint indicates an expected call of GetSprint.
+func (mr *MockSprintMockRecorder) GetSprint(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprint", reflect.TypeOf((*MockSprint)(nil).GetSprint), orgId, managerId, projectId, id)
+}
+
+// GetSprints mocks base method.
+func (m *MockSprint) GetSprints(orgId, managerId, projectId int) (models.Sprints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprints", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.Sprints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprints indicates an expected call of GetSprints.
+func (mr *MockSprintMockRecorder) GetSprints(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprints", reflect.TypeOf((*MockSprint)(nil).GetSprints), orgId, managerId, projectId)
+}
+
+// GetVelocity mocks base method.
+func (m *MockSprint) GetVelocity(orgId, managerId, projectId int) (models.Velocity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVelocity", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.Velocity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVelocity indicates an expected call of GetVelocity.
+func (mr *MockSprintMockRecorder) GetVelocity(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVelocity", reflect.TypeOf((*MockSprint)(nil).GetVelocity), orgId, managerId, projectId)
+}
+
+// RemoveTask mocks base method.
+func (m *MockSprint) RemoveTask(ctx context.Context, orgId, managerId, projectId, id, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTask", ctx, orgId, managerId, projectId, id, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTask indicates an expected call of RemoveTask.
+func (mr *MockSprintMockRecorder) RemoveTask(ctx, orgId, managerId, projectId, id, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTask", reflect.TypeOf((*MockSprint)(nil).RemoveTask), ctx, orgId, managerId, projectId, id, taskId)
+}
+
+// StartSprint mocks base method.
+func (m *MockSprint) StartSprint(orgId, managerId, projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSprint", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartSprint indicates an expected call of StartSprint.
+func (mr *MockSprintMockRecorder) StartSprint(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSprint", reflect.TypeOf((*MockSprint)(nil).StartSprint), orgId, managerId, projectId, id)
+}
+
+// UpdateSprint mocks base method.
+func (m *MockSprint) UpdateSprint(orgId, managerId int, sprint models.Sprint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSprint", orgId, managerId, sprint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSprint indicates an expected call of UpdateSprint.
+func (mr *MockSprintMockRecorder) UpdateSprint(orgId, managerId, sprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSprint", reflect.TypeOf((*MockSprint)(nil).UpdateSprint), orgId, managerId, sprint)
+}
+
+// MockMilestone is a mock of Milestone interface.
+type MockMilestone struct {
+	ctrl     *gomock.Controller
+	recorder *MockMilestoneMockRecorder
+}
+
+// MockMilestoneMockRecorder is the mock recorder for MockMilestone.
+type MockMilestoneMockRecorder struct {
+	mock *MockMilestone
+}
+
+// NewMockMilestone creates a new mock instance.
+func NewMockMilestone(ctrl *gomock.Controller) *MockMilestone {
+	mock := &MockMilestone{ctrl: ctrl}
+	mock.recorder = &MockMilestoneMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMilestone) EXPECT() *MockMilestoneMockRecorder {
+	return m.recorder
+}
+
+// AddTasks mocks base method.
+func (m *MockMilestone) AddTasks(ctx context.Context, orgId, managerId, projectId, id int, taskIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTasks", ctx, orgId, managerId, projectId, id, taskIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTasks indicates an expected call of AddTasks.
+func (mr *MockMilestoneMockRecorder) AddTasks(ctx, orgId, managerId, projectId, id, taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockMilestone)(nil).AddTasks), ctx, orgId, managerId, projectId, id, taskIds)
+}
+
+// CreateMilestone mocks base method.
+func (m *MockMilestone) CreateMilestone(orgId, managerId int, milestone models.Milestone) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMilestone", orgId, managerId, milestone)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMilestone indicates an expected call of CreateMilestone.
+func (mr *MockMilestoneMockRecorder) CreateMilestone(orgId, managerId, milestone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMilestone", reflect.TypeOf((*MockMilestone)(nil).CreateMilestone), orgId, managerId, milestone)
+}
+
+// DeleteMilestone mocks base method.
+func (m *MockMilestone) DeleteMilestone(orgId, managerId, projectId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMilestone", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMilestone indicates an expected call of DeleteMilestone.
+func (mr *MockMilestoneMockRecorder) DeleteMilestone(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMilestone", reflect.TypeOf((*MockMilestone)(nil).DeleteMilestone), orgId, managerId, projectId, id)
+}
+
+// GetMilestone mocks base method.
+func (m *MockMilestone) GetMilestone(orgId, managerId, projectId, id int) (models.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestone", orgId, managerId, projectId, id)
+	ret0, _ := ret[0].(models.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestone indicates an expected call of GetMilestone.
+func (mr *MockMilestoneMockRecorder) GetMilestone(orgId, managerId, projectId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestone", reflect.TypeOf((*MockMilestone)(nil).GetMilestone), orgId, managerId, projectId, id)
+}
+
+// GetMilestones mocks base method.
+func (m *MockMilestone) GetMilestones(orgId, managerId, projectId int) (models.Milestones, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestones", orgId, managerId, projectId)
+	ret0, _ := ret[0].(models.Milestones)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestones indicates an expected call of GetMilestones.
+func (mr *MockMilestoneMockRecorder) GetMilestones(orgId, managerId, projectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestones", reflect.TypeOf((*MockMilestone)(nil).GetMilestones), orgId, managerId, projectId)
+}
+
+// RemoveTask mocks base method.
+func (m *MockMilestone) RemoveTask(ctx context.Context, orgId, managerId, projectId, id, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTask", ctx, orgId, managerId, projectId, id, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTask indicates an expected call of RemoveTask.
+func (mr *MockMilestoneMockRecorder) RemoveTask(ctx, orgId, managerId, projectId, id, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTask", reflect.TypeOf((*MockMilestone)(nil).RemoveTask), ctx, orgId, managerId, projectId, id, taskId)
+}
+
+// UpdateMilestone mocks base method.
+func (m *MockMilestone) UpdateMilestone(orgId, managerId int, milestone models.Milestone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMilestone", orgId, managerId, milestone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMilestone indicates an expected call of UpdateMilestone.
+func (mr *MockMilestoneMockRecorder) UpdateMilestone(orgId, managerId, milestone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMilestone", reflect.TypeOf((*MockMilestone)(nil).UpdateMilestone), orgId, managerId, milestone)
+}
+
+// MockWorklog is a mock of Worklog interface.
+type MockWorklog struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorklogMockRecorder
+}
+
+// MockWorklogMockRecorder is the mock recorder for MockWorklog.
+type MockWorklogMockRecorder struct {
+	mock *MockWorklog
+}
+
+// NewMockWorklog creates a new mock instance.
+func NewMockWorklog(ctrl *gomock.Controller) *MockWorklog {
+	mock := &MockWorklog{ctrl: ctrl}
+	mock.recorder = &MockWorklogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorklog) EXPECT() *MockWorklogMockRecorder {
+	return m.recorder
+}
+
+// DeleteWorklog mocks base method.
+func (m *MockWorklog) DeleteWorklog(ctx context.Context, orgId, userId, taskId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorklog", ctx, orgId, userId, taskId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorklog indicates an expected call of DeleteWorklog.
+func (mr *MockWorklogMockRecorder) DeleteWorklog(ctx, orgId, userId, taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorklog", reflect.TypeOf((*MockWorklog)(nil).DeleteWorklog), ctx, orgId, userId, taskId, id)
+}
+
+// GetRunningTimer mocks base method.
+func (m *MockWorklog) GetRunningTimer(userId int) (models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", userId)
+	ret0, _ := ret[0].(models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockWorklogMockRecorder) GetRunningTimer(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockWorklog)(nil).GetRunningTimer), userId)
+}
+
+// GetTimesheet mocks base method.
+func (m *MockWorklog) GetTimesheet(filter models.TimesheetFilter) (models.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimesheet", filter)
+	ret0, _ := ret[0].(models.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimesheet indicates an expected call of GetTimesheet.
+func (mr *MockWorklogMockRecorder) GetTimesheet(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimesheet", reflect.TypeOf((*MockWorklog)(nil).GetTimesheet), filter)
+}
+
+// GetWorklogs mocks base method.
+func (m *MockWorklog) GetWorklogs(orgId, userId, taskId int) (models.Worklogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogs", orgId, userId, taskId)
+	ret0, _ := ret[0].(models.Worklogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogs indicates an expected call of GetWorklogs.
+func (mr *MockWorklogMockRecorder) GetWorklogs(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogs", reflect.TypeOf((*MockWorklog)(nil).GetWorklogs), orgId, userId, taskId)
+}
+
+// LogWork mocks base method.
+func (m *MockWorklog) LogWork(ctx context.Context, orgId, userId int, worklog models.Worklog) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogWork", ctx, orgId, userId, worklog)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogWork indicates an expected call of LogWork.
+func (mr *MockWorklogMockRecorder) LogWork(ctx, orgId, userId, worklog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogWork", reflect.TypeOf((*MockWorklog)(nil).LogWork), ctx, orgId, userId, worklog)
+}
+
+// StartTimer mocks base method.
+func (m *MockWorklog) StartTimer(orgId, userId, taskId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", orgId, userId, taskId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockWorklogMockRecorder) StartTimer(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockWorklog)(nil).StartTimer), orgId, userId, taskId)
+}
+
+// StopTimer mocks base method.
+func (m *MockWorklog) StopTimer(ctx context.Context, userId int) (models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, userId)
+	ret0, _ := ret[0].(models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockWorklogMockRecorder) StopTimer(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockWorklog)(nil).StopTimer), ctx, userId)
+}
+
+// MockRecurrence is a mock of Recurrence interface.
+type MockRecurrence struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurrenceMockRecorder
+}
+
+// MockRecurrenceMockRecorder is the mock recorder for MockRecurrence.
+type MockRecurrenceMockRecorder struct {
+	mock *MockRecurrence
+}
+
+// NewMockRecurrence creates a new mock instance.
+func NewMockRecurrence(ctrl *gomock.Controller) *MockRecurrence {
+	mock := &MockRecurrence{ctrl: ctrl}
+	mock.recorder = &MockRecurrenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurrence) EXPECT() *MockRecurrenceMockRecorder {
+	return m.recorder
+}
+
+// GenerateDue mocks base method.
+func (m *MockRecurrence) GenerateDue(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDue", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDue indicates an expected call of GenerateDue.
+func (mr *MockRecurrenceMockRecorder) GenerateDue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDue", reflect.TypeOf((*MockRecurrence)(nil).GenerateDue), now)
+}
+
+// GetRecurrence mocks base method.
+func (m *MockRecurrence) GetRecurrence(orgId, userId, taskId int) (models.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", orgId, userId, taskId)
+	ret0, _ := ret[0].(models.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *MockRecurrenceMockRecorder) GetRecurrence(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*MockRecurrence)(nil).GetRecurrence), orgId, userId, taskId)
+}
+
+// Run mocks base method.
+func (m *MockRecurrence) Run(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockRecurrenceMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRecurrence)(nil).Run), ctx, interval)
+}
+
+// SetRecurrence mocks base method.
+func (m *MockRecurrence) SetRecurrence(ctx context.Context, orgId, userId, taskId int, recurrence models.Recurrence) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurrence", ctx, orgId, userId, taskId, recurrence)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecurrence indicates an expected call of SetRecurrence.
+func (mr *MockRecurrenceMockRecorder) SetRecurrence(ctx, orgId, userId, taskId, recurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurrence", reflect.TypeOf((*MockRecurrence)(nil).SetRecurrence), ctx, orgId, userId, taskId, recurrence)
+}
+
+// StopRecurrence mocks base method.
+func (m *MockRecurrence) StopRecurrence(orgId, userId, taskId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRecurrence", orgId, userId, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopRecurrence indicates an expected call of StopRecurrence.
+func (mr *MockRecurrenceMockRecorder) StopRecurrence(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRecurrence", reflect.TypeOf((*MockRecurrence)(nil).StopRecurrence), orgId, userId, taskId)
+}
+
+// MockChecklist is a mock of Checklist interface.
+type MockChecklist struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistMockRecorder
+}
+
+// MockChecklistMockRecorder is the mock recorder for MockChecklist.
+type MockChecklistMockRecorder struct {
+	mock *MockChecklist
+}
+
+// NewMockChecklist creates a new mock instance.
+func NewMockChecklist(ctrl *gomock.Controller) *MockChecklist {
+	mock := &MockChecklist{ctrl: ctrl}
+	mock.recorder = &MockChecklistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklist) EXPECT() *MockChecklistMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockChecklist) CreateItem(ctx context.Context, orgId, userId int, item models.ChecklistItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", ctx, orgId, userId, item)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockChecklistMockRecorder) CreateItem(ctx, orgId, userId, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockChecklist)(nil).CreateItem), ctx, orgId, userId, item)
+}
+
+// DeleteItem mocks base method.
+func (m *MockChecklist) DeleteItem(ctx context.Context, orgId, userId, taskId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, orgId, userId, taskId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockChecklistMockRecorder) DeleteItem(ctx, orgId, userId, taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockChecklist)(nil).DeleteItem), ctx, orgId, userId, taskId, id)
+}
+
+// GetItems mocks base method.
+func (m *MockChecklist) GetItems(orgId, userId, taskId int) (models.ChecklistItems, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", orgId, userId, taskId)
+	ret0, _ := ret[0].(models.ChecklistItems)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockChecklistMockRecorder) GetItems(orgId, userId, taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockChecklist)(nil).GetItems), orgId, userId, taskId)
+}
+
+// MoveItem mocks base method.
+func (m *MockChecklist) MoveItem(ctx context.Context, orgId, userId int, move models.ChecklistMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", ctx, orgId, userId, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockChecklistMockRecorder) MoveItem(ctx, orgId, userId, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockChecklist)(nil).MoveItem), ctx, orgId, userId, move)
+}
+
+// UpdateItem mocks base method.
+func (m *MockChecklist) UpdateItem(ctx context.Context, orgId, userId, taskId, id int, title *string, isChecked *bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, orgId, userId, taskId, id, title, isChecked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockChecklistMockRecorder) UpdateItem(ctx, orgId, userId, taskId, id, title, isChecked interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockChecklist)(nil).UpdateItem), ctx, orgId, userId, taskId, id, title, isChecked)
 }
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/configs"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/mailer"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// notificationMessage puts the notification into words for the user it is
+// for, like "Ali assigned you to 'Deploy API'".
+func notificationMessage(notification models.Notification) string {
+	text := func(key string) string {
+		if value, ok := notification.Data[key].(string); ok {
+			return value
+		}
+		return ""
+	}
+
+	actor, title := notification.ActorName, text("title")
+
+	switch notification.Type {
+	case models.NotificationAssigned:
+		if role := text("role"); role != "" && role != models.TaskRoleAssignee {
+			return fmt.Sprintf("%s made you a %s of '%s'", actor, role, title)
+		}
+		return fmt.Sprintf("%s assigned you to '%s'", actor, title)
+	case models.NotificationStatusChanged:
+		return fmt.Sprintf("%s moved '%s' to %s", actor, title, text("to"))
+	case models.NotificationCommented:
+		return fmt.Sprintf("%s commented on '%s'", actor, title)
+	case models.NotificationMentioned:
+		return fmt.Sprintf("%s mentioned you in a comment on '%s'", actor, title)
+	case models.NotificationDeadline:
+		if title == "" {
+			return fmt.Sprintf("The project %s is due on %s", text("project"), text("deadline"))
+		}
+		return fmt.Sprintf("'%s' is due on %s", title, text("deadline"))
+	case models.NotificationOverdue:
+		if title == "" {
+			return fmt.Sprintf("The project %s is overdue since %s", text("project"), text("deadline"))
+		}
+		return fmt.Sprintf("'%s' is overdue since %s", title, text("deadline"))
+	case models.NotificationInvited:
+		return fmt.Sprintf("%s invited you to the project %s", actor, text("project"))
+	}
+
+	return title
+}
+
+// notificationTypes are the types of notifications users can set email
+// preferences for.
+var notificationTypes = []string{
+	models.NotificationAssigned,
+	models.NotificationStatusChanged,
+	models.NotificationCommented,
+	models.NotificationMentioned,
+	models.NotificationDeadline,
+	models.NotificationOverdue,
+	models.NotificationInvited,
+}
+
+var emailModes = map[string]bool{
+	models.EmailInstant: true,
+	models.EmailDigest:  true,
+	models.EmailOff:     true,
+}
+
+const (
+	// instantEmailWindow is how long an instant email that failed to send is
+	// retried. Older ones are given up on.
+	instantEmailWindow = 24 * time.Hour
+	// emailLease is how long the emails a sender claims are kept from the
+	// others. A failed email is tried again once its lease runs out.
+	emailLease = 10 * time.Minute
+	// instantEmailBatch is how many instant emails are sent at every run.
+	instantEmailBatch = 100
+	// emailSendWindow is how long after a run starts it still begins
+	// sending, so that the last email is sent before the lease runs out and
+	// another sender claims it again.
+	emailSendWindow = emailLease - mailer.SendTimeout
+)
+
+type NotificationService struct {
+	repo       repository.Notification
+	mailer     mailer.Mailer
+	digestHour int
+	sendWindow time.Duration
+}
+
+func NewNotificationService(repo repository.Notification, mailer mailer.Mailer,
+	cfg configs.EmailConfig) *NotificationService {
+	return &NotificationService{repo: repo, mailer: mailer, digestHour: cfg.DigestHour, sendWindow: emailSendWindow}
+}
+
+// digestCutoff returns the latest time digests were due by now: today at
+// the digest hour once it has passed, yesterday's otherwise.
+func digestCutoff(now time.Time, hour int) time.Time {
+	now = now.UTC()
+	cutoff := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	if cutoff.After(now) {
+		cutoff = cutoff.AddDate(0, 0, -1)
+	}
+
+	return cutoff
+}
+
+type digest struct {
+	to   string
+	data mailer.DigestData
+	ids  []int
+}
+
+// digests gathers the pending emails, which come ordered by user, into one
+// digest per user.
+func digests(pending []models.PendingEmail) []digest {
+	var result []digest
+	for _, email := range pending {
+		if len(result) == 0 || result[len(result)-1].to != email.Email {
+			result = append(result, digest{to: email.Email, data: mailer.DigestData{Name: email.Firstname}})
+		}
+
+		last := &result[len(result)-1]
+		last.data.Messages = append(last.data.Messages, notificationMessage(email.Notification))
+		last.ids = append(last.ids, email.ID)
+	}
+
+	return result
+}
+
+func (n *NotificationService) GetNotifications(filter models.NotificationFilter) (models.NotificationPage, error) {
+	filter.Limit = pageLimit(filter.Limit)
+
+	notifications, err := n.repo.GetNotifications(filter)
+	if err != nil {
+		log.Println("failed to get the notifications. Error is: ", err.Error())
+		return models.NotificationPage{}, err
+	}
+
+	unread, err := n.repo.CountUnread(filter.UserId)
+	if err != nil {
+		log.Println("failed to count the unread notifications. Error is: ", err.Error())
+		return models.NotificationPage{}, err
+	}
+
+	for i := range notifications {
+		notifications[i].Message = notificationMessage(notifications[i])
+	}
+
+	page := models.NotificationPage{Notifications: notifications, Unread: unread}
+	if len(notifications) == filter.Limit {
+		page.NextBeforeId = &notifications[len(notifications)-1].ID
+	}
+
+	return page, nil
+}
+
+func (n *NotificationService) CountUnread(userId int) (int64, error) {
+	count, err := n.repo.CountUnread(userId)
+	if err != nil {
+		log.Println("failed to count the unread notifications. Error is: ", err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (n *NotificationService) MarkRead(userId, id int) error {
+	err := n.repo.MarkRead(userId, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("notification doesn't exist")
+	}
+	if err != nil {
+		log.Println("failed to mark the notification as read. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (n *NotificationService) MarkAllRead(userId int) (int64, error) {
+	count, err := n.repo.MarkAllRead(userId)
+	if err != nil {
+		log.Println("failed to mark the notifications as read. Error is: ", err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetPreferences returns how the user is emailed about every type of
+// notification.
+func (n *NotificationService) GetPreferences(userId int) ([]models.NotificationPreference, error) {
+	saved, err := n.repo.GetPreferences(userId)
+	if err != nil {
+		log.Println("failed to get the notification preferences. Error is: ", err.Error())
+		return nil, err
+	}
+
+	modes := make(map[string]string, len(saved))
+	for _, preference := range saved {
+		modes[preference.Type] = preference.Email
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(notificationTypes))
+	for _, notificationType := range notificationTypes {
+		mode, ok := modes[notificationType]
+		if !ok {
+			mode = models.EmailInstant
+		}
+		preferences = append(preferences, models.NotificationPreference{Type: notificationType, Email: mode})
+	}
+
+	return preferences, nil
+}
+
+// SetPreferences sets how the user is emailed about the given types of
+// notifications; the other types are left as they are.
+func (n *NotificationService) SetPreferences(userId int, modes map[string]string) error {
+	known := make(map[string]bool, len(notificationTypes))
+	for _, notificationType := range notificationTypes {
+		known[notificationType] = true
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(modes))
+	for notificationType, mode := range modes {
+		if !known[notificationType] {
+			return fmt.Errorf("unknown notification type %s", notificationType)
+		}
+
+		if !emailModes[mode] {
+			return fmt.Errorf("invalid email mode %s", mode)
+		}
+
+		preferences = append(preferences, models.NotificationPreference{UserId: userId, Type: notificationType,
+			Email: mode})
+	}
+
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	if err := n.repo.SetPreferences(preferences); err != nil {
+		log.Println("failed to set the notification preferences. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// SendEmails emails the new notifications users want one email each for,
+// and, once the digest hour has passed, the daily digests. Every email is
+// claimed first, so that it is sent once however many instances run, and
+// marked as emailed only once it is sent, so failed ones are tried again
+// when their claim runs out. Instant ones are given up on after
+// instantEmailWindow. The run stops sending at the end of its send window;
+// the emails it hasn't sent are claimed again when their lease runs out.
+func (n *NotificationService) SendEmails(now time.Time) error {
+	start := time.Now()
+	closed := func() bool {
+		if time.Since(start) < n.sendWindow {
+			return false
+		}
+
+		log.Println("the email send window has closed, the rest of the emails are left for later")
+		return true
+	}
+
+	if _, err := n.repo.SkipMutedEmails(); err != nil {
+		log.Println("failed to skip the muted emails. Error is: ", err.Error())
+		return err
+	}
+
+	if _, err := n.repo.ExpireInstantEmails(now.Add(-instantEmailWindow)); err != nil {
+		log.Println("failed to expire the old emails. Error is: ", err.Error())
+		return err
+	}
+
+	instant, err := n.repo.ClaimEmails(models.EmailInstant, now.Add(-instantEmailWindow), now, now, emailLease,
+		instantEmailBatch)
+	if err != nil {
+		log.Println("failed to get the pending emails. Error is: ", err.Error())
+		return err
+	}
+
+	for _, email := range instant {
+		if closed() {
+			return nil
+		}
+
+		message := notificationMessage(email.Notification)
+		msg, err := mailer.Render("notification", []string{email.Email}, message,
+			mailer.NotificationData{Name: email.Firstname, Message: message})
+		if err == nil {
+			err = n.mailer.Send(msg)
+		}
+		if err != nil {
+			log.Println("failed to email the notification. Error is: ", err.Error())
+			continue
+		}
+
+		if err := n.repo.SetEmailStatus([]int{email.ID}, models.EmailSent); err != nil {
+			log.Println("failed to mark the notification as emailed. Error is: ", err.Error())
+		}
+	}
+
+	if closed() {
+		return nil
+	}
+
+	pending, err := n.repo.ClaimEmails(models.EmailDigest, time.Time{}, digestCutoff(now, n.digestHour), now,
+		emailLease, 0)
+	if err != nil {
+		log.Println("failed to get the pending digests. Error is: ", err.Error())
+		return err
+	}
+
+	for _, d := range digests(pending) {
+		if closed() {
+			return nil
+		}
+
+		msg, err := mailer.Render("digest", []string{d.to},
+			fmt.Sprintf("Your daily digest: %d notifications", len(d.ids)), d.data)
+		if err == nil {
+			err = n.mailer.Send(msg)
+		}
+		if err != nil {
+			log.Println("failed to email the digest. Error is: ", err.Error())
+			continue
+		}
+
+		if err := n.repo.SetEmailStatus(d.ids, models.EmailSent); err != nil {
+			log.Println("failed to mark the digest as emailed. Error is: ", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Run sends the emails every interval until ctx is done.
+func (n *NotificationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			_ = n.SendEmails(now)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/configs"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/mailer"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNotificationMessage(t *testing.T) {
+	testTable := []struct {
+		name         string
+		notification models.Notification
+		expected     string
+	}{
+		{
+			name: "assigned",
+			notification: models.Notification{Type: models.NotificationAssigned, ActorName: "Ali",
+				Data: models.JSONMap{"title": "Deploy API"}},
+			expected: "Ali assigned you to 'Deploy API'",
+		},
+		{
+			name: "watcher",
+			notification: models.Notification{Type: models.NotificationAssigned, ActorName: "Ali",
+				Data: models.JSONMap{"title": "Deploy API", "role": models.TaskRoleWatcher}},
+			expected: "Ali made you a watcher of 'Deploy API'",
+		},
+		{
+			name: "mentioned",
+			notification: models.Notification{Type: models.NotificationMentioned, ActorName: "Ali",
+				Data: models.JSONMap{"title": "Deploy API", "comment_id": float64(4)}},
+			expected: "Ali mentioned you in a comment on 'Deploy API'",
+		},
+		{
+			name: "deadline",
+			notification: models.Notification{Type: models.NotificationDeadline,
+				Data: models.JSONMap{"title": "Deploy API", "deadline": "2024-03-04 17:00"}},
+			expected: "'Deploy API' is due on 2024-03-04 17:00",
+		},
+		{
+			name: "project deadline",
+			notification: models.Notification{Type: models.NotificationDeadline,
+				Data: models.JSONMap{"project": "Backend", "deadline": "2024-03-04 17:00"}},
+			expected: "The project Backend is due on 2024-03-04 17:00",
+		},
+		{
+			name: "overdue",
+			notification: models.Notification{Type: models.NotificationOverdue,
+				Data: models.JSONMap{"title": "Deploy API", "project": "Backend", "deadline": "2024-03-04 17:00"}},
+			expected: "'Deploy API' is overdue since 2024-03-04 17:00",
+		},
+		{
+			name: "invited",
+			notification: models.Notification{Type: models.NotificationInvited, ActorName: "Ali",
+				Data: models.JSONMap{"project": "Backend", "role": "participant"}},
+			expected: "Ali invited you to the project Backend",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, notificationMessage(test.notification))
+		})
+	}
+}
+
+func TestWithout(t *testing.T) {
+	assert.Equal(t, []int{1, 3}, without([]int{1, 2, 3, 4}, 2, 4))
+	assert.Equal(t, []int{}, without([]int{2}, 2))
+	assert.Equal(t, []int{5, 6}, without([]int{5, 6}))
+}
+
+func TestDigestCutoff(t *testing.T) {
+	testTable := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "before the digest hour",
+			now:      time.Date(2024, 3, 1, 7, 59, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "at the digest hour",
+			now:      time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "past the hour locally but not in UTC",
+			now:      time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60)),
+			expected: time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, digestCutoff(test.now, 8))
+		})
+	}
+}
+
+func TestDigests(t *testing.T) {
+	pending := []models.PendingEmail{
+		{Notification: models.Notification{ID: 1, Type: models.NotificationCommented, ActorName: "Vali",
+			Data: models.JSONMap{"title": "Deploy API"}}, Email: "ali@example.com", Firstname: "Ali"},
+		{Notification: models.Notification{ID: 4, Type: models.NotificationStatusChanged, ActorName: "Vali",
+			Data: models.JSONMap{"title": "Deploy API", "to": "Review"}}, Email: "ali@example.com", Firstname: "Ali"},
+		{Notification: models.Notification{ID: 2, Type: models.NotificationDeadline,
+			Data: models.JSONMap{"title": "Write docs", "deadline": "2024-03-02 10:00"}}, Email: "vali@example.com",
+			Firstname: "Vali"},
+	}
+
+	result := digests(pending)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, "ali@example.com", result[0].to)
+	assert.Equal(t, []int{1, 4}, result[0].ids)
+	assert.Equal(t, "Ali", result[0].data.Name)
+	assert.Equal(t, []string{"Vali commented on 'Deploy API'", "Vali moved 'Deploy API' to Review"},
+		result[0].data.Messages)
+
+	assert.Equal(t, "vali@example.com", result[1].to)
+	assert.Equal(t, []int{2}, result[1].ids)
+	assert.Equal(t, []string{"'Write docs' is due on 2024-03-02 10:00"}, result[1].data.Messages)
+}
+
+// testMailer records the messages it is given and fails to send to failTo.
+type testMailer struct {
+	failTo string
+	sent   []string
+}
+
+func (m *testMailer) Send(msg mailer.Message) error {
+	if msg.To[0] == m.failTo {
+		return errors.New("mailbox unavailable")
+	}
+
+	m.sent = append(m.sent, msg.To[0])
+	return nil
+}
+
+func TestNotificationService_SendEmails(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	instant := []models.PendingEmail{
+		{Notification: models.Notification{ID: 1, Type: models.NotificationCommented, ActorName: "Vali",
+			Data: models.JSONMap{"title": "Deploy API"}}, Email: "ali@example.com", Firstname: "Ali"},
+		{Notification: models.Notification{ID: 2, Type: models.NotificationCommented, ActorName: "Ali",
+			Data: models.JSONMap{"title": "Deploy API"}}, Email: "vali@example.com", Firstname: "Vali"},
+	}
+
+	repo := mock_repository.NewMockNotification(c)
+	gomock.InOrder(
+		repo.EXPECT().SkipMutedEmails().Return(int64(0), nil),
+		repo.EXPECT().ExpireInstantEmails(now.Add(-instantEmailWindow)).Return(int64(3), nil),
+		repo.EXPECT().ClaimEmails(models.EmailInstant, now.Add(-instantEmailWindow), now, now, emailLease,
+			instantEmailBatch).Return(instant, nil),
+		repo.EXPECT().SetEmailStatus([]int{1}, models.EmailSent).Return(nil),
+		repo.EXPECT().ClaimEmails(models.EmailDigest, time.Time{}, digestCutoff(now, 8), now, emailLease, 0).
+			Return(nil, nil),
+	)
+
+	m := &testMailer{failTo: "vali@example.com"}
+	err := NewNotificationService(repo, m, configs.EmailConfig{DigestHour: 8}).SendEmails(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ali@example.com"}, m.sent, "the failed email is left to be claimed again")
+}
+
+func TestNotificationService_SendEmails_WindowClosed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	instant := []models.PendingEmail{
+		{Notification: models.Notification{ID: 1, Type: models.NotificationCommented, ActorName: "Vali",
+			Data: models.JSONMap{"title": "Deploy API"}}, Email: "ali@example.com", Firstname: "Ali"},
+	}
+
+	repo := mock_repository.NewMockNotification(c)
+	gomock.InOrder(
+		repo.EXPECT().SkipMutedEmails().Return(int64(0), nil),
+		repo.EXPECT().ExpireInstantEmails(now.Add(-instantEmailWindow)).Return(int64(0), nil),
+		repo.EXPECT().ClaimEmails(models.EmailInstant, now.Add(-instantEmailWindow), now, now, emailLease,
+			instantEmailBatch).Return(instant, nil),
+	)
+
+	m := &testMailer{}
+	s := NewNotificationService(repo, m, configs.EmailConfig{DigestHour: 8})
+	s.sendWindow = 0
+
+	assert.NoError(t, s.SendEmails(now))
+	assert.Empty(t, m.sent, "nothing is sent once the window has closed, nor are digests claimed")
+}
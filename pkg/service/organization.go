@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"regexp"
+	"strings"
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var organizationRoles = map[string]bool{
+	"owner":  true,
+	"admin":  true,
+	"member": true,
+}
+
+type OrganizationService struct {
+	repo  repository.Organization
+	users repository.Authorization
+	audit *AuditLog
+}
+
+func NewOrganizationService(repo repository.Organization, users repository.Authorization,
+	audit *AuditLog) *OrganizationService {
+	return &OrganizationService{repo: repo, users: users, audit: audit}
+}
+
+func (o *OrganizationService) CreateOrganization(userId int, org models.Organization) (int, error) {
+	org.Name = strings.TrimSpace(org.Name)
+	org.Slug = strings.ToLower(strings.TrimSpace(org.Slug))
+
+	if org.Name == "" {
+		return -1, errors.New("name is required")
+	}
+
+	if !slugRegexp.MatchString(org.Slug) {
+		return -1, errors.New("slug may contain only lowercase letters, digits and dashes")
+	}
+
+	if o.repo.IsSlugUsed(org.Slug) {
+		return -1, errors.New("slug is already used")
+	}
+
+	id, err := o.repo.CreateOrganization(org, userId)
+	if err != nil {
+		log.Println("failed to create a new organization. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (o *OrganizationService) GetOrganizations(userId int) (models.Organizations, error) {
+	orgs, err := o.repo.GetOrganizations(userId)
+	if err != nil {
+		log.Println("failed to get the list of organizations. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+func (o *OrganizationService) GetOrganization(orgId int) (models.Organization, error) {
+	org, err := o.repo.GetOrganization(orgId)
+	if err != nil {
+		log.Println("failed to get the organization. Error is: ", err.Error())
+		return models.Organization{}, err
+	}
+
+	return org, nil
+}
+
+func (o *OrganizationService) UpdateOrganization(org models.Organization) error {
+	org.Name = strings.TrimSpace(org.Name)
+	if org.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if err := o.repo.UpdateOrganization(org); err != nil {
+		log.Println("failed to update the organization. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationService) GetMembership(orgId, userId int) (models.OrganizationMember, error) {
+	return o.repo.GetMember(orgId, userId)
+}
+
+func (o *OrganizationService) GetMembers(orgId int) ([]models.OrganizationMember, error) {
+	members, err := o.repo.GetMembers(orgId)
+	if err != nil {
+		log.Println("failed to get the list of members. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddMember adds an existing user to the organization. Only owners may grant
+// the owner role.
+func (o *OrganizationService) AddMember(ctx context.Context, actorRole string, member models.OrganizationMember) error {
+	if member.Role == "" {
+		member.Role = "member"
+	}
+
+	if !organizationRoles[member.Role] {
+		return errors.New("invalid role")
+	}
+
+	if member.Role == "owner" && actorRole != "owner" {
+		return errors.New("only owners can add owners")
+	}
+
+	if _, err := o.users.GetUserById(member.UserId); err != nil {
+		return errors.New("user not found")
+	}
+
+	if err := o.repo.AddMember(member); err != nil {
+		log.Println("failed to add a member to the organization. Error is: ", err.Error())
+		return errors.New("user is already a member")
+	}
+
+	if added, err := o.repo.GetMember(member.OrganizationId, member.UserId); err == nil {
+		o.audit.created(ctx, models.EntityMember, added.ID)
+	}
+
+	return nil
+}
+
+func (o *OrganizationService) UpdateMemberRole(ctx context.Context, actorRole string, orgId, userId int,
+	role string) error {
+	if !organizationRoles[role] {
+		return errors.New("invalid role")
+	}
+
+	current, err := o.repo.GetMember(orgId, userId)
+	if err != nil {
+		return errors.New("member not found")
+	}
+
+	if (role == "owner" || current.Role == "owner") && actorRole != "owner" {
+		return errors.New("only owners can change owners")
+	}
+
+	if current.Role == "owner" && role != "owner" {
+		if err := o.ensureAnotherOwner(orgId); err != nil {
+			return err
+		}
+	}
+
+	audit := o.audit.track(models.EntityMember, current.ID)
+	if err := o.repo.UpdateMemberRole(orgId, userId, role); err != nil {
+		log.Println("failed to update the role of the member. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	return nil
+}
+
+func (o *OrganizationService) RemoveMember(ctx context.Context, actorRole string, orgId, userId int) error {
+	current, err := o.repo.GetMember(orgId, userId)
+	if err != nil {
+		return errors.New("member not found")
+	}
+
+	if current.Role == "owner" {
+		if actorRole != "owner" {
+			return errors.New("only owners can remove owners")
+		}
+		if err := o.ensureAnotherOwner(orgId); err != nil {
+			return err
+		}
+	}
+
+	audit := o.audit.track(models.EntityMember, current.ID)
+	if err := o.repo.RemoveMember(orgId, userId); err != nil {
+		log.Println("failed to remove the member. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditDelete)
+
+	return nil
+}
+
+func (o *OrganizationService) ensureAnotherOwner(orgId int) error {
+	owners, err := o.repo.CountOwners(orgId)
+	if err != nil {
+		log.Println("failed to count the owners. Error is: ", err.Error())
+		return err
+	}
+
+	if owners < 2 {
+		return errors.New("organization must keep at least one owner")
+	}
+
+	return nil
+}
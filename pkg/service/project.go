@@ -1,31 +1,58 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
 	"log"
 )
 
 type ProjectService struct {
-	repo repository.Project
+	repo       repository.Project
+	org        repository.Organization
+	department repository.Department
+	feed       *ActivityFeed
+	audit      *AuditLog
 }
 
-func NewProjectService(repo repository.Project) *ProjectService {
-	return &ProjectService{repo: repo}
+func NewProjectService(repo repository.Project, org repository.Organization,
+	department repository.Department, feed *ActivityFeed, audit *AuditLog) *ProjectService {
+	return &ProjectService{repo: repo, org: org, department: department, feed: feed, audit: audit}
 }
 
-func (p *ProjectService) CreateProject(project models.Project) (int, error) {
+func (p *ProjectService) checkDepartment(project models.Project) error {
+	if project.DepartmentId == nil {
+		return nil
+	}
+
+	if _, err := p.department.GetDepartment(project.OrganizationId, *project.DepartmentId); err != nil {
+		return errors.New("department doesn't exist")
+	}
+
+	return nil
+}
+
+func (p *ProjectService) CreateProject(ctx context.Context, project models.Project) (int, error) {
+	if err := p.checkDepartment(project); err != nil {
+		log.Println("failed to create a new project. Error is: ", err.Error())
+		return -1, err
+	}
+
 	id, err := p.repo.CreateProject(project)
 	if err != nil {
 		log.Println("failed to create a new project. Error is: ", err.Error())
 		return -1, err
 	}
 
+	p.audit.created(ctx, models.EntityProject, id)
+	p.feed.project(project.ManagerID, models.ActivityProjectCreated, id, nil)
+
 	return id, nil
 }
 
-func (p *ProjectService) GetAllProjects(userId int) (models.Projects, error) {
-	projects, err := p.repo.GetAllProjects(userId)
+func (p *ProjectService) GetAllProjects(orgId, userId int) (models.Projects, error) {
+	projects, err := p.repo.GetAllProjects(orgId, userId)
 	if err != nil {
 		log.Println("failed to get the list of projects. Error is: ", err.Error())
 		return nil, err
@@ -34,8 +61,8 @@ func (p *ProjectService) GetAllProjects(userId int) (models.Projects, error) {
 	return projects, nil
 }
 
-func (p *ProjectService) GetProjectById(userId, projectId int) (models.Project, error) {
-	project, err := p.repo.GetProjectById(userId, projectId)
+func (p *ProjectService) GetProjectById(orgId, userId, projectId int) (models.Project, error) {
+	project, err := p.repo.GetProjectById(orgId, userId, projectId)
 	if err != nil {
 		log.Println("failed to get the project by id. Error is: ", err.Error())
 		return models.Project{}, err
@@ -44,33 +71,46 @@ func (p *ProjectService) GetProjectById(userId, projectId int) (models.Project,
 	return project, nil
 }
 
-func (p *ProjectService) UpdateProject(project models.Project) error {
-	_, err := p.repo.GetProjectById(project.ManagerID, project.ID)
+func (p *ProjectService) UpdateProject(ctx context.Context, project models.Project) error {
+	_, err := p.repo.GetProjectById(project.OrganizationId, project.ManagerID, project.ID)
 	if err != nil {
 		log.Println("you don't have any project. Error is: ", err.Error())
 		return err
 	}
 
+	if err := p.checkDepartment(project); err != nil {
+		log.Println("failed to update the project. Error is: ", err.Error())
+		return err
+	}
+
+	audit := p.audit.track(models.EntityProject, project.ID)
 	if err := p.repo.UpdateProject(project); err != nil {
 		log.Println("failed to update the project. Error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditUpdate)
+
+	p.feed.project(project.ManagerID, models.ActivityProjectUpdated, project.ID, nil)
 
 	return nil
 }
 
-func (p *ProjectService) DeleteProject(userId, projectId int) error {
-	err := p.repo.DeleteProject(userId, projectId)
+func (p *ProjectService) DeleteProject(ctx context.Context, orgId, userId, projectId int) error {
+	audit := p.audit.track(models.EntityProject, projectId)
+	err := p.repo.DeleteProject(orgId, userId, projectId)
 	if err != nil {
 		log.Println("failed to delete the project. Error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditDelete)
+
+	p.feed.project(userId, models.ActivityProjectDeleted, projectId, nil)
 
 	return nil
 }
 
-func (p *ProjectService) GetDeletedProjects(userId int) (models.Projects, error) {
-	projects, err := p.repo.GetDeletedProjects(userId)
+func (p *ProjectService) GetDeletedProjects(orgId, userId int) (models.Projects, error) {
+	projects, err := p.repo.GetDeletedProjects(orgId, userId)
 	if err != nil {
 		log.Println("failed to get the list of deleted projects. Error is: ", err.Error())
 		return nil, err
@@ -79,27 +119,42 @@ func (p *ProjectService) GetDeletedProjects(userId int) (models.Projects, error)
 	return projects, nil
 }
 
-func (p *ProjectService) Restore(userId, projectId int) error {
-	err := p.repo.RestoreProject(userId, projectId)
+func (p *ProjectService) Restore(ctx context.Context, orgId, userId, projectId int) error {
+	audit := p.audit.track(models.EntityProject, projectId)
+	err := p.repo.RestoreProject(orgId, userId, projectId)
 	if err != nil {
 		log.Println("failed to restore the project. Error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditRestore)
+
+	p.feed.project(userId, models.ActivityProjectRestored, projectId, nil)
 
 	return nil
 }
 
-func (p *ProjectService) AddUserToProject(managerId int, propar models.ProjectParticipant) error {
-	_, err := p.repo.GetProjectById(managerId, propar.ProjectId)
+func (p *ProjectService) AddUserToProject(ctx context.Context, orgId, managerId int,
+	propar models.ProjectParticipant) (int, error) {
+	_, err := p.repo.GetProjectById(orgId, managerId, propar.ProjectId)
 	if err != nil {
 		log.Println("failed to add a new participant to the project. Error is: ", err.Error())
-		return err
+		return -1, err
 	}
 
-	if err := p.repo.AddUserToProject(propar); err != nil {
+	if _, err := p.org.GetMember(orgId, propar.ParticipantId); err != nil {
+		log.Println("the participant is not a member of the organization. Error is: ", err.Error())
+		return -1, err
+	}
+
+	id, err := p.repo.AddUserToProject(propar)
+	if err != nil {
 		log.Println("failed to add a new participant to the project. Error is: ", err.Error())
-		return err
+		return -1, err
 	}
 
-	return nil
+	p.audit.created(ctx, models.EntityParticipant, id)
+	p.feed.add(models.Activity{ActorId: managerId, Verb: models.ActivityParticipantAdded,
+		ProjectId: propar.ProjectId, UserId: &propar.ParticipantId})
+
+	return id, nil
 }
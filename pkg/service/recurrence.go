@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"strings"
+	"time"
+)
+
+type RecurrenceService struct {
+	repo  repository.Recurrence
+	task  repository.Task
+	audit *AuditLog
+}
+
+func NewRecurrenceService(repo repository.Recurrence, task repository.Task, audit *AuditLog) *RecurrenceService {
+	return &RecurrenceService{repo: repo, task: task, audit: audit}
+}
+
+// nextOccurrence returns the deadline of the next task of the series, or
+// false once the series is over. Rules on schedule skip the occurrences
+// missed while nothing was running instead of piling up overdue tasks.
+func nextOccurrence(recurrence models.Recurrence, rule utils.RRule, now time.Time) (time.Time, bool) {
+	if rule.Count > 0 && recurrence.Occurrences >= rule.Count {
+		return time.Time{}, false
+	}
+
+	next, ok := rule.Next(recurrence.StartAt, recurrence.LastDeadline)
+	if recurrence.Mode == models.RecurrenceOnSchedule {
+		for ok && !next.After(now) {
+			next, ok = rule.Next(recurrence.StartAt, next)
+		}
+	}
+
+	return next, ok
+}
+
+func checkRecurrence(recurrence *models.Recurrence) error {
+	recurrence.Rule = strings.ToUpper(strings.TrimSpace(recurrence.Rule))
+	if _, err := utils.ParseRRule(recurrence.Rule); err != nil {
+		return err
+	}
+
+	if recurrence.Mode == "" {
+		recurrence.Mode = models.RecurrenceOnSchedule
+	}
+
+	if recurrence.Mode != models.RecurrenceOnSchedule && recurrence.Mode != models.RecurrenceOnComplete {
+		return errors.New("invalid mode")
+	}
+
+	return nil
+}
+
+func (r *RecurrenceService) GetRecurrence(orgId, userId, taskId int) (models.Recurrence, error) {
+	task, err := r.task.GetTaskById(orgId, userId, taskId)
+	if err != nil {
+		return models.Recurrence{}, errors.New("task doesn't exist")
+	}
+
+	if task.RecurrenceId == nil {
+		return models.Recurrence{}, errors.New("task doesn't repeat")
+	}
+
+	recurrence, err := r.repo.GetRecurrence(orgId, *task.RecurrenceId)
+	if err != nil {
+		log.Println("failed to get the recurrence of the task. Error is: ", err.Error())
+		return models.Recurrence{}, err
+	}
+
+	return recurrence, nil
+}
+
+// SetRecurrence makes the task repeat, or changes the rule of the series it
+// is in. A changed rule starts over from the latest occurrence, which also
+// resumes a stopped series.
+func (r *RecurrenceService) SetRecurrence(ctx context.Context, orgId, userId, taskId int,
+	recurrence models.Recurrence) (int, error) {
+	if err := checkRecurrence(&recurrence); err != nil {
+		return -1, err
+	}
+
+	task, err := r.task.GetTaskById(orgId, userId, taskId)
+	if err != nil {
+		return -1, errors.New("task doesn't exist")
+	}
+
+	if task.RecurrenceId != nil {
+		current, err := r.repo.GetRecurrence(orgId, *task.RecurrenceId)
+		if err != nil {
+			log.Println("failed to get the recurrence of the task. Error is: ", err.Error())
+			return -1, err
+		}
+
+		recurrence.ID = current.ID
+		recurrence.OrganizationId = orgId
+		recurrence.StartAt = current.LastDeadline
+		recurrence.Occurrences = 1
+		if err := r.repo.UpdateRecurrence(recurrence); err != nil {
+			log.Println("failed to update the recurrence. Error is: ", err.Error())
+			return -1, err
+		}
+
+		return current.ID, nil
+	}
+
+	deadline, err := utils.ParseDeadline(task.Deadline)
+	if err != nil {
+		return -1, err
+	}
+
+	recurrence.OrganizationId = orgId
+	recurrence.StartAt = deadline
+	recurrence.LastTaskId = task.ID
+	recurrence.LastDeadline = deadline
+	recurrence.Occurrences = 1
+
+	audit := r.audit.track(models.EntityTask, task.ID)
+	id, err := r.repo.CreateRecurrence(recurrence)
+	if err != nil {
+		log.Println("failed to create a new recurrence. Error is: ", err.Error())
+		return -1, err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	return id, nil
+}
+
+func (r *RecurrenceService) StopRecurrence(orgId, userId, taskId int) error {
+	recurrence, err := r.GetRecurrence(orgId, userId, taskId)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.StopRecurrence(recurrence.ID); err != nil {
+		log.Println("failed to stop the recurrence. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GenerateDue makes the next task of every series that is due and returns
+// how many it made.
+func (r *RecurrenceService) GenerateDue(now time.Time) (int, error) {
+	recurrences, err := r.repo.GetDueRecurrences(now)
+	if err != nil {
+		log.Println("failed to get the due recurrences. Error is: ", err.Error())
+		return 0, err
+	}
+
+	created := 0
+	for _, recurrence := range recurrences {
+		ok, err := r.generate(recurrence, now)
+		if err != nil {
+			log.Println("failed to generate the next occurrence of recurrence", recurrence.ID, ". Error is: ",
+				err.Error())
+			continue
+		}
+
+		if ok {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// generate makes the next task of the series and tells whether it did. A
+// series that is over is stopped instead. When the task can't be made, e.g.
+// because its column is at its WIP limit, the series stays due and is tried
+// again on the next run.
+func (r *RecurrenceService) generate(recurrence models.Recurrence, now time.Time) (bool, error) {
+	rule, err := utils.ParseRRule(recurrence.Rule)
+	if err != nil {
+		return false, err
+	}
+
+	deadline, ok := nextOccurrence(recurrence, rule, now)
+	if !ok {
+		return false, r.repo.StopRecurrence(recurrence.ID)
+	}
+
+	last, err := r.repo.GetTask(recurrence.LastTaskId)
+	if err != nil {
+		return false, err
+	}
+
+	task := models.Task{
+		OrganizationId:   last.OrganizationId,
+		Title:            last.Title,
+		Description:      last.Description,
+		ControllerId:     last.ControllerId,
+		ExecutorId:       last.ExecutorId,
+		TeamId:           last.TeamId,
+		CustomFields:     last.CustomFields,
+		Status:           models.StatusNotStarted,
+		Priority:         last.Priority,
+		RecurrenceId:     &recurrence.ID,
+		OriginalEstimate: last.OriginalEstimate,
+		ProjectId:        last.ProjectId,
+		Deadline:         deadline.Format("2006-01-02 15:04:05"),
+		IsActive:         true,
+	}
+	task.RemainingEstimate = task.OriginalEstimate
+
+	id, err := r.repo.CreateOccurrence(recurrence, task, deadline)
+	if errors.Is(err, repository.ErrOccurrenceTaken) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	ctx := WithAuditSource(context.Background(), models.AuditSource{OrganizationId: &recurrence.OrganizationId})
+	r.audit.created(ctx, models.EntityTask, id)
+
+	return true, nil
+}
+
+// Run generates due occurrences every interval until ctx is done.
+func (r *RecurrenceService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if created, err := r.GenerateDue(now); err == nil && created > 0 {
+				log.Println("recurring tasks created:", created)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2024, 3, day, 17, 0, 0, 0, time.UTC)
+	}
+
+	testTable := []struct {
+		name        string
+		rule        string
+		recurrence  models.Recurrence
+		now         time.Time
+		expected    time.Time
+		expectedEnd bool
+	}{
+		{
+			name: "on completion keeps the next date even when overdue",
+			rule: "FREQ=DAILY",
+			recurrence: models.Recurrence{Mode: models.RecurrenceOnComplete, StartAt: date(1), LastDeadline: date(1),
+				Occurrences: 1},
+			now:      date(5),
+			expected: date(2),
+		},
+		{
+			name: "on schedule skips missed occurrences",
+			rule: "FREQ=DAILY",
+			recurrence: models.Recurrence{Mode: models.RecurrenceOnSchedule, StartAt: date(1), LastDeadline: date(1),
+				Occurrences: 1},
+			now:      date(5).Add(time.Hour),
+			expected: date(6),
+		},
+		{
+			name: "count reached",
+			rule: "FREQ=WEEKLY;COUNT=3",
+			recurrence: models.Recurrence{Mode: models.RecurrenceOnSchedule, StartAt: date(1), LastDeadline: date(15),
+				Occurrences: 3},
+			now:         date(15),
+			expectedEnd: true,
+		},
+		{
+			name: "past until",
+			rule: "FREQ=WEEKLY;UNTIL=20240320",
+			recurrence: models.Recurrence{Mode: models.RecurrenceOnComplete, StartAt: date(1), LastDeadline: date(15),
+				Occurrences: 3},
+			now:         date(15),
+			expectedEnd: true,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := utils.ParseRRule(test.rule)
+			require.NoError(t, err)
+
+			next, ok := nextOccurrence(test.recurrence, rule, test.now)
+			if test.expectedEnd {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, test.expected, next)
+		})
+	}
+}
+
+func TestRecurrenceService_GenerateDue(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	now := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	series := func(id int) models.Recurrence {
+		return models.Recurrence{ID: id, OrganizationId: 1, Rule: "FREQ=DAILY", Mode: models.RecurrenceOnComplete,
+			LastTaskId: id * 10, StartAt: now, LastDeadline: now, Occurrences: 1}
+	}
+
+	repo := mock_repository.NewMockRecurrence(c)
+	repo.EXPECT().GetDueRecurrences(now).Return([]models.Recurrence{series(1), series(2), series(3)}, nil)
+	for _, id := range []int{1, 2, 3} {
+		repo.EXPECT().GetTask(id*10).Return(models.Task{ID: id * 10, OrganizationId: 1, ProjectId: 4}, nil)
+	}
+	repo.EXPECT().CreateOccurrence(series(1), gomock.Any(), gomock.Any()).Return(11, nil)
+	// The series was stopped while its occurrence was being made.
+	repo.EXPECT().CreateOccurrence(series(2), gomock.Any(), gomock.Any()).
+		Return(-1, repository.ErrOccurrenceTaken)
+	// The column is full, so the series stays due.
+	repo.EXPECT().CreateOccurrence(series(3), gomock.Any(), gomock.Any()).Return(-1, repository.ErrWipLimit)
+
+	audit, store := testAudit(c)
+	s := NewRecurrenceService(repo, nil, audit)
+
+	created, err := s.GenerateDue(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+	if assert.Len(t, store.entries, 1) {
+		assert.Equal(t, 11, store.entries[0].EntityId)
+	}
+}
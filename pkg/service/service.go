@@ -1,9 +1,14 @@
 package service
 
 import (
+	"context"
+	"github.com/sharifsharifzoda/project-management-system/configs"
 	"github.com/sharifsharifzoda/project-management-system/logging"
 	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	"github.com/sharifsharifzoda/project-management-system/pkg/mailer"
 	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"time"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
@@ -11,57 +16,294 @@ import (
 type Authorization interface {
 	ValidateUser(user models.User) error
 	IsEmailUsed(email string) bool
-	CreateUser(user *models.User) (int, error)
+	CreateUser(ctx context.Context, user *models.User) (int, error)
 	CheckUser(user models.User) (models.User, error)
 	GenerateToken(user models.User) (string, error)
-	ParseToken(token string) (int, string, error)
+	ParseToken(token string) (models.Identity, error)
+	JWKS() models.JWKS
+	Impersonate(adminId, userId int, ip string) (string, error)
+	RecordImpersonation(entry models.ImpersonationLog)
 }
 
 type User interface {
 	GetUser(id int) (models.User, error)
-	UpdateUser(newUser models.User) error
-	DeleteUser(id int) error
-	Restore(id int) error
-	GetProjects(userId int) ([]models.ProjectParticipant, error)
-	GetTasks(userId int) (models.Tasks, error)
-	UploadUserPicture(id int, filepath string) (models.User, error)
-	UpdatePictureUser(id int, filepath string) (models.User, error)
+	UpdateUser(ctx context.Context, newUser models.User) error
+	DeleteUser(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	GetProjects(orgId, userId int) ([]models.UserProject, error)
+	GetTasks(orgId, userId int) (models.Tasks, error)
+	UploadUserPicture(ctx context.Context, id int, filepath string) (models.User, error)
+	UpdatePictureUser(ctx context.Context, id int, filepath string) (models.User, error)
 }
 
 type Project interface {
-	CreateProject(project models.Project) (int, error)
-	GetAllProjects(userId int) (models.Projects, error)
-	GetProjectById(userId, projectId int) (models.Project, error)
-	UpdateProject(project models.Project) error
-	DeleteProject(userId, projectId int) error
-	GetDeletedProjects(userId int) (models.Projects, error)
-	Restore(userId, projectId int) error
-	AddUserToProject(managerId int, propar models.ProjectParticipant) error
+	CreateProject(ctx context.Context, project models.Project) (int, error)
+	GetAllProjects(orgId, userId int) (models.Projects, error)
+	GetProjectById(orgId, userId, projectId int) (models.Project, error)
+	UpdateProject(ctx context.Context, project models.Project) error
+	DeleteProject(ctx context.Context, orgId, userId, projectId int) error
+	GetDeletedProjects(orgId, userId int) (models.Projects, error)
+	Restore(ctx context.Context, orgId, userId, projectId int) error
+	AddUserToProject(ctx context.Context, orgId, managerId int, propar models.ProjectParticipant) (int, error)
+}
+
+type Activity interface {
+	GetProjectActivity(userId int, filter models.ActivityFilter) (models.ActivityPage, error)
+	GetUserActivity(filter models.ActivityFilter) (models.ActivityPage, error)
+}
+
+type Comment interface {
+	AddComment(orgId, userId int, comment models.TaskComment) (int, error)
+	GetComments(orgId, userId, taskId int) (models.TaskComments, error)
+}
+
+type Notification interface {
+	GetNotifications(filter models.NotificationFilter) (models.NotificationPage, error)
+	CountUnread(userId int) (int64, error)
+	MarkRead(userId, id int) error
+	MarkAllRead(userId int) (int64, error)
+	GetPreferences(userId int) ([]models.NotificationPreference, error)
+	SetPreferences(userId int, modes map[string]string) error
+	SendEmails(now time.Time) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type Deadline interface {
+	Check(now time.Time) (int64, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type Stream interface {
+	Subscribe(ctx context.Context, orgId, userId int) <-chan broker.Event
+}
+
+type Webhook interface {
+	CreateWebhook(webhook models.Webhook) (models.Webhook, error)
+	GetWebhooks(orgId int) (models.Webhooks, error)
+	GetWebhook(orgId, id int) (models.Webhook, error)
+	UpdateWebhook(webhook models.Webhook) error
+	DeleteWebhook(orgId, id int) error
+	GetDeliveries(orgId, id, beforeId, limit int) (models.WebhookDeliveryPage, error)
+	Redeliver(orgId, id, deliveryId int) (int, error)
+	Deliver(now time.Time) (int, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type Audit interface {
+	GetEntries(filter models.AuditFilter) (models.AuditPage, error)
+}
+
+type Template interface {
+	SaveTemplate(orgId, userId, projectId int, template models.ProjectTemplate) (int, error)
+	GetTemplates(orgId int) (models.ProjectTemplates, error)
+	GetTemplate(orgId, id int) (models.ProjectTemplate, error)
+	UpdateTemplate(template models.ProjectTemplate) error
+	DeleteTemplate(orgId, id int) error
+	CreateProject(ctx context.Context, orgId, templateId int, project models.Project) (int, error)
+	CloneProject(ctx context.Context, orgId, userId, projectId int, project models.Project) (int, error)
 }
 
 type Task interface {
-	CreateTask(task models.Task) (int, error)
-	GetAllTasks(userId int) (models.Tasks, error)
-	GetTaskById(userId, taskId int) (models.Task, error)
-	UpdateTask(task models.Task) error
-	DeleteTask(userId, taskId int) error
-	RestoreTask(userId, taskId int) error
+	CreateTask(ctx context.Context, task models.Task) (int, error)
+	GetAllTasks(orgId, userId int, filter models.TaskFilter) (models.Tasks, error)
+	GetTaskById(orgId, userId, taskId int) (models.Task, error)
+	UpdateTask(ctx context.Context, task models.Task) error
+	DeleteTask(ctx context.Context, orgId, userId, taskId int) error
+	RestoreTask(ctx context.Context, orgId, userId, taskId int) error
+	SetAssignee(ctx context.Context, orgId, userId int, assignee models.TaskAssignee) error
+	RemoveAssignee(ctx context.Context, orgId, userId, taskId, assigneeId int) error
+	GetAssignees(orgId, userId, taskId int) ([]models.TaskAssignee, error)
+	SetLabels(ctx context.Context, orgId, userId, taskId int, labelIds []int) error
+	BulkUpdate(ctx context.Context, orgId, userId int, bulk models.BulkTaskUpdate) (models.BulkTaskResults, error)
+	MoveTask(ctx context.Context, orgId, userId, taskId, projectId int, options models.TransferOptions) error
+	CopyTask(ctx context.Context, orgId, userId, taskId, projectId int, options models.TransferOptions) (int, error)
+	GetTransfers(orgId, userId, taskId int) ([]models.TaskTransfer, error)
+}
+
+type Invite interface {
+	CreateInvite(orgId, managerId int, invite models.ProjectInvite) (int, error)
+	GetPendingInvites(orgId, managerId, projectId int) (models.ProjectInvites, error)
+	RevokeInvite(orgId, managerId, projectId, inviteId int) error
+	GetInvite(token string) (models.InviteInfo, error)
+	AcceptInvite(ctx context.Context, token string, user models.User) (models.ProjectParticipant, error)
+}
+
+type Organization interface {
+	CreateOrganization(userId int, org models.Organization) (int, error)
+	GetOrganizations(userId int) (models.Organizations, error)
+	GetOrganization(orgId int) (models.Organization, error)
+	UpdateOrganization(org models.Organization) error
+	GetMembership(orgId, userId int) (models.OrganizationMember, error)
+	GetMembers(orgId int) ([]models.OrganizationMember, error)
+	AddMember(ctx context.Context, actorRole string, member models.OrganizationMember) error
+	UpdateMemberRole(ctx context.Context, actorRole string, orgId, userId int, role string) error
+	RemoveMember(ctx context.Context, actorRole string, orgId, userId int) error
+}
+
+type Department interface {
+	CreateDepartment(department models.Department) (int, error)
+	GetDepartments(orgId int) (models.Departments, error)
+	GetDepartment(orgId, id int) (models.Department, error)
+	UpdateDepartment(department models.Department) error
+	DeleteDepartment(orgId, id int) error
+	GetMembers(orgId, id int) ([]models.DepartmentMember, error)
+	AddMember(orgId int, member models.DepartmentMember) error
+	RemoveMember(orgId, id, userId int) error
+	GetProjects(orgId, id int) (models.Projects, error)
+	GetStats(orgId, id int) (models.DepartmentStats, error)
+}
+
+type Team interface {
+	CreateTeam(team models.Team) (int, error)
+	GetTeams(orgId int) (models.Teams, error)
+	GetTeam(orgId, id int) (models.Team, error)
+	UpdateTeam(team models.Team) error
+	DeleteTeam(orgId, id int) error
+	GetMembers(orgId, id int) ([]models.TeamMember, error)
+	AddMember(orgId int, member models.TeamMember) error
+	RemoveMember(orgId, id, userId int) error
+	AddTeamToProject(ctx context.Context, orgId, managerId int, projectTeam models.ProjectTeam) error
+	RemoveTeamFromProject(ctx context.Context, orgId, managerId, projectId, teamId int) error
+	GetProjectTeams(orgId, managerId, projectId int) ([]models.ProjectTeam, error)
+	GetQueue(orgId, userId, id int, isAdmin bool) (models.Tasks, error)
+	ClaimTask(ctx context.Context, orgId, userId, id, taskId int) error
+}
+
+type Label interface {
+	CreateLabel(orgId, managerId int, label models.Label) (int, error)
+	GetLabels(orgId, managerId, projectId int) (models.Labels, error)
+	UpdateLabel(orgId, managerId int, label models.Label) error
+	DeleteLabel(orgId, managerId, projectId, id int) error
+}
+
+type CustomField interface {
+	CreateCustomField(orgId, managerId int, field models.CustomField) (int, error)
+	GetCustomFields(orgId, managerId, projectId int) (models.CustomFields, error)
+	UpdateCustomField(orgId, managerId int, field models.CustomField) error
+	DeleteCustomField(orgId, managerId, projectId, id int) error
+}
+
+type Board interface {
+	GetBoard(orgId, managerId, projectId int) (models.Board, error)
+	CreateColumn(orgId, managerId int, column models.BoardColumn) (int, error)
+	UpdateColumn(orgId, managerId int, column models.BoardColumn) error
+	DeleteColumn(orgId, managerId, projectId, id int) error
+	MoveTask(ctx context.Context, orgId, managerId int, move models.TaskMove) error
+}
+
+type Sprint interface {
+	CreateSprint(orgId, managerId int, sprint models.Sprint) (int, error)
+	GetSprints(orgId, managerId, projectId int) (models.Sprints, error)
+	GetSprint(orgId, managerId, projectId, id int) (models.Sprint, error)
+	UpdateSprint(orgId, managerId int, sprint models.Sprint) error
+	DeleteSprint(orgId, managerId, projectId, id int) error
+	StartSprint(orgId, managerId, projectId, id int) error
+	CompleteSprint(ctx context.Context, orgId, managerId, projectId, id int, nextId *int) error
+	AddTasks(ctx context.Context, orgId, managerId, projectId, id int, taskIds []int) error
+	RemoveTask(ctx context.Context, orgId, managerId, projectId, id, taskId int) error
+	GetBacklog(orgId, managerId, projectId int) (models.Tasks, error)
+	GetVelocity(orgId, managerId, projectId int) (models.Velocity, error)
+}
+
+type Milestone interface {
+	CreateMilestone(orgId, managerId int, milestone models.Milestone) (int, error)
+	GetMilestones(orgId, managerId, projectId int) (models.Milestones, error)
+	GetMilestone(orgId, managerId, projectId, id int) (models.Milestone, error)
+	UpdateMilestone(orgId, managerId int, milestone models.Milestone) error
+	DeleteMilestone(orgId, managerId, projectId, id int) error
+	AddTasks(ctx context.Context, orgId, managerId, projectId, id int, taskIds []int) error
+	RemoveTask(ctx context.Context, orgId, managerId, projectId, id, taskId int) error
+}
+
+type Worklog interface {
+	LogWork(ctx context.Context, orgId, userId int, worklog models.Worklog) (int, error)
+	GetWorklogs(orgId, userId, taskId int) (models.Worklogs, error)
+	DeleteWorklog(ctx context.Context, orgId, userId, taskId, id int) error
+	StartTimer(orgId, userId, taskId int) (int, error)
+	GetRunningTimer(userId int) (models.Worklog, error)
+	StopTimer(ctx context.Context, userId int) (models.Worklog, error)
+	GetTimesheet(filter models.TimesheetFilter) (models.Timesheet, error)
+}
+
+type Recurrence interface {
+	GetRecurrence(orgId, userId, taskId int) (models.Recurrence, error)
+	SetRecurrence(ctx context.Context, orgId, userId, taskId int, recurrence models.Recurrence) (int, error)
+	StopRecurrence(orgId, userId, taskId int) error
+	GenerateDue(now time.Time) (int, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type Checklist interface {
+	CreateItem(ctx context.Context, orgId, userId int, item models.ChecklistItem) (int, error)
+	GetItems(orgId, userId, taskId int) (models.ChecklistItems, error)
+	UpdateItem(ctx context.Context, orgId, userId, taskId, id int, title *string, isChecked *bool) error
+	DeleteItem(ctx context.Context, orgId, userId, taskId, id int) error
+	MoveItem(ctx context.Context, orgId, userId int, move models.ChecklistMove) error
 }
 
 type Service struct {
-	Auth    Authorization
-	User    User
-	Project Project
-	Task    Task
-	Logger  *logging.Logger
+	Auth         Authorization
+	User         User
+	Project      Project
+	Task         Task
+	Invite       Invite
+	Organization Organization
+	Department   Department
+	Team         Team
+	Label        Label
+	CustomField  CustomField
+	Board        Board
+	Sprint       Sprint
+	Milestone    Milestone
+	Worklog      Worklog
+	Recurrence   Recurrence
+	Checklist    Checklist
+	Template     Template
+	Audit        Audit
+	Activity     Activity
+	Comment      Comment
+	Notification Notification
+	Webhook      Webhook
+	Stream       Stream
+	Deadline     Deadline
+	Logger       *logging.Logger
 }
 
-func NewService(repository *repository.Repository, log *logging.Logger) *Service {
+func NewService(repository *repository.Repository, keys *KeySet, mailer mailer.Mailer, emailCfg configs.EmailConfig,
+	deadlineCfg configs.DeadlineConfig, events broker.Broker, log *logging.Logger) *Service {
+	audit := NewAuditLog(repository.Audit)
+	auth := NewAuthService(repository.Authorization, keys, log, audit)
+	feed := NewActivityFeed(repository.Activity, repository.Notification, repository.Webhook, events)
+
 	return &Service{
-		Auth:    NewAuthService(repository.Authorization, log),
-		User:    NewUserService(repository.User),
-		Project: NewProjectService(repository.Project),
-		Task:    NewTaskService(repository.Task),
-		Logger:  log,
+		Auth: auth,
+		User: NewUserService(repository.User, audit),
+		Project: NewProjectService(repository.Project, repository.Organization, repository.Department, feed,
+			audit),
+		Task: NewTaskService(repository.Task, repository.Organization, repository.Label,
+			repository.CustomField, repository.Board, repository.Project, feed, audit),
+		Invite: NewInviteService(repository.Invite, repository.Project, repository.Authorization, auth, keys, mailer,
+			feed, audit),
+		Organization: NewOrganizationService(repository.Organization, repository.Authorization, audit),
+		Department:   NewDepartmentService(repository.Department, repository.Organization),
+		Team:         NewTeamService(repository.Team, repository.Organization, repository.Project, feed, audit),
+		Label:        NewLabelService(repository.Label, repository.Project),
+		CustomField:  NewCustomFieldService(repository.CustomField, repository.Project),
+		Board:        NewBoardService(repository.Board, repository.Project, feed, audit),
+		Sprint:       NewSprintService(repository.Sprint, repository.Project, feed, audit),
+		Milestone:    NewMilestoneService(repository.Milestone, repository.Project, feed, audit),
+		Worklog:      NewWorklogService(repository.Worklog, feed, audit),
+		Recurrence:   NewRecurrenceService(repository.Recurrence, repository.Task, audit),
+		Checklist:    NewChecklistService(repository.Checklist, repository.Task, feed, audit),
+		Audit:        NewAuditService(repository.Audit),
+		Activity:     NewActivityService(repository.Activity, repository.Task),
+		Comment:      NewCommentService(repository.Comment, repository.Task, feed),
+		Notification: NewNotificationService(repository.Notification, mailer, emailCfg),
+		Template: NewTemplateService(repository.Template, repository.Project, repository.Organization,
+			repository.Department, repository.Task, feed, audit),
+		Webhook:  NewWebhookService(repository.Webhook, repository.Task),
+		Stream:   NewStreamService(events, repository.Task),
+		Deadline: NewDeadlineService(repository.Deadline, deadlineCfg, audit),
+		Logger:   log,
 	}
 }
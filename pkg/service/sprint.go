@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"strings"
+)
+
+// velocitySprints is how many of the latest completed sprints the average
+// velocity is taken over.
+const velocitySprints = 3
+
+type SprintService struct {
+	repo    repository.Sprint
+	project repository.Project
+	feed    *ActivityFeed
+	audit   *AuditLog
+}
+
+func NewSprintService(repo repository.Sprint, project repository.Project, feed *ActivityFeed,
+	audit *AuditLog) *SprintService {
+	return &SprintService{repo: repo, project: project, feed: feed, audit: audit}
+}
+
+// addTasks adds the tasks, each once, with add, which returns how many of
+// them it found among the active tasks of the project. It returns the ids
+// it added.
+func addTasks(taskIds []int, add func(ids []int) (int64, error)) ([]int, error) {
+	ids := uniqueIds(taskIds)
+
+	if len(ids) == 0 {
+		return nil, errors.New("no tasks to add")
+	}
+
+	added, err := add(ids)
+	if err != nil {
+		log.Println("failed to add the tasks. Error is: ", err.Error())
+		return nil, err
+	}
+
+	if added != int64(len(ids)) {
+		return nil, errors.New("some of the tasks don't exist in the project")
+	}
+
+	return ids, nil
+}
+
+func checkSprint(sprint *models.Sprint) error {
+	sprint.Name = strings.TrimSpace(sprint.Name)
+	if sprint.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if !sprint.EndDate.After(sprint.StartDate) {
+		return errors.New("sprint must end after it starts")
+	}
+
+	return nil
+}
+
+func (s *SprintService) CreateSprint(orgId, managerId int, sprint models.Sprint) (int, error) {
+	if err := checkProject(s.project, orgId, managerId, sprint.ProjectId); err != nil {
+		return -1, err
+	}
+
+	if err := checkSprint(&sprint); err != nil {
+		return -1, err
+	}
+	sprint.Status = models.SprintPlanned
+
+	id, err := s.repo.CreateSprint(sprint)
+	if err != nil {
+		log.Println("failed to create a new sprint. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (s *SprintService) GetSprints(orgId, managerId, projectId int) (models.Sprints, error) {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return nil, err
+	}
+
+	sprints, err := s.repo.GetSprints(projectId)
+	if err != nil {
+		log.Println("failed to get the list of sprints. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return sprints, nil
+}
+
+func (s *SprintService) GetSprint(orgId, managerId, projectId, id int) (models.Sprint, error) {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return models.Sprint{}, err
+	}
+
+	sprint, err := s.repo.GetSprint(projectId, id)
+	if err != nil {
+		return models.Sprint{}, errors.New("sprint doesn't exist")
+	}
+
+	sprint.Tasks, err = s.repo.GetSprintTasks(id)
+	if err != nil {
+		log.Println("failed to get the tasks of the sprint. Error is: ", err.Error())
+		return models.Sprint{}, err
+	}
+
+	return sprint, nil
+}
+
+func (s *SprintService) UpdateSprint(orgId, managerId int, sprint models.Sprint) error {
+	if err := checkProject(s.project, orgId, managerId, sprint.ProjectId); err != nil {
+		return err
+	}
+
+	current, err := s.repo.GetSprint(sprint.ProjectId, sprint.ID)
+	if err != nil {
+		return errors.New("sprint doesn't exist")
+	}
+
+	if current.Status == models.SprintCompleted {
+		return errors.New("completed sprints can't be changed")
+	}
+
+	if err := checkSprint(&sprint); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateSprint(sprint); err != nil {
+		log.Println("failed to update the sprint. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *SprintService) DeleteSprint(orgId, managerId, projectId, id int) error {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSprint(projectId, id); err != nil {
+		log.Println("failed to delete the sprint. Error is: ", err.Error())
+		return errors.New("only planned sprints can be deleted")
+	}
+
+	return nil
+}
+
+func (s *SprintService) StartSprint(orgId, managerId, projectId, id int) error {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	sprint, err := s.repo.GetSprint(projectId, id)
+	if err != nil {
+		return errors.New("sprint doesn't exist")
+	}
+
+	if sprint.Status != models.SprintPlanned {
+		return errors.New("only planned sprints can be started")
+	}
+
+	if s.repo.HasActiveSprint(projectId) {
+		return errors.New("project already has an active sprint")
+	}
+
+	if err := s.repo.StartSprint(projectId, id); err != nil {
+		log.Println("failed to start the sprint. Error is: ", err.Error())
+		return errors.New("failed to start the sprint")
+	}
+
+	return nil
+}
+
+// CompleteSprint closes the active sprint, carrying its unfinished tasks over
+// to the next sprint or, without one, back to the backlog.
+func (s *SprintService) CompleteSprint(ctx context.Context, orgId, managerId, projectId, id int, nextId *int) error {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	sprint, err := s.repo.GetSprint(projectId, id)
+	if err != nil {
+		return errors.New("sprint doesn't exist")
+	}
+
+	if sprint.Status != models.SprintActive {
+		return errors.New("only the active sprint can be completed")
+	}
+
+	if nextId != nil {
+		next, err := s.repo.GetSprint(projectId, *nextId)
+		if err != nil || next.Status != models.SprintPlanned {
+			return errors.New("tasks can be carried over only to a planned sprint of the project")
+		}
+	}
+
+	carried, err := s.repo.CompleteSprint(projectId, id, nextId)
+	if err != nil {
+		log.Println("failed to complete the sprint. Error is: ", err.Error())
+		return errors.New("failed to complete the sprint")
+	}
+
+	var to any
+	if nextId != nil {
+		to = *nextId
+	}
+	s.audit.changed(ctx, models.EntityTask, carried, "sprint_id", id, to)
+	s.feed.updated(managerId, carried, "sprint")
+
+	return nil
+}
+
+func (s *SprintService) AddTasks(ctx context.Context, orgId, managerId, projectId, id int, taskIds []int) error {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	sprint, err := s.repo.GetSprint(projectId, id)
+	if err != nil {
+		return errors.New("sprint doesn't exist")
+	}
+
+	if sprint.Status == models.SprintCompleted {
+		return errors.New("tasks can't be added to a completed sprint")
+	}
+
+	audit := s.audit.track(models.EntityTask, uniqueIds(taskIds)...)
+	added, err := addTasks(taskIds, func(ids []int) (int64, error) {
+		return s.repo.AddTasks(projectId, id, ids)
+	})
+	// Tasks that were found are added even when others weren't.
+	audit.record(ctx, models.AuditUpdate)
+	if err != nil {
+		return err
+	}
+
+	s.feed.updated(managerId, added, "sprint")
+
+	return nil
+}
+
+func (s *SprintService) RemoveTask(ctx context.Context, orgId, managerId, projectId, id, taskId int) error {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return err
+	}
+
+	sprint, err := s.repo.GetSprint(projectId, id)
+	if err != nil {
+		return errors.New("sprint doesn't exist")
+	}
+
+	if sprint.Status == models.SprintCompleted {
+		return errors.New("tasks can't be removed from a completed sprint")
+	}
+
+	audit := s.audit.track(models.EntityTask, taskId)
+	if err := s.repo.RemoveTask(id, taskId); err != nil {
+		log.Println("failed to remove the task from the sprint. Error is: ", err.Error())
+		return errors.New("task is not in the sprint")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	s.feed.updated(managerId, []int{taskId}, "sprint")
+
+	return nil
+}
+
+func (s *SprintService) GetBacklog(orgId, managerId, projectId int) (models.Tasks, error) {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.repo.GetBacklog(projectId)
+	if err != nil {
+		log.Println("failed to get the backlog. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (s *SprintService) GetVelocity(orgId, managerId, projectId int) (models.Velocity, error) {
+	if err := checkProject(s.project, orgId, managerId, projectId); err != nil {
+		return models.Velocity{}, err
+	}
+
+	sprints, err := s.repo.GetCompletedSprints(projectId)
+	if err != nil {
+		log.Println("failed to get the completed sprints. Error is: ", err.Error())
+		return models.Velocity{}, err
+	}
+
+	return models.Velocity{
+		ProjectId: projectId,
+		Average:   averageVelocity(sprints, velocitySprints),
+		Sprints:   sprints,
+	}, nil
+}
+
+// averageVelocity averages the completed tasks of the first n sprints, which
+// are the latest ones.
+func averageVelocity(sprints models.Sprints, n int) float64 {
+	if len(sprints) < n {
+		n = len(sprints)
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, sprint := range sprints[:n] {
+		total += sprint.CompletedTasks
+	}
+
+	return float64(total) / float64(n)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAverageVelocity(t *testing.T) {
+	testTable := []struct {
+		name     string
+		sprints  models.Sprints
+		expected float64
+	}{
+		{
+			name:     "no completed sprints",
+			expected: 0,
+		},
+		{
+			name:     "fewer sprints than the window",
+			sprints:  models.Sprints{{CompletedTasks: 4}, {CompletedTasks: 7}},
+			expected: 5.5,
+		},
+		{
+			name: "only the latest sprints count",
+			sprints: models.Sprints{{CompletedTasks: 6}, {CompletedTasks: 9}, {CompletedTasks: 3},
+				{CompletedTasks: 100}},
+			expected: 6,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, averageVelocity(test.sprints, velocitySprints))
+		})
+	}
+}
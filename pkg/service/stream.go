@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"time"
+)
+
+// membershipTTL is how long whether a streaming user takes part in a project
+// is trusted before it is checked again.
+const membershipTTL = time.Minute
+
+// memberships remembers for a while which projects a user takes part in, so
+// that the events streamed to them aren't checked against the database one
+// by one.
+type memberships struct {
+	check   func(projectId int) bool
+	checked map[int]membership
+}
+
+type membership struct {
+	member    bool
+	checkedAt time.Time
+}
+
+func newMemberships(check func(projectId int) bool) *memberships {
+	return &memberships{check: check, checked: make(map[int]membership)}
+}
+
+// visible tells whether the user may see the event: it is about them, or
+// about a project they take part in. Events that may change who takes part
+// in the project have it checked again.
+func (m *memberships) visible(event broker.Event, userId int, now time.Time) bool {
+	if event.UserId != nil && *event.UserId == userId {
+		return true
+	}
+
+	switch event.Type {
+	case models.ActivityProjectCreated, models.ActivityProjectUpdated, models.ActivityParticipantAdded,
+		models.ActivityParticipantJoined, models.ActivityParticipantRemoved:
+		delete(m.checked, event.ProjectId)
+	}
+
+	known, ok := m.checked[event.ProjectId]
+	if !ok || now.Sub(known.checkedAt) > membershipTTL {
+		known = membership{member: m.check(event.ProjectId), checkedAt: now}
+		m.checked[event.ProjectId] = known
+	}
+
+	return known.member
+}
+
+type StreamService struct {
+	broker broker.Broker
+	task   repository.Task
+}
+
+func NewStreamService(events broker.Broker, task repository.Task) *StreamService {
+	return &StreamService{broker: events, task: task}
+}
+
+// Subscribe streams the events of the organization the user may see until
+// ctx is done or the broker ends the subscription, and then closes the
+// channel.
+func (s *StreamService) Subscribe(ctx context.Context, orgId, userId int) <-chan broker.Event {
+	subscription := s.broker.Subscribe(orgId)
+	events := make(chan broker.Event)
+
+	go func() {
+		defer close(events)
+		defer subscription.Close()
+
+		projects := newMemberships(func(projectId int) bool {
+			return s.task.IsProjectMember(projectId, userId)
+		})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscription.C:
+				if !ok {
+					return
+				}
+
+				if !projects.visible(event, userId, time.Now()) {
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/broker"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMembershipsVisible(t *testing.T) {
+	userId, otherId := 5, 6
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	members := map[int]bool{1: true}
+	checks := 0
+	m := newMemberships(func(projectId int) bool {
+		checks++
+		return members[projectId]
+	})
+
+	assert.True(t, m.visible(broker.Event{ProjectId: 1, Type: models.ActivityTaskCreated}, userId, now))
+	assert.False(t, m.visible(broker.Event{ProjectId: 2, Type: models.ActivityTaskCreated}, userId, now))
+	assert.True(t, m.visible(broker.Event{ProjectId: 1, Type: models.ActivityTaskUpdated}, userId, now))
+	assert.Equal(t, 2, checks, "memberships are remembered")
+
+	assert.True(t, m.visible(broker.Event{ProjectId: 2, Type: models.ActivityTaskAssigned, UserId: &userId},
+		userId, now), "events about the user are visible")
+	assert.False(t, m.visible(broker.Event{ProjectId: 2, Type: models.ActivityTaskAssigned, UserId: &otherId},
+		userId, now))
+	assert.Equal(t, 2, checks)
+
+	members[2] = true
+	assert.True(t, m.visible(broker.Event{ProjectId: 2, Type: models.ActivityParticipantAdded, UserId: &otherId},
+		userId, now), "joining a project is noticed at once")
+	assert.Equal(t, 3, checks)
+
+	members[2] = false
+	assert.False(t, m.visible(broker.Event{ProjectId: 2, Type: models.ActivityParticipantRemoved}, userId, now),
+		"being removed from a project is noticed at once")
+	assert.Equal(t, 4, checks)
+	members[2] = true
+
+	members[1] = false
+	assert.True(t, m.visible(broker.Event{ProjectId: 1, Type: models.ActivityTaskUpdated}, userId,
+		now.Add(membershipTTL)))
+	assert.False(t, m.visible(broker.Event{ProjectId: 1, Type: models.ActivityTaskUpdated}, userId,
+		now.Add(membershipTTL+time.Second)), "leaving a project is noticed after a while")
+	assert.Equal(t, 5, checks)
+}
@@ -1,31 +1,188 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
 	"log"
 )
 
+var priorities = map[string]bool{
+	models.PriorityLow:    true,
+	models.PriorityMedium: true,
+	models.PriorityHigh:   true,
+	models.PriorityUrgent: true,
+}
+
+// ErrInvalidTask matches the errors about what is wrong with a task the
+// client sent. Their messages are meant for the client, unlike those of
+// failures to read or save the task.
+var ErrInvalidTask = errors.New("invalid task")
+
+// invalidTask is what is wrong with a task the client sent.
+type invalidTask struct {
+	err error
+}
+
+func (e invalidTask) Error() string {
+	return e.err.Error()
+}
+
+func (e invalidTask) Is(target error) bool {
+	return target == ErrInvalidTask
+}
+
 type TaskService struct {
-	repo repository.Task
+	repo    repository.Task
+	org     repository.Organization
+	labels  repository.Label
+	fields  repository.CustomField
+	board   repository.Board
+	project repository.Project
+	feed    *ActivityFeed
+	audit   *AuditLog
+}
+
+func NewTaskService(repo repository.Task, org repository.Organization, labels repository.Label,
+	fields repository.CustomField, board repository.Board, project repository.Project, feed *ActivityFeed,
+	audit *AuditLog) *TaskService {
+	return &TaskService{repo: repo, org: org, labels: labels, fields: fields, board: board, project: project,
+		feed: feed, audit: audit}
+}
+
+// checkTenant makes sure the task's project and assignees all belong to the
+// task's organization. A task is assigned to a user, a team or both.
+func (t *TaskService) checkTenant(task models.Task) error {
+	if !t.repo.ProjectInOrganization(task.OrganizationId, task.ProjectId) {
+		return invalidTask{errors.New("project doesn't exist")}
+	}
+
+	if task.ExecutorId == nil && task.TeamId == nil {
+		return invalidTask{errors.New("task must be assigned to a user or a team")}
+	}
+
+	if task.ExecutorId != nil {
+		if _, err := t.org.GetMember(task.OrganizationId, *task.ExecutorId); err != nil {
+			return invalidTask{errors.New("executor is not a member of the organization")}
+		}
+	}
+
+	if task.TeamId != nil && !t.repo.TeamInOrganization(task.OrganizationId, *task.TeamId) {
+		return invalidTask{errors.New("team doesn't exist")}
+	}
+
+	return nil
 }
 
-func NewTaskService(repo repository.Task) *TaskService {
-	return &TaskService{repo: repo}
+// checkPriority defaults an empty priority to medium.
+func checkPriority(task *models.Task) error {
+	if task.Priority == "" {
+		task.Priority = models.PriorityMedium
+	}
+
+	if !priorities[task.Priority] {
+		return invalidTask{errors.New("invalid priority")}
+	}
+
+	return nil
 }
 
-func (t *TaskService) CreateTask(task models.Task) (int, error) {
+// checkEstimates rejects negative estimates. A new original estimate with no
+// remaining one means no work is logged against it yet.
+func checkEstimates(task *models.Task) error {
+	if task.OriginalEstimate != nil && *task.OriginalEstimate < 0 ||
+		task.RemainingEstimate != nil && *task.RemainingEstimate < 0 {
+		return invalidTask{errors.New("estimates can't be negative")}
+	}
+
+	if task.RemainingEstimate == nil && task.OriginalEstimate != nil {
+		remaining := *task.OriginalEstimate
+		task.RemainingEstimate = &remaining
+	}
+
+	return nil
+}
+
+// checkCustomFields validates the custom field values against the fields of
+// the task's project.
+func (t *TaskService) checkCustomFields(task *models.Task) error {
+	fields, err := t.fields.GetCustomFields(task.ProjectId)
+	if err != nil {
+		log.Println("failed to get the custom fields of the project. Error is: ", err.Error())
+		return err
+	}
+
+	values, err := validateCustomFields(fields, task.CustomFields, func(userId int) bool {
+		_, err := t.org.GetMember(task.OrganizationId, userId)
+		return err == nil
+	})
+	if err != nil {
+		return invalidTask{err}
+	}
+	task.CustomFields = values
+
+	return nil
+}
+
+func (t *TaskService) CreateTask(ctx context.Context, task models.Task) (int, error) {
+	if err := checkPriority(&task); err != nil {
+		return -1, err
+	}
+
+	if err := t.checkTenant(task); err != nil {
+		log.Println("failed to create a new task. Error is: ", err.Error())
+		return -1, err
+	}
+
+	if err := t.checkCustomFields(&task); err != nil {
+		return -1, err
+	}
+
+	if task.Status == "" {
+		task.Status = models.StatusNotStarted
+	}
+
+	if err := checkEstimates(&task); err != nil {
+		return -1, err
+	}
+
 	id, err := t.repo.CreateTask(task)
 	if err != nil {
 		log.Println("failed to create a new task. Error is: ", err.Error())
 		return -1, err
 	}
 
+	t.audit.created(ctx, models.EntityTask, id)
+	t.feed.add(models.Activity{ActorId: task.ControllerId, Verb: models.ActivityTaskCreated, TaskId: &id,
+		UserId: task.ExecutorId})
+
 	return id, nil
 }
 
-func (t *TaskService) GetAllTasks(userId int) (models.Tasks, error) {
-	tasks, err := t.repo.GetTasks(userId)
+func (t *TaskService) GetAllTasks(orgId, userId int, filter models.TaskFilter) (models.Tasks, error) {
+	if filter.Priority != "" && !priorities[filter.Priority] {
+		return nil, invalidTask{errors.New("invalid priority")}
+	}
+
+	if len(filter.Fields) > 0 || filter.SortField != "" {
+		if filter.ProjectId == 0 {
+			return nil, invalidTask{errors.New("project_id is required to filter or sort by custom fields")}
+		}
+
+		fields, err := t.fields.GetCustomFields(filter.ProjectId)
+		if err != nil {
+			log.Println("failed to get the custom fields of the project. Error is: ", err.Error())
+			return nil, err
+		}
+
+		if err := customFieldFilter(fields, &filter); err != nil {
+			return nil, invalidTask{err}
+		}
+	}
+
+	tasks, err := t.repo.GetTasks(orgId, userId, filter)
 	if err != nil {
 		log.Println("failed to get the list of tasks. Error is: ", err.Error())
 		return nil, err
@@ -34,8 +191,8 @@ func (t *TaskService) GetAllTasks(userId int) (models.Tasks, error) {
 	return tasks, nil
 }
 
-func (t *TaskService) GetTaskById(userId, taskId int) (models.Task, error) {
-	task, err := t.repo.GetTaskById(userId, taskId)
+func (t *TaskService) GetTaskById(orgId, userId, taskId int) (models.Task, error) {
+	task, err := t.repo.GetTaskById(orgId, userId, taskId)
 	if err != nil {
 		log.Println("failed to get the task. Error is: ", err.Error())
 		return models.Task{}, err
@@ -44,32 +201,650 @@ func (t *TaskService) GetTaskById(userId, taskId int) (models.Task, error) {
 	return task, nil
 }
 
-func (t *TaskService) UpdateTask(task models.Task) error {
-	err := t.repo.UpdateTask(task)
+func (t *TaskService) UpdateTask(ctx context.Context, task models.Task) error {
+	current, err := t.repo.GetTaskById(task.OrganizationId, task.ControllerId, task.ID)
 	if err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return err
+	}
+
+	if task.ProjectId != current.ProjectId {
+		return invalidTask{errors.New("tasks are moved to another project with the move operation")}
+	}
+
+	if err := checkPriority(&task); err != nil {
+		return err
+	}
+
+	if err := t.checkTenant(task); err != nil {
 		log.Println("failed to update the task. Error is: ", err.Error())
 		return err
 	}
 
+	if err := t.checkCustomFields(&task); err != nil {
+		return err
+	}
+
+	if task.Status == "" {
+		task.Status = models.StatusNotStarted
+	}
+
+	task.SprintId = current.SprintId
+	task.MilestoneId = current.MilestoneId
+	task.RecurrenceId = current.RecurrenceId
+
+	if task.OriginalEstimate == nil {
+		task.OriginalEstimate = current.OriginalEstimate
+	}
+	if task.RemainingEstimate == nil {
+		task.RemainingEstimate = current.RemainingEstimate
+	}
+
+	if err := checkEstimates(&task); err != nil {
+		return err
+	}
+
+	audit := t.audit.track(models.EntityTask, task.ID)
+	err = t.repo.UpdateTask(task)
+	if err != nil {
+		log.Println("failed to update the task. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	if task.Status != current.Status {
+		t.feed.task(task.ControllerId, models.ActivityTaskStatusChanged, task.ID,
+			models.JSONMap{"from": current.Status, "to": task.Status})
+	}
+
+	if task.ExecutorId != nil && (current.ExecutorId == nil || *current.ExecutorId != *task.ExecutorId) {
+		t.feed.add(models.Activity{ActorId: task.ControllerId, Verb: models.ActivityTaskAssigned, TaskId: &task.ID,
+			UserId: task.ExecutorId})
+	}
+
+	if fields := updatedFields(current, task); len(fields) > 0 {
+		t.feed.task(task.ControllerId, models.ActivityTaskUpdated, task.ID, models.JSONMap{"fields": fields})
+	}
+
 	return nil
 }
 
-func (t *TaskService) DeleteTask(userId, taskId int) error {
-	err := t.repo.DeleteTask(userId, taskId)
+func (t *TaskService) DeleteTask(ctx context.Context, orgId, userId, taskId int) error {
+	audit := t.audit.track(models.EntityTask, taskId)
+	err := t.repo.DeleteTask(orgId, userId, taskId)
 	if err != nil {
 		log.Println("failed to delete the task. Error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditDelete)
+
+	t.feed.task(userId, models.ActivityTaskDeleted, taskId, nil)
 
 	return nil
 }
 
-func (t *TaskService) RestoreTask(userId, taskId int) error {
-	err := t.repo.RestoreTask(userId, taskId)
+func (t *TaskService) RestoreTask(ctx context.Context, orgId, userId, taskId int) error {
+	audit := t.audit.track(models.EntityTask, taskId)
+	err := t.repo.RestoreTask(orgId, userId, taskId)
 	if err != nil {
 		log.Println("failed to restore the task. Error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditRestore)
+
+	t.feed.task(userId, models.ActivityTaskRestored, taskId, nil)
+
+	return nil
+}
+
+var taskRoles = map[string]bool{
+	models.TaskRoleAssignee: true,
+	models.TaskRoleReviewer: true,
+	models.TaskRoleWatcher:  true,
+}
+
+func (t *TaskService) SetAssignee(ctx context.Context, orgId, userId int, assignee models.TaskAssignee) error {
+	if _, err := t.repo.GetTaskById(orgId, userId, assignee.TaskId); err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return errors.New("task doesn't exist")
+	}
+
+	if assignee.Role == "" {
+		assignee.Role = models.TaskRoleAssignee
+	}
+
+	if !taskRoles[assignee.Role] {
+		return errors.New("invalid role")
+	}
+
+	if _, err := t.org.GetMember(orgId, assignee.UserId); err != nil {
+		return errors.New("user is not a member of the organization")
+	}
+
+	audit := t.audit.track(models.EntityTask, assignee.TaskId)
+	if err := t.repo.SetAssignee(assignee); err != nil {
+		log.Println("failed to set the assignee of the task. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	t.feed.add(models.Activity{ActorId: userId, Verb: models.ActivityTaskAssigned, TaskId: &assignee.TaskId,
+		UserId: &assignee.UserId, Data: models.JSONMap{"role": assignee.Role}})
+
+	return nil
+}
+
+func (t *TaskService) RemoveAssignee(ctx context.Context, orgId, userId, taskId, assigneeId int) error {
+	if _, err := t.repo.GetTaskById(orgId, userId, taskId); err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return errors.New("task doesn't exist")
+	}
+
+	audit := t.audit.track(models.EntityTask, taskId)
+	if err := t.repo.RemoveAssignee(taskId, assigneeId); err != nil {
+		log.Println("failed to remove the assignee of the task. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	t.feed.add(models.Activity{ActorId: userId, Verb: models.ActivityTaskUnassigned, TaskId: &taskId,
+		UserId: &assigneeId})
+
+	return nil
+}
+
+func (t *TaskService) GetAssignees(orgId, userId, taskId int) ([]models.TaskAssignee, error) {
+	if _, err := t.repo.GetTaskById(orgId, userId, taskId); err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return nil, errors.New("task doesn't exist")
+	}
+
+	assignees, err := t.repo.GetAssignees(taskId)
+	if err != nil {
+		log.Println("failed to get the assignees of the task. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return assignees, nil
+}
+
+// SetLabels replaces the labels of the task. Only labels of the task's
+// project can be used.
+func (t *TaskService) SetLabels(ctx context.Context, orgId, userId, taskId int, labelIds []int) error {
+	task, err := t.repo.GetTaskById(orgId, userId, taskId)
+	if err != nil {
+		log.Println("you don't have such a task. Error is: ", err.Error())
+		return errors.New("task doesn't exist")
+	}
+
+	ids := uniqueIds(labelIds)
+
+	if len(ids) > 0 {
+		count, err := t.labels.CountProjectLabels(task.ProjectId, ids)
+		if err != nil {
+			log.Println("failed to check the labels of the task. Error is: ", err.Error())
+			return err
+		}
+
+		if count != int64(len(ids)) {
+			return errors.New("label doesn't exist in the project")
+		}
+	}
+
+	audit := t.audit.track(models.EntityTask, taskId)
+	if err := t.labels.SetTaskLabels(taskId, ids); err != nil {
+		log.Println("failed to set the labels of the task. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	t.feed.task(userId, models.ActivityTaskUpdated, taskId, models.JSONMap{"fields": []string{"labels"}})
+
+	return nil
+}
+
+// checkTarget makes sure the user manages the project the task goes to and
+// that its executor and team take part in it. Custom fields the project
+// doesn't have are dropped.
+func (t *TaskService) checkTarget(orgId, userId int, task *models.Task) error {
+	if _, err := t.project.GetProjectById(orgId, userId, task.ProjectId); err != nil {
+		return errors.New("target project doesn't exist or you don't manage it")
+	}
+
+	if task.ExecutorId != nil && !t.repo.IsProjectMember(task.ProjectId, *task.ExecutorId) {
+		return errors.New("executor doesn't take part in the target project")
+	}
+
+	if task.TeamId != nil && !t.repo.TeamInProject(task.ProjectId, *task.TeamId) {
+		return errors.New("team doesn't take part in the target project")
+	}
+
+	fields, err := t.fields.GetCustomFields(task.ProjectId)
+	if err != nil {
+		log.Println("failed to get the custom fields of the project. Error is: ", err.Error())
+		return err
+	}
+
+	values := models.JSONMap{}
+	for _, field := range fields {
+		if value, ok := task.CustomFields[field.Key]; ok {
+			values[field.Key] = value
+		}
+	}
+	task.CustomFields = values
+
+	return t.checkCustomFields(task)
+}
+
+// MoveTask moves the task to another project. It leaves its sprint,
+// milestone and labels behind and keeps its checklist and comments only if
+// asked to.
+func (t *TaskService) MoveTask(ctx context.Context, orgId, userId, taskId, projectId int,
+	options models.TransferOptions) error {
+	task, err := t.repo.GetTaskById(orgId, userId, taskId)
+	if err != nil {
+		return errors.New("task doesn't exist")
+	}
+
+	fromProjectId := task.ProjectId
+	if projectId == fromProjectId {
+		return errors.New("task is already in the project")
+	}
+
+	task.OrganizationId = orgId
+	task.ProjectId = projectId
+	if err := t.checkTarget(orgId, userId, &task); err != nil {
+		return err
+	}
+
+	if err := t.checkTenant(task); err != nil {
+		return err
+	}
+
+	change := models.TaskChange{TaskId: taskId, Columns: map[string]any{
+		"project_id":    task.ProjectId,
+		"sprint_id":     nil,
+		"milestone_id":  nil,
+		"custom_fields": task.CustomFields,
+	}}
+
+	audit := t.audit.track(models.EntityTask, taskId)
+	err = t.repo.MoveToProject(orgId, userId, change, models.TaskTransfer{
+		TaskId:        taskId,
+		Kind:          models.TransferMove,
+		FromProjectId: fromProjectId,
+		ToProjectId:   projectId,
+		ActorId:       userId,
+	}, options)
+	if err != nil {
+		log.Println("failed to move the task. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	t.feed.task(userId, models.ActivityTaskMoved, taskId, nil)
 
 	return nil
 }
+
+// CopyTask makes a copy of the task in another project, or in its own one,
+// optionally with its checklist and comments. The copy starts with no time
+// logged.
+func (t *TaskService) CopyTask(ctx context.Context, orgId, userId, taskId, projectId int,
+	options models.TransferOptions) (int, error) {
+	original, err := t.repo.GetTaskById(orgId, userId, taskId)
+	if err != nil {
+		return -1, errors.New("task doesn't exist")
+	}
+
+	task := models.Task{
+		OrganizationId:    orgId,
+		Title:             original.Title,
+		Description:       original.Description,
+		ControllerId:      userId,
+		ExecutorId:        original.ExecutorId,
+		TeamId:            original.TeamId,
+		CustomFields:      original.CustomFields,
+		Status:            original.Status,
+		Priority:          original.Priority,
+		OriginalEstimate:  original.OriginalEstimate,
+		RemainingEstimate: original.OriginalEstimate,
+		ProjectId:         projectId,
+		Deadline:          original.Deadline,
+		IsActive:          true,
+	}
+
+	if err := t.checkTarget(orgId, userId, &task); err != nil {
+		return -1, err
+	}
+
+	if err := t.checkTenant(task); err != nil {
+		return -1, err
+	}
+
+	id, err := t.repo.CopyToProject(task, models.TaskTransfer{
+		TaskId:        taskId,
+		Kind:          models.TransferCopy,
+		FromProjectId: original.ProjectId,
+		ToProjectId:   projectId,
+		ActorId:       userId,
+	}, options)
+	if err != nil {
+		log.Println("failed to copy the task. Error is: ", err.Error())
+		return -1, err
+	}
+	t.audit.created(ctx, models.EntityTask, id)
+
+	t.feed.task(userId, models.ActivityTaskCopied, id, nil)
+
+	return id, nil
+}
+
+func (t *TaskService) GetTransfers(orgId, userId, taskId int) ([]models.TaskTransfer, error) {
+	if _, err := t.repo.GetTaskById(orgId, userId, taskId); err != nil {
+		return nil, errors.New("task doesn't exist")
+	}
+
+	transfers, err := t.repo.GetTransfers(taskId)
+	if err != nil {
+		log.Println("failed to get the transfers of the task. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// maxBulkTasks bounds how many tasks one bulk update can touch.
+const maxBulkTasks = 500
+
+// columnSlots counts the places a bulk update takes in board columns with a
+// WIP limit, so the limit covers the whole batch. Tasks the batch moves out
+// of a column make room in it, whatever their order in the batch.
+type columnSlots struct {
+	board repository.Board
+	left  map[boardColumn]int
+	moves []columnMove
+}
+
+type boardColumn struct {
+	projectId int
+	status    string
+}
+
+type columnMove struct {
+	taskId int
+	to     boardColumn
+}
+
+func (c *columnSlots) move(taskId int, from, to boardColumn) {
+	c.left[from]++
+	c.moves = append(c.moves, columnMove{taskId: taskId, to: to})
+}
+
+// check returns why the tasks that don't fit in their new column can't be
+// moved, by task id.
+func (c *columnSlots) check() map[int]error {
+	failed := map[int]error{}
+	room := map[boardColumn]int{}
+	for _, move := range c.moves {
+		left, ok := room[move.to]
+		if !ok {
+			var err error
+			if left, err = c.room(move.to); err != nil {
+				failed[move.taskId] = err
+				continue
+			}
+		}
+
+		if left == 0 {
+			failed[move.taskId] = repository.ErrWipLimit
+		} else if left > 0 {
+			left--
+		}
+		room[move.to] = left
+	}
+
+	return failed
+}
+
+// room tells how many tasks the column still takes, or -1 when it has no
+// limit.
+func (c *columnSlots) room(to boardColumn) (int, error) {
+	columns, err := c.board.GetColumns(to.projectId)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, column := range columns {
+		if column.Status != to.status || column.WipLimit == 0 {
+			continue
+		}
+
+		count, err := c.board.CountColumnTasks(to.projectId, to.status)
+		if err != nil {
+			return 0, err
+		}
+
+		room := column.WipLimit - int(count) + c.left[to]
+		if room < 0 {
+			room = 0
+		}
+
+		return room, nil
+	}
+
+	return -1, nil
+}
+
+// BulkUpdate checks the changes against every task and applies them in one
+// go, unless it is a dry run or any task fails.
+func (t *TaskService) BulkUpdate(ctx context.Context, orgId, userId int,
+	bulk models.BulkTaskUpdate) (models.BulkTaskResults, error) {
+	results := models.BulkTaskResults{DryRun: bulk.DryRun}
+
+	if len(bulk.TaskIds) == 0 {
+		return results, errors.New("no tasks to update")
+	}
+
+	if len(bulk.TaskIds) > maxBulkTasks {
+		return results, fmt.Errorf("at most %d tasks can be updated at once", maxBulkTasks)
+	}
+
+	changes := bulk.Status != "" || bulk.ExecutorId != nil || bulk.TeamId != nil || bulk.ProjectId != 0 ||
+		bulk.SetLabels
+	if !changes && !bulk.Delete {
+		return results, errors.New("nothing to change")
+	}
+
+	if changes && bulk.Delete {
+		return results, errors.New("deleting can't be combined with other changes")
+	}
+
+	bulk.LabelIds = uniqueIds(bulk.LabelIds)
+	slots := &columnSlots{board: t.board, left: map[boardColumn]int{}}
+
+	var taskChanges []models.TaskChange
+	var changed []int
+	failed := false
+	for _, taskId := range uniqueIds(bulk.TaskIds) {
+		result := models.BulkTaskResult{TaskId: taskId, Ok: true}
+
+		change, err := t.bulkChange(orgId, userId, taskId, bulk, slots)
+		if err != nil {
+			result.Ok, result.Error = false, err.Error()
+			failed = true
+		} else {
+			taskChanges = append(taskChanges, change)
+			changed = append(changed, taskId)
+		}
+
+		results.Results = append(results.Results, result)
+	}
+
+	if full := slots.check(); len(full) > 0 {
+		for i, result := range results.Results {
+			if err, ok := full[result.TaskId]; ok {
+				results.Results[i] = models.BulkTaskResult{TaskId: result.TaskId, Error: err.Error()}
+			}
+		}
+		failed = true
+	}
+
+	if failed || bulk.DryRun {
+		return results, nil
+	}
+
+	audit := t.audit.track(models.EntityTask, changed...)
+	if err := t.repo.BulkUpdateTasks(orgId, userId, taskChanges); err != nil {
+		log.Println("failed to bulk update tasks. Error is: ", err.Error())
+		if errors.Is(err, repository.ErrWipLimit) {
+			return results, err
+		}
+		return results, errors.New("failed to update the tasks")
+	}
+	results.Applied = true
+	if bulk.Delete {
+		audit.record(ctx, models.AuditDelete)
+	} else {
+		audit.record(ctx, models.AuditUpdate)
+	}
+
+	for _, change := range taskChanges {
+		t.feedBulkChange(userId, change)
+	}
+
+	return results, nil
+}
+
+// feedBulkChange adds what the bulk update did to one task to the feed.
+func (t *TaskService) feedBulkChange(userId int, change models.TaskChange) {
+	if change.Delete {
+		t.feed.task(userId, models.ActivityTaskDeleted, change.TaskId, nil)
+		return
+	}
+
+	_, moved := change.Columns["project_id"]
+	if moved {
+		t.feed.task(userId, models.ActivityTaskMoved, change.TaskId, nil)
+	} else if status, ok := change.Columns["status"]; ok {
+		t.feed.task(userId, models.ActivityTaskStatusChanged, change.TaskId, models.JSONMap{"to": status})
+	}
+
+	if executorId, ok := change.Columns["executor_id"].(int); ok {
+		t.feed.add(models.Activity{ActorId: userId, Verb: models.ActivityTaskAssigned, TaskId: &change.TaskId,
+			UserId: &executorId})
+	}
+
+	var fields []string
+	if _, ok := change.Columns["team_id"]; ok {
+		fields = append(fields, "team")
+	}
+	if change.SetLabels && (!moved || len(change.LabelIds) > 0) {
+		fields = append(fields, "labels")
+	}
+	if len(fields) > 0 {
+		t.feed.task(userId, models.ActivityTaskUpdated, change.TaskId, models.JSONMap{"fields": fields})
+	}
+}
+
+// bulkChange works out what the bulk update does to one task. Moving a task
+// to another project takes it out of its sprint and milestone, drops its
+// labels unless new ones are given and is recorded like a single move.
+func (t *TaskService) bulkChange(orgId, userId, taskId int, bulk models.BulkTaskUpdate,
+	slots *columnSlots) (models.TaskChange, error) {
+	current, err := t.repo.GetTaskById(orgId, userId, taskId)
+	if err != nil {
+		return models.TaskChange{}, errors.New("task doesn't exist")
+	}
+
+	change := models.TaskChange{TaskId: taskId, Columns: map[string]any{}}
+	if bulk.Delete {
+		change.Delete = true
+		return change, nil
+	}
+
+	task := current
+	task.OrganizationId = orgId
+
+	if bulk.ExecutorId != nil {
+		task.ExecutorId = bulk.ExecutorId
+		change.Columns["executor_id"] = *task.ExecutorId
+	}
+
+	if bulk.TeamId != nil {
+		task.TeamId = bulk.TeamId
+		change.Columns["team_id"] = *task.TeamId
+	}
+
+	moved := bulk.ProjectId != 0 && bulk.ProjectId != current.ProjectId
+	if moved {
+		task.ProjectId = bulk.ProjectId
+		if err := t.checkTarget(orgId, userId, &task); err != nil {
+			return models.TaskChange{}, err
+		}
+
+		change.Columns["project_id"] = task.ProjectId
+		change.Columns["sprint_id"] = nil
+		change.Columns["milestone_id"] = nil
+		change.Columns["custom_fields"] = task.CustomFields
+		change.Transfer = &models.TaskTransfer{
+			TaskId:        taskId,
+			Kind:          models.TransferMove,
+			FromProjectId: current.ProjectId,
+			ToProjectId:   task.ProjectId,
+			ActorId:       userId,
+		}
+		change.Options = bulk.Transfer
+	}
+
+	if moved || bulk.ExecutorId != nil || bulk.TeamId != nil {
+		if err := t.checkTenant(task); err != nil {
+			return models.TaskChange{}, err
+		}
+	}
+
+	if bulk.Status != "" {
+		task.Status = bulk.Status
+	}
+
+	if moved || task.Status != current.Status {
+		slots.move(taskId, boardColumn{projectId: current.ProjectId, status: current.Status},
+			boardColumn{projectId: task.ProjectId, status: task.Status})
+		change.Columns["status"] = task.Status
+		change.Place = true
+	}
+
+	if bulk.SetLabels || moved {
+		change.SetLabels = true
+		if bulk.SetLabels {
+			change.LabelIds = bulk.LabelIds
+		}
+	}
+
+	if len(change.LabelIds) > 0 {
+		count, err := t.labels.CountProjectLabels(task.ProjectId, change.LabelIds)
+		if err != nil {
+			return models.TaskChange{}, err
+		}
+
+		if count != int64(len(change.LabelIds)) {
+			return models.TaskChange{}, errors.New("label doesn't exist in the project")
+		}
+	}
+
+	return change, nil
+}
+
+// uniqueIds drops repeated ids and keeps the order of the rest.
+func uniqueIds(ids []int) []int {
+	unique := make([]int, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type taskMocks struct {
+	repo    *mock_repository.MockTask
+	org     *mock_repository.MockOrganization
+	labels  *mock_repository.MockLabel
+	fields  *mock_repository.MockCustomField
+	board   *mock_repository.MockBoard
+	project *mock_repository.MockProject
+}
+
+// newTestTaskService builds a task service on mocks, a test feed and a test audit log.
+func newTestTaskService(c *gomock.Controller) (*TaskService, taskMocks) {
+	m := taskMocks{
+		repo:    mock_repository.NewMockTask(c),
+		org:     mock_repository.NewMockOrganization(c),
+		labels:  mock_repository.NewMockLabel(c),
+		fields:  mock_repository.NewMockCustomField(c),
+		board:   mock_repository.NewMockBoard(c),
+		project: mock_repository.NewMockProject(c),
+	}
+
+	feed, _ := testFeed(c)
+	audit, _ := testAudit(c)
+
+	return NewTaskService(m.repo, m.org, m.labels, m.fields, m.board, m.project, feed, audit), m
+}
+
+func TestTaskService_CheckTarget(t *testing.T) {
+	executorId, teamId := 5, 6
+
+	testTable := []struct {
+		name     string
+		task     models.Task
+		managed  bool
+		member   bool
+		inTeam   bool
+		fields   models.CustomFields
+		expected models.JSONMap
+		err      string
+	}{
+		{
+			name:    "project the user doesn't manage",
+			task:    models.Task{OrganizationId: 1, ProjectId: 3, ExecutorId: &executorId},
+			managed: false,
+			err:     "target project doesn't exist or you don't manage it",
+		},
+		{
+			name:    "executor outside the project",
+			task:    models.Task{OrganizationId: 1, ProjectId: 3, ExecutorId: &executorId},
+			managed: true,
+			member:  false,
+			err:     "executor doesn't take part in the target project",
+		},
+		{
+			name:    "team outside the project",
+			task:    models.Task{OrganizationId: 1, ProjectId: 3, TeamId: &teamId},
+			managed: true,
+			inTeam:  false,
+			err:     "team doesn't take part in the target project",
+		},
+		{
+			name: "fields the project doesn't have are dropped",
+			task: models.Task{OrganizationId: 1, ProjectId: 3, ExecutorId: &executorId,
+				CustomFields: models.JSONMap{"size": "L", "colour": "red"}},
+			managed:  true,
+			member:   true,
+			fields:   models.CustomFields{{ProjectId: 3, Key: "size", Type: models.FieldText}},
+			expected: models.JSONMap{"size": "L"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, m := newTestTaskService(c)
+
+			if test.managed {
+				m.project.EXPECT().GetProjectById(1, 2, 3).Return(models.Project{ID: 3}, nil)
+			} else {
+				m.project.EXPECT().GetProjectById(1, 2, 3).Return(models.Project{}, errors.New("record not found"))
+			}
+			if test.managed && test.task.ExecutorId != nil {
+				m.repo.EXPECT().IsProjectMember(3, executorId).Return(test.member)
+			}
+			if test.managed && test.task.TeamId != nil {
+				m.repo.EXPECT().TeamInProject(3, teamId).Return(test.inTeam)
+			}
+			if test.err == "" {
+				m.fields.EXPECT().GetCustomFields(3).Return(test.fields, nil).Times(2)
+			}
+
+			task := test.task
+			err := s.checkTarget(1, 2, &task)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, task.CustomFields)
+			}
+		})
+	}
+}
+
+// expectTarget lets the task through checkTarget and checkTenant for the
+// project.
+func (m taskMocks) expectTarget(projectId, executorId int) {
+	m.project.EXPECT().GetProjectById(1, 2, projectId).Return(models.Project{ID: projectId}, nil)
+	m.repo.EXPECT().IsProjectMember(projectId, executorId).Return(true)
+	m.fields.EXPECT().GetCustomFields(projectId).Return(nil, nil).Times(2)
+	m.repo.EXPECT().ProjectInOrganization(1, projectId).Return(true)
+	m.org.EXPECT().GetMember(1, executorId).Return(models.OrganizationMember{}, nil)
+}
+
+func TestTaskService_MoveTask(t *testing.T) {
+	executorId, sprintId := 5, 8
+	options := models.TransferOptions{Checklist: true}
+
+	t.Run("to the same project", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		s, m := newTestTaskService(c)
+		m.repo.EXPECT().GetTaskById(1, 2, 4).Return(models.Task{ID: 4, ProjectId: 3, ExecutorId: &executorId}, nil)
+
+		assert.EqualError(t, s.MoveTask(context.Background(), 1, 2, 4, 3, options), "task is already in the project")
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		s, m := newTestTaskService(c)
+		m.repo.EXPECT().GetTaskById(1, 2, 4).Return(models.Task{}, errors.New("record not found"))
+
+		assert.EqualError(t, s.MoveTask(context.Background(), 1, 2, 4, 7, options), "task doesn't exist")
+	})
+
+	t.Run("to another project", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		s, m := newTestTaskService(c)
+		m.repo.EXPECT().GetTaskById(1, 2, 4).Return(models.Task{ID: 4, ProjectId: 3, ExecutorId: &executorId,
+			SprintId: &sprintId}, nil)
+		m.expectTarget(7, executorId)
+		m.repo.EXPECT().MoveToProject(1, 2, models.TaskChange{TaskId: 4, Columns: map[string]any{
+			"project_id":    7,
+			"sprint_id":     nil,
+			"milestone_id":  nil,
+			"custom_fields": models.JSONMap{},
+		}}, models.TaskTransfer{TaskId: 4, Kind: models.TransferMove, FromProjectId: 3, ToProjectId: 7, ActorId: 2},
+			options).Return(nil)
+
+		assert.NoError(t, s.MoveTask(context.Background(), 1, 2, 4, 7, options))
+	})
+}
+
+func TestTaskService_CopyTask(t *testing.T) {
+	executorId, sprintId := 5, 8
+	estimate, remaining := 120, 30
+	options := models.TransferOptions{}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, m := newTestTaskService(c)
+	m.repo.EXPECT().GetTaskById(1, 2, 4).Return(models.Task{ID: 4, ProjectId: 3, Title: "Test", ControllerId: 9,
+		ExecutorId: &executorId, SprintId: &sprintId, Status: models.StatusInProgress, OriginalEstimate: &estimate,
+		RemainingEstimate: &remaining, Deadline: "2030-01-01 00:00"}, nil)
+	m.expectTarget(7, executorId)
+	m.repo.EXPECT().CopyToProject(models.Task{OrganizationId: 1, Title: "Test", ControllerId: 2,
+		ExecutorId: &executorId, CustomFields: models.JSONMap{}, Status: models.StatusInProgress,
+		OriginalEstimate: &estimate, RemainingEstimate: &estimate, ProjectId: 7, Deadline: "2030-01-01 00:00", IsActive: true},
+		models.TaskTransfer{TaskId: 4, Kind: models.TransferCopy, FromProjectId: 3, ToProjectId: 7, ActorId: 2},
+		options).Return(10, nil)
+
+	id, err := s.CopyTask(context.Background(), 1, 2, 4, 7, options)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, id)
+}
+
+func TestColumnSlots(t *testing.T) {
+	todo := boardColumn{projectId: 3, status: models.StatusNotStarted}
+	doing := boardColumn{projectId: 3, status: models.StatusInProgress}
+
+	testTable := []struct {
+		name     string
+		columns  []models.BoardColumn
+		count    int64
+		moves    map[int]boardColumn
+		expected map[int]error
+	}{
+		{
+			name:     "status without a column",
+			moves:    map[int]boardColumn{1: doing, 2: doing, 3: doing},
+			expected: map[int]error{},
+		},
+		{
+			name:     "column without a limit",
+			columns:  []models.BoardColumn{{Status: models.StatusInProgress}},
+			moves:    map[int]boardColumn{1: doing, 2: doing},
+			expected: map[int]error{},
+		},
+		{
+			name:     "room left in the column",
+			columns:  []models.BoardColumn{{Status: models.StatusInProgress, WipLimit: 3}},
+			count:    1,
+			moves:    map[int]boardColumn{1: doing, 2: doing},
+			expected: map[int]error{},
+		},
+		{
+			name:     "column fills up",
+			columns:  []models.BoardColumn{{Status: models.StatusInProgress, WipLimit: 3}},
+			count:    2,
+			moves:    map[int]boardColumn{1: doing, 2: doing},
+			expected: map[int]error{2: repository.ErrWipLimit},
+		},
+		{
+			name:     "column over its limit",
+			columns:  []models.BoardColumn{{Status: models.StatusInProgress, WipLimit: 1}},
+			count:    2,
+			moves:    map[int]boardColumn{1: doing},
+			expected: map[int]error{1: repository.ErrWipLimit},
+		},
+		{
+			name:     "tasks moved out of a full column make room",
+			columns:  []models.BoardColumn{{Status: models.StatusInProgress, WipLimit: 2}},
+			count:    2,
+			moves:    map[int]boardColumn{1: doing, 2: todo},
+			expected: map[int]error{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			board := mock_repository.NewMockBoard(c)
+			board.EXPECT().GetColumns(3).Return(test.columns, nil).AnyTimes()
+			board.EXPECT().CountColumnTasks(3, gomock.Any()).Return(test.count, nil).AnyTimes()
+
+			slots := &columnSlots{board: board, left: map[boardColumn]int{}}
+			// A task moving to one column leaves the other. Tasks move in
+			// the order of their ids.
+			for taskId := 1; taskId <= len(test.moves); taskId++ {
+				to := test.moves[taskId]
+				from := todo
+				if to == todo {
+					from = doing
+				}
+				slots.move(taskId, from, to)
+			}
+
+			assert.Equal(t, test.expected, slots.check())
+		})
+	}
+}
+
+func TestTaskService_BulkUpdate(t *testing.T) {
+	executorId := 5
+	task := func(id, projectId int) models.Task {
+		return models.Task{ID: id, ProjectId: projectId, ExecutorId: &executorId, Status: models.StatusNotStarted}
+	}
+	moved := func(taskId int) models.TaskChange {
+		return models.TaskChange{TaskId: taskId, SetLabels: true, Columns: map[string]any{
+			"project_id":    7,
+			"sprint_id":     nil,
+			"milestone_id":  nil,
+			"custom_fields": models.JSONMap{},
+			"status":        models.StatusNotStarted,
+		}, Place: true, Transfer: &models.TaskTransfer{TaskId: taskId, Kind: models.TransferMove, FromProjectId: 3,
+			ToProjectId: 7, ActorId: 2}, Options: models.TransferOptions{Checklist: true}}
+	}
+
+	testTable := []struct {
+		name     string
+		bulk     models.BulkTaskUpdate
+		mock     func(m taskMocks)
+		expected models.BulkTaskResults
+		err      string
+	}{
+		{
+			name: "no tasks",
+			bulk: models.BulkTaskUpdate{Status: models.StatusDone},
+			err:  "no tasks to update",
+		},
+		{
+			name: "nothing to change",
+			bulk: models.BulkTaskUpdate{TaskIds: []int{4}},
+			err:  "nothing to change",
+		},
+		{
+			name: "deleting with other changes",
+			bulk: models.BulkTaskUpdate{TaskIds: []int{4}, Status: models.StatusDone, Delete: true},
+			err:  "deleting can't be combined with other changes",
+		},
+		{
+			name: "dry run checks every task but saves nothing",
+			bulk: models.BulkTaskUpdate{TaskIds: []int{4, 5, 4}, Status: models.StatusDone, DryRun: true},
+			mock: func(m taskMocks) {
+				m.repo.EXPECT().GetTaskById(1, 2, 4).Return(task(4, 3), nil)
+				m.repo.EXPECT().GetTaskById(1, 2, 5).Return(task(5, 3), nil)
+				m.board.EXPECT().GetColumns(3).Return(nil, nil)
+			},
+			expected: models.BulkTaskResults{DryRun: true, Results: []models.BulkTaskResult{
+				{TaskId: 4, Ok: true}, {TaskId: 5, Ok: true}}},
+		},
+		{
+			name: "one failing task stops them all",
+			bulk: models.BulkTaskUpdate{TaskIds: []int{4, 5, 6}, Status: models.StatusDone},
+			mock: func(m taskMocks) {
+				m.repo.EXPECT().GetTaskById(1, 2, 4).Return(task(4, 3), nil)
+				m.repo.EXPECT().GetTaskById(1, 2, 5).Return(models.Task{}, errors.New("record not found"))
+				m.repo.EXPECT().GetTaskById(1, 2, 6).Return(task(6, 3), nil)
+				m.board.EXPECT().GetColumns(3).Return([]models.BoardColumn{{Status: models.StatusDone,
+					WipLimit: 1}}, nil)
+				m.board.EXPECT().CountColumnTasks(3, models.StatusDone).Return(int64(0), nil)
+			},
+			expected: models.BulkTaskResults{Results: []models.BulkTaskResult{
+				{TaskId: 4, Ok: true},
+				{TaskId: 5, Error: "task doesn't exist"},
+				{TaskId: 6, Error: repository.ErrWipLimit.Error()},
+			}},
+		},
+		{
+			name: "tasks moved to another project are transferred",
+			bulk: models.BulkTaskUpdate{TaskIds: []int{4, 6}, ProjectId: 7,
+				Transfer: models.TransferOptions{Checklist: true}},
+			mock: func(m taskMocks) {
+				m.repo.EXPECT().GetTaskById(1, 2, 4).Return(task(4, 3), nil)
+				m.repo.EXPECT().GetTaskById(1, 2, 6).Return(task(6, 3), nil)
+				m.project.EXPECT().GetProjectById(1, 2, 7).Return(models.Project{ID: 7}, nil).Times(2)
+				m.repo.EXPECT().IsProjectMember(7, executorId).Return(true).Times(2)
+				m.fields.EXPECT().GetCustomFields(7).Return(nil, nil).Times(4)
+				m.repo.EXPECT().ProjectInOrganization(1, 7).Return(true).Times(2)
+				m.org.EXPECT().GetMember(1, executorId).Return(models.OrganizationMember{}, nil).Times(2)
+				m.board.EXPECT().GetColumns(7).Return(nil, nil)
+
+				m.repo.EXPECT().BulkUpdateTasks(1, 2, []models.TaskChange{moved(4), moved(6)}).Return(nil)
+			},
+			expected: models.BulkTaskResults{Applied: true, Results: []models.BulkTaskResult{
+				{TaskId: 4, Ok: true}, {TaskId: 6, Ok: true}}},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, m := newTestTaskService(c)
+			if test.mock != nil {
+				test.mock(m)
+			}
+
+			results, err := s.BulkUpdate(context.Background(), 1, 2, test.bulk)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			test.expected.DryRun = test.bulk.DryRun
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
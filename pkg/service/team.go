@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"strings"
+)
+
+type TeamService struct {
+	repo    repository.Team
+	org     repository.Organization
+	project repository.Project
+	feed    *ActivityFeed
+	audit   *AuditLog
+}
+
+func NewTeamService(repo repository.Team, org repository.Organization, project repository.Project,
+	feed *ActivityFeed, audit *AuditLog) *TeamService {
+	return &TeamService{repo: repo, org: org, project: project, feed: feed, audit: audit}
+}
+
+// prepare normalizes the name and checks that it is not taken by another team.
+func (t *TeamService) prepare(team *models.Team) error {
+	team.Name = strings.TrimSpace(team.Name)
+	team.Key = utils.NormalizeName(team.Name)
+	if team.Key == "" {
+		return errors.New("name is required")
+	}
+
+	existing, err := t.repo.GetTeamByKey(team.OrganizationId, team.Key)
+	if err == nil && existing.ID != team.ID {
+		return errors.New("team with this name already exists")
+	}
+
+	return nil
+}
+
+func (t *TeamService) CreateTeam(team models.Team) (int, error) {
+	if err := t.prepare(&team); err != nil {
+		return -1, err
+	}
+
+	id, err := t.repo.CreateTeam(team)
+	if err != nil {
+		log.Println("failed to create a new team. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (t *TeamService) GetTeams(orgId int) (models.Teams, error) {
+	teams, err := t.repo.GetTeams(orgId)
+	if err != nil {
+		log.Println("failed to get the list of teams. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (t *TeamService) GetTeam(orgId, id int) (models.Team, error) {
+	team, err := t.repo.GetTeam(orgId, id)
+	if err != nil {
+		log.Println("failed to get the team. Error is: ", err.Error())
+		return models.Team{}, err
+	}
+
+	return team, nil
+}
+
+func (t *TeamService) UpdateTeam(team models.Team) error {
+	if _, err := t.repo.GetTeam(team.OrganizationId, team.ID); err != nil {
+		return errors.New("team doesn't exist")
+	}
+
+	if err := t.prepare(&team); err != nil {
+		return err
+	}
+
+	if err := t.repo.UpdateTeam(team); err != nil {
+		log.Println("failed to update the team. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamService) DeleteTeam(orgId, id int) error {
+	if _, err := t.repo.GetTeam(orgId, id); err != nil {
+		return errors.New("team doesn't exist")
+	}
+
+	if t.repo.HasUnclaimedTasks(id) {
+		return errors.New("team still has unclaimed tasks")
+	}
+
+	if err := t.repo.DeleteTeam(orgId, id); err != nil {
+		log.Println("failed to delete the team. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamService) GetMembers(orgId, id int) ([]models.TeamMember, error) {
+	if _, err := t.repo.GetTeam(orgId, id); err != nil {
+		return nil, errors.New("team doesn't exist")
+	}
+
+	members, err := t.repo.GetMembers(id)
+	if err != nil {
+		log.Println("failed to get the members of the team. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (t *TeamService) AddMember(orgId int, member models.TeamMember) error {
+	if _, err := t.repo.GetTeam(orgId, member.TeamId); err != nil {
+		return errors.New("team doesn't exist")
+	}
+
+	if _, err := t.org.GetMember(orgId, member.UserId); err != nil {
+		return errors.New("user is not a member of the organization")
+	}
+
+	if err := t.repo.AddMember(member); err != nil {
+		log.Println("failed to add a member to the team. Error is: ", err.Error())
+		return errors.New("user is already a member of the team")
+	}
+
+	return nil
+}
+
+func (t *TeamService) RemoveMember(orgId, id, userId int) error {
+	if _, err := t.repo.GetTeam(orgId, id); err != nil {
+		return errors.New("team doesn't exist")
+	}
+
+	if err := t.repo.RemoveMember(id, userId); err != nil {
+		log.Println("failed to remove a member from the team. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamService) AddTeamToProject(ctx context.Context, orgId, managerId int,
+	projectTeam models.ProjectTeam) error {
+	if _, err := t.project.GetProjectById(orgId, managerId, projectTeam.ProjectId); err != nil {
+		log.Println("failed to get the project while adding a team. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	team, err := t.repo.GetTeam(orgId, projectTeam.TeamId)
+	if err != nil {
+		return errors.New("team doesn't exist")
+	}
+
+	if projectTeam.Role == "" {
+		projectTeam.Role = "participant"
+	}
+
+	audit := t.audit.track(models.EntityProject, projectTeam.ProjectId)
+	if err := t.repo.AddTeamToProject(projectTeam); err != nil {
+		log.Println("failed to add the team to the project. Error is: ", err.Error())
+		return errors.New("team is already a participant of the project")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	t.feed.project(managerId, models.ActivityParticipantAdded, projectTeam.ProjectId,
+		models.JSONMap{"team_id": team.ID, "team": team.Name})
+
+	return nil
+}
+
+func (t *TeamService) RemoveTeamFromProject(ctx context.Context, orgId, managerId, projectId, teamId int) error {
+	if _, err := t.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while removing a team. Error is: ", err.Error())
+		return errors.New("project doesn't exist")
+	}
+
+	audit := t.audit.track(models.EntityProject, projectId)
+	if err := t.repo.RemoveTeamFromProject(projectId, teamId); err != nil {
+		log.Println("failed to remove the team from the project. Error is: ", err.Error())
+		return err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	data := models.JSONMap{"team_id": teamId}
+	if team, err := t.repo.GetTeam(orgId, teamId); err == nil {
+		data["team"] = team.Name
+	}
+	t.feed.project(managerId, models.ActivityParticipantRemoved, projectId, data)
+
+	return nil
+}
+
+func (t *TeamService) GetProjectTeams(orgId, managerId, projectId int) ([]models.ProjectTeam, error) {
+	if _, err := t.project.GetProjectById(orgId, managerId, projectId); err != nil {
+		log.Println("failed to get the project while listing teams. Error is: ", err.Error())
+		return nil, errors.New("project doesn't exist")
+	}
+
+	teams, err := t.repo.GetProjectTeams(projectId)
+	if err != nil {
+		log.Println("failed to get the teams of the project. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// GetQueue lists the unclaimed tasks of the team. Only its members and the
+// organization admins may look at it.
+func (t *TeamService) GetQueue(orgId, userId, id int, isAdmin bool) (models.Tasks, error) {
+	if _, err := t.repo.GetTeam(orgId, id); err != nil {
+		return nil, errors.New("team doesn't exist")
+	}
+
+	if !isAdmin && !t.repo.IsMember(id, userId) {
+		return nil, errors.New("you are not a member of the team")
+	}
+
+	tasks, err := t.repo.GetQueue(orgId, id)
+	if err != nil {
+		log.Println("failed to get the queue of the team. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (t *TeamService) ClaimTask(ctx context.Context, orgId, userId, id, taskId int) error {
+	if _, err := t.repo.GetTeam(orgId, id); err != nil {
+		return errors.New("team doesn't exist")
+	}
+
+	if !t.repo.IsMember(id, userId) {
+		return errors.New("you are not a member of the team")
+	}
+
+	audit := t.audit.track(models.EntityTask, taskId)
+	if err := t.repo.ClaimTask(orgId, id, taskId, userId); err != nil {
+		log.Println("failed to claim the task. Error is: ", err.Error())
+		return errors.New("task is not in the queue or was already claimed")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	t.feed.task(userId, models.ActivityTaskClaimed, taskId, nil)
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"github.com/sharifsharifzoda/project-management-system/utils"
+	"log"
+	"strings"
+	"time"
+)
+
+type TemplateService struct {
+	repo       repository.Template
+	project    repository.Project
+	org        repository.Organization
+	department repository.Department
+	task       repository.Task
+	feed       *ActivityFeed
+	audit      *AuditLog
+}
+
+func NewTemplateService(repo repository.Template, project repository.Project, org repository.Organization,
+	department repository.Department, task repository.Task, feed *ActivityFeed, audit *AuditLog) *TemplateService {
+	return &TemplateService{repo: repo, project: project, org: org, department: department, task: task,
+		feed: feed, audit: audit}
+}
+
+// dayOffset counts the calendar days from start to date.
+func dayOffset(start, date time.Time) int {
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return int(day(date).Sub(day(start)).Hours() / 24)
+}
+
+// newTemplateContent turns a snapshot of the project into a template: dates
+// become offsets from the project start and ids of the project's labels and
+// milestones become references into the template. Tasks start over in the
+// first column of the board, with their checklists unchecked.
+func newTemplateContent(project models.Project, snapshot models.ProjectSnapshot) (models.TemplateContent, int, error) {
+	start, err := utils.ParseDeadline(project.StartDate)
+	if err != nil {
+		return models.TemplateContent{}, 0, errors.New("project has an invalid start date")
+	}
+
+	deadline, err := utils.ParseDeadline(project.Deadline)
+	if err != nil {
+		return models.TemplateContent{}, 0, errors.New("project has an invalid deadline")
+	}
+
+	content := models.TemplateContent{
+		Columns:      []models.TemplateColumn{},
+		Fields:       []models.TemplateField{},
+		Labels:       []models.TemplateLabel{},
+		Participants: []models.TemplateParticipant{},
+		Teams:        []models.TemplateTeam{},
+		Milestones:   []models.TemplateMilestone{},
+		Tasks:        []models.TemplateTask{},
+	}
+
+	status := models.StatusNotStarted
+	for i, column := range snapshot.Columns {
+		if i == 0 {
+			status = column.Status
+		}
+		content.Columns = append(content.Columns, models.TemplateColumn{Status: column.Status,
+			Position: column.Position, WipLimit: column.WipLimit})
+	}
+
+	for _, field := range snapshot.Fields {
+		content.Fields = append(content.Fields, models.TemplateField{Name: field.Name, Key: field.Key,
+			Type: field.Type, Options: field.Options, Required: field.Required})
+	}
+
+	labelKeys := make(map[int]string, len(snapshot.Labels))
+	for _, label := range snapshot.Labels {
+		labelKeys[label.ID] = label.Key
+		content.Labels = append(content.Labels, models.TemplateLabel{Name: label.Name, Key: label.Key,
+			Color: label.Color})
+	}
+
+	for _, participant := range snapshot.Participants {
+		content.Participants = append(content.Participants, models.TemplateParticipant{
+			UserId: participant.ParticipantId, Role: participant.Role})
+	}
+
+	for _, team := range snapshot.Teams {
+		content.Teams = append(content.Teams, models.TemplateTeam{TeamId: team.TeamId, Role: team.Role})
+	}
+
+	milestones := make(map[int]int, len(snapshot.Milestones))
+	for i, milestone := range snapshot.Milestones {
+		milestones[milestone.ID] = i
+		content.Milestones = append(content.Milestones, models.TemplateMilestone{Name: milestone.Name,
+			Description: milestone.Description, DueDay: dayOffset(start, milestone.DueDate)})
+	}
+
+	labels := map[int]models.StringList{}
+	for _, taskLabel := range snapshot.TaskLabels {
+		if key, ok := labelKeys[taskLabel.LabelId]; ok {
+			labels[taskLabel.TaskId] = append(labels[taskLabel.TaskId], key)
+		}
+	}
+
+	checklists := map[int]models.StringList{}
+	for _, item := range snapshot.Checklist {
+		checklists[item.TaskId] = append(checklists[item.TaskId], item.Title)
+	}
+
+	for _, task := range snapshot.Tasks {
+		taskDeadline, err := utils.ParseDeadline(task.Deadline)
+		if err != nil {
+			return models.TemplateContent{}, 0, errors.New("task has an invalid deadline")
+		}
+
+		templateTask := models.TemplateTask{
+			Title:            task.Title,
+			Description:      task.Description,
+			ExecutorId:       task.ExecutorId,
+			TeamId:           task.TeamId,
+			Status:           status,
+			Priority:         task.Priority,
+			CustomFields:     task.CustomFields,
+			OriginalEstimate: task.OriginalEstimate,
+			DeadlineDay:      dayOffset(start, taskDeadline),
+			Labels:           labels[task.ID],
+			Checklist:        checklists[task.ID],
+		}
+		if task.MilestoneId != nil {
+			if i, ok := milestones[*task.MilestoneId]; ok {
+				templateTask.Milestone = &i
+			}
+		}
+
+		content.Tasks = append(content.Tasks, templateTask)
+	}
+
+	return content, dayOffset(start, deadline), nil
+}
+
+func (t *TemplateService) snapshot(orgId, userId, projectId int) (models.Project, models.TemplateContent, int, error) {
+	project, err := t.project.GetProjectById(orgId, userId, projectId)
+	if err != nil {
+		return models.Project{}, models.TemplateContent{}, 0, errors.New("project doesn't exist")
+	}
+
+	snapshot, err := t.repo.GetProjectSnapshot(projectId)
+	if err != nil {
+		log.Println("failed to read the structure of the project. Error is: ", err.Error())
+		return models.Project{}, models.TemplateContent{}, 0, err
+	}
+
+	content, duration, err := newTemplateContent(project, snapshot)
+	if err != nil {
+		return models.Project{}, models.TemplateContent{}, 0, err
+	}
+
+	return project, content, duration, nil
+}
+
+func checkTemplate(template *models.ProjectTemplate) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return nil
+}
+
+// SaveTemplate saves the structure and the tasks of the project as a template.
+func (t *TemplateService) SaveTemplate(orgId, userId, projectId int, template models.ProjectTemplate) (int, error) {
+	if err := checkTemplate(&template); err != nil {
+		return -1, err
+	}
+
+	_, content, duration, err := t.snapshot(orgId, userId, projectId)
+	if err != nil {
+		return -1, err
+	}
+
+	template.OrganizationId = orgId
+	template.CreatedBy = userId
+	template.Content = content
+	template.DurationDays = duration
+
+	id, err := t.repo.CreateTemplate(template)
+	if err != nil {
+		log.Println("failed to create a new template. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (t *TemplateService) GetTemplates(orgId int) (models.ProjectTemplates, error) {
+	templates, err := t.repo.GetTemplates(orgId)
+	if err != nil {
+		log.Println("failed to get the list of templates. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (t *TemplateService) GetTemplate(orgId, id int) (models.ProjectTemplate, error) {
+	template, err := t.repo.GetTemplate(orgId, id)
+	if err != nil {
+		log.Println("failed to get the template by id. Error is: ", err.Error())
+		return models.ProjectTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (t *TemplateService) UpdateTemplate(template models.ProjectTemplate) error {
+	if err := checkTemplate(&template); err != nil {
+		return err
+	}
+
+	if err := t.repo.UpdateTemplate(template); err != nil {
+		log.Println("failed to update the template. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (t *TemplateService) DeleteTemplate(orgId, id int) error {
+	if err := t.repo.DeleteTemplate(orgId, id); err != nil {
+		log.Println("failed to delete the template. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// CreateProject makes a new project from the template, starting on the
+// project's start date, today if it has none.
+func (t *TemplateService) CreateProject(ctx context.Context, orgId, templateId int,
+	project models.Project) (int, error) {
+	template, err := t.repo.GetTemplate(orgId, templateId)
+	if err != nil {
+		return -1, errors.New("template doesn't exist")
+	}
+
+	return t.instantiate(ctx, project, template.Content, template.DurationDays)
+}
+
+// CloneProject copies the project with its dates shifted to the start date
+// of the new one. The description and the department are kept unless given.
+func (t *TemplateService) CloneProject(ctx context.Context, orgId, userId, projectId int,
+	project models.Project) (int, error) {
+	source, content, duration, err := t.snapshot(orgId, userId, projectId)
+	if err != nil {
+		return -1, err
+	}
+
+	if project.Description == "" {
+		project.Description = source.Description
+	}
+
+	if project.DepartmentId == nil {
+		project.DepartmentId = source.DepartmentId
+	}
+
+	return t.instantiate(ctx, project, content, duration)
+}
+
+// instantiate creates the project from the template content. Participants
+// and teams that have left the organization are dropped; a task left with
+// nobody to do it goes to the project's manager.
+func (t *TemplateService) instantiate(ctx context.Context, project models.Project, content models.TemplateContent,
+	duration int) (int, error) {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return -1, errors.New("name is required")
+	}
+
+	start := time.Now()
+	if project.StartDate != "" {
+		var err error
+		if start, err = utils.ParseDeadline(project.StartDate); err != nil {
+			return -1, errors.New("invalid start date")
+		}
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	project.StartDate = start.Format("2006-01-02")
+
+	if project.Deadline == "" {
+		project.Deadline = start.AddDate(0, 0, duration).Format("2006-01-02")
+	} else if _, err := utils.ParseDeadline(project.Deadline); err != nil {
+		return -1, errors.New("invalid deadline")
+	}
+
+	if project.DepartmentId != nil {
+		if _, err := t.department.GetDepartment(project.OrganizationId, *project.DepartmentId); err != nil {
+			return -1, errors.New("department doesn't exist")
+		}
+	}
+
+	members, err := t.org.GetMembers(project.OrganizationId)
+	if err != nil {
+		log.Println("failed to get the members of the organization. Error is: ", err.Error())
+		return -1, err
+	}
+
+	isMember := make(map[int]bool, len(members))
+	for _, member := range members {
+		isMember[member.UserId] = true
+	}
+
+	teams := map[int]bool{}
+	hasTeam := func(teamId int) bool {
+		if _, ok := teams[teamId]; !ok {
+			teams[teamId] = t.task.TeamInOrganization(project.OrganizationId, teamId)
+		}
+		return teams[teamId]
+	}
+
+	participants := make([]models.TemplateParticipant, 0, len(content.Participants))
+	for _, participant := range content.Participants {
+		if isMember[participant.UserId] {
+			participants = append(participants, participant)
+		}
+	}
+	content.Participants = participants
+
+	projectTeams := make([]models.TemplateTeam, 0, len(content.Teams))
+	for _, team := range content.Teams {
+		if hasTeam(team.TeamId) {
+			projectTeams = append(projectTeams, team)
+		}
+	}
+	content.Teams = projectTeams
+
+	tasks := make([]models.TemplateTask, 0, len(content.Tasks))
+	for _, task := range content.Tasks {
+		if task.ExecutorId != nil && !isMember[*task.ExecutorId] {
+			task.ExecutorId = nil
+		}
+
+		if task.TeamId != nil && !hasTeam(*task.TeamId) {
+			task.TeamId = nil
+		}
+
+		if task.ExecutorId == nil && task.TeamId == nil {
+			managerId := project.ManagerID
+			task.ExecutorId = &managerId
+		}
+
+		tasks = append(tasks, task)
+	}
+	content.Tasks = tasks
+
+	created, err := t.repo.CreateFromTemplate(project, content, start)
+	if err != nil {
+		log.Println("failed to create the project from the template. Error is: ", err.Error())
+		return -1, err
+	}
+
+	t.audit.created(ctx, models.EntityProject, created.ProjectId)
+	t.audit.created(ctx, models.EntityParticipant, created.ParticipantIds...)
+	t.audit.created(ctx, models.EntityTask, created.TaskIds...)
+	t.feed.project(project.ManagerID, models.ActivityProjectCreated, created.ProjectId, nil)
+
+	return created.ProjectId, nil
+}
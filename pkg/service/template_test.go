@@ -0,0 +1,86 @@
+package service
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDayOffset(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name     string
+		date     time.Time
+		expected int
+	}{
+		{name: "same day", date: time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC), expected: 0},
+		{name: "earlier hour next day", date: time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC), expected: 1},
+		{name: "across a month", date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), expected: 31},
+		{name: "before the start", date: time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), expected: -2},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, dayOffset(start, test.date))
+		})
+	}
+}
+
+func TestNewTemplateContent(t *testing.T) {
+	executorId, milestoneId, estimate := 7, 30, 90
+	project := models.Project{StartDate: "2024-03-01T00:00:00Z", Deadline: "2024-03-31T00:00:00Z"}
+	snapshot := models.ProjectSnapshot{
+		Columns: []models.BoardColumn{
+			{Status: "Backlog", Position: 0},
+			{Status: models.StatusDone, Position: 1, WipLimit: 5},
+		},
+		Labels:       models.Labels{{ID: 10, Name: "Bug", Key: "bug", Color: "#ff0000"}},
+		Participants: []models.ProjectParticipant{{ParticipantId: executorId, Role: "developer"}},
+		Milestones: models.Milestones{
+			{ID: milestoneId, Name: "Launch", DueDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		},
+		Tasks: models.Tasks{
+			{ID: 1, Title: "Kick-off", ExecutorId: &executorId, Status: models.StatusDone, Priority: "high",
+				OriginalEstimate: &estimate, MilestoneId: &milestoneId, Deadline: "2024-03-04T17:00:00Z"},
+			{ID: 2, Title: "Wrap-up", ExecutorId: &executorId, Status: "Backlog", Priority: "low",
+				Deadline: "2024-03-31T00:00:00Z"},
+		},
+		TaskLabels: []models.TaskLabel{{TaskId: 1, LabelId: 10}},
+		Checklist: models.ChecklistItems{
+			{TaskId: 1, Title: "Agenda", IsChecked: true},
+			{TaskId: 1, Title: "Minutes"},
+		},
+	}
+
+	content, duration, err := newTemplateContent(project, snapshot)
+	require.NoError(t, err)
+
+	milestone := 0
+	assert.Equal(t, 30, duration)
+	assert.Equal(t, []models.TemplateColumn{
+		{Status: "Backlog", Position: 0},
+		{Status: models.StatusDone, Position: 1, WipLimit: 5},
+	}, content.Columns)
+	assert.Equal(t, []models.TemplateLabel{{Name: "Bug", Key: "bug", Color: "#ff0000"}}, content.Labels)
+	assert.Equal(t, []models.TemplateParticipant{{UserId: executorId, Role: "developer"}}, content.Participants)
+	assert.Equal(t, []models.TemplateMilestone{{Name: "Launch", DueDay: 14}}, content.Milestones)
+	assert.Equal(t, []models.TemplateTask{
+		{Title: "Kick-off", ExecutorId: &executorId, Status: "Backlog", Priority: "high",
+			OriginalEstimate: &estimate, DeadlineDay: 3, Milestone: &milestone,
+			Labels: models.StringList{"bug"}, Checklist: models.StringList{"Agenda", "Minutes"}},
+		{Title: "Wrap-up", ExecutorId: &executorId, Status: "Backlog", Priority: "low", DeadlineDay: 30},
+	}, content.Tasks)
+}
+
+func TestNewTemplateContentInvalidDates(t *testing.T) {
+	_, _, err := newTemplateContent(models.Project{StartDate: "soon", Deadline: "2024-03-31"},
+		models.ProjectSnapshot{})
+	assert.EqualError(t, err, "project has an invalid start date")
+
+	_, _, err = newTemplateContent(models.Project{StartDate: "2024-03-01", Deadline: "2024-03-31"},
+		models.ProjectSnapshot{Tasks: models.Tasks{{Title: "Broken", Deadline: "someday"}}})
+	assert.EqualError(t, err, "task has an invalid deadline")
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"github.com/sharifsharifzoda/project-management-system/models"
 	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
@@ -10,12 +11,14 @@ import (
 )
 
 type UserService struct {
-	repo repository.User
+	repo  repository.User
+	audit *AuditLog
 }
 
-func NewUserService(repo repository.User) *UserService {
+func NewUserService(repo repository.User, audit *AuditLog) *UserService {
 	return &UserService{
-		repo: repo,
+		repo:  repo,
+		audit: audit,
 	}
 }
 
@@ -28,38 +31,44 @@ func (u *UserService) GetUser(id int) (models.User, error) {
 	return user, nil
 }
 
-func (u *UserService) UpdateUser(newUser models.User) error {
+func (u *UserService) UpdateUser(ctx context.Context, newUser models.User) error {
+	audit := u.audit.track(models.EntityUser, newUser.ID)
 	err := u.repo.UpdateUser(newUser)
 	if err != nil {
 		log.Println("failed to update the user. error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditUpdate)
 
 	return nil
 }
 
-func (u *UserService) DeleteUser(id int) error {
+func (u *UserService) DeleteUser(ctx context.Context, id int) error {
+	audit := u.audit.track(models.EntityUser, id)
 	err := u.repo.DeleteUser(id)
 	if err != nil {
 		log.Println("failed to delete the user. error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditDelete)
 
 	return nil
 }
 
-func (u *UserService) Restore(id int) error {
+func (u *UserService) Restore(ctx context.Context, id int) error {
+	audit := u.audit.track(models.EntityUser, id)
 	err := u.repo.RestoreUser(id)
 	if err != nil {
 		log.Println("failed to restore the user. error is: ", err.Error())
 		return err
 	}
+	audit.record(ctx, models.AuditRestore)
 
 	return nil
 }
 
-func (u *UserService) GetProjects(userId int) ([]models.ProjectParticipant, error) {
-	projects, err := u.repo.GetProjects(userId)
+func (u *UserService) GetProjects(orgId, userId int) ([]models.UserProject, error) {
+	projects, err := u.repo.GetProjects(orgId, userId)
 	if err != nil {
 		log.Println("failed to get the list of projects. Error is: ", err.Error())
 		return nil, err
@@ -68,8 +77,8 @@ func (u *UserService) GetProjects(userId int) ([]models.ProjectParticipant, erro
 	return projects, nil
 }
 
-func (u *UserService) GetTasks(userId int) (models.Tasks, error) {
-	tasks, err := u.repo.GetTasks(userId)
+func (u *UserService) GetTasks(orgId, userId int) (models.Tasks, error) {
+	tasks, err := u.repo.GetTasks(orgId, userId)
 	if err != nil {
 		log.Println("failed to get the list of tasks. Error is: ", err.Error())
 		return nil, err
@@ -78,7 +87,7 @@ func (u *UserService) GetTasks(userId int) (models.Tasks, error) {
 	return tasks, nil
 }
 
-func (u *UserService) UploadUserPicture(id int, filepath string) (models.User, error) {
+func (u *UserService) UploadUserPicture(ctx context.Context, id int, filepath string) (models.User, error) {
 	user, err := u.GetUser(id)
 	if err != nil {
 		return models.User{}, err
@@ -87,15 +96,17 @@ func (u *UserService) UploadUserPicture(id int, filepath string) (models.User, e
 	user.Photo = filepath
 	user.UpdatedAt = time.Now()
 
+	audit := u.audit.track(models.EntityUser, id)
 	if err := u.repo.UpdateUser(user); err != nil {
 		log.Println("failed to update user while uploading photo. Error is: ", err.Error())
 		return models.User{}, err
 	}
+	audit.record(ctx, models.AuditUpdate)
 
 	return user, nil
 }
 
-func (u *UserService) UpdatePictureUser(id int, filepath string) (models.User, error) {
+func (u *UserService) UpdatePictureUser(ctx context.Context, id int, filepath string) (models.User, error) {
 	user, err := u.GetUser(id)
 	if err != nil {
 		return user, err
@@ -108,10 +119,12 @@ func (u *UserService) UpdatePictureUser(id int, filepath string) (models.User, e
 	user.Photo = filepath
 	user.UpdatedAt = time.Now()
 
+	audit := u.audit.track(models.EntityUser, id)
 	if err := u.repo.UpdateUser(user); err != nil {
 		log.Println("failed to update user while changing the profile photo. Error is: ", err.Error())
 		return models.User{}, err
 	}
+	audit.record(ctx, models.AuditUpdate)
 
 	return user, nil
 }
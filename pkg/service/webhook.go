@@ -0,0 +1,426 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"gorm.io/gorm"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// webhookEvents are the activities webhooks can subscribe to.
+var webhookEvents = map[string]bool{
+	models.ActivityTaskCreated:        true,
+	models.ActivityTaskUpdated:        true,
+	models.ActivityTaskStatusChanged:  true,
+	models.ActivityTaskAssigned:       true,
+	models.ActivityTaskUnassigned:     true,
+	models.ActivityTaskClaimed:        true,
+	models.ActivityTaskMoved:          true,
+	models.ActivityTaskCopied:         true,
+	models.ActivityTaskDeleted:        true,
+	models.ActivityTaskRestored:       true,
+	models.ActivityTaskCommented:      true,
+	models.ActivityProjectCreated:     true,
+	models.ActivityProjectUpdated:     true,
+	models.ActivityProjectDeleted:     true,
+	models.ActivityProjectRestored:    true,
+	models.ActivityParticipantAdded:   true,
+	models.ActivityParticipantJoined:  true,
+	models.ActivityParticipantRemoved: true,
+}
+
+const (
+	// maxWebhookAttempts is how many times a delivery is tried before it is
+	// given up as failed.
+	maxWebhookAttempts = 10
+	// webhookTimeout is how long a receiver has to answer.
+	webhookTimeout = 10 * time.Second
+	// webhookBatch is how many deliveries are sent at every run. They are
+	// sent at once, so the whole batch is done within webhookTimeout.
+	webhookBatch = 50
+)
+
+var errWebhookAddress = errors.New("webhooks can only be sent to public addresses")
+
+// publicAddress tells whether webhooks may be sent to the address. Loopback,
+// private, link-local, like the cloud metadata address 169.254.169.254,
+// multicast and unspecified addresses are refused.
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// newWebhookClient returns the client webhooks are sent with. It connects
+// only to the addresses allowed says yes to, checked after the host name is
+// resolved, doesn't use a proxy and doesn't follow redirects.
+func newWebhookClient(allowed func(ip net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return errWebhookAddress
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: webhookTimeout},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookBackoff returns how long to wait before the next attempt after the
+// given number of failed ones: 30 seconds, doubling every time, up to 6
+// hours.
+func webhookBackoff(attempts int) time.Duration {
+	const base, ceiling = 30 * time.Second, 6 * time.Hour
+
+	wait := base
+	for i := 1; i < attempts && wait < ceiling; i++ {
+		wait *= 2
+	}
+
+	if wait > ceiling {
+		return ceiling
+	}
+
+	return wait
+}
+
+// signWebhook signs the body sent at the given unix time with the secret of
+// the webhook. Receivers check it by computing the HMAC-SHA256 of
+// "<X-Webhook-Timestamp>.<body>" themselves.
+func signWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookPayload is what webhooks are sent about the activity.
+func webhookPayload(activity models.Activity) models.JSONMap {
+	return models.JSONMap{
+		"event":           activity.Verb,
+		"activity_id":     activity.ID,
+		"organization_id": activity.OrganizationId,
+		"project_id":      activity.ProjectId,
+		"task_id":         activity.TaskId,
+		"actor_id":        activity.ActorId,
+		"user_id":         activity.UserId,
+		"data":            activity.Data,
+		"created_at":      activity.CreatedAt,
+	}
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+type WebhookService struct {
+	repo   repository.Webhook
+	task   repository.Task
+	client *http.Client
+}
+
+func NewWebhookService(repo repository.Webhook, task repository.Task) *WebhookService {
+	return &WebhookService{repo: repo, task: task, client: newWebhookClient(publicAddress)}
+}
+
+// checkWebhook refuses URLs that name a local host or address outright. Host
+// names that resolve to one are refused when the webhook is sent.
+func (w *WebhookService) checkWebhook(webhook *models.Webhook) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	host := target.Hostname()
+	ip := net.ParseIP(host)
+	if strings.EqualFold(host, "localhost") || (ip != nil && !publicAddress(ip)) {
+		return errWebhookAddress
+	}
+
+	for _, event := range webhook.Events {
+		if !webhookEvents[event] {
+			return fmt.Errorf("unknown event %s", event)
+		}
+	}
+
+	if webhook.Events == nil {
+		webhook.Events = models.StringList{}
+	}
+
+	if webhook.ProjectId != nil && !w.task.ProjectInOrganization(webhook.OrganizationId, *webhook.ProjectId) {
+		return errors.New("project doesn't exist")
+	}
+
+	return nil
+}
+
+// CreateWebhook subscribes the webhook and returns it with its secret, which
+// is not shown again.
+func (w *WebhookService) CreateWebhook(webhook models.Webhook) (models.Webhook, error) {
+	if err := w.checkWebhook(&webhook); err != nil {
+		return models.Webhook{}, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		log.Println("failed to generate the webhook secret. Error is: ", err.Error())
+		return models.Webhook{}, err
+	}
+	webhook.Secret = secret
+
+	id, err := w.repo.CreateWebhook(webhook)
+	if err != nil {
+		log.Println("failed to create the webhook. Error is: ", err.Error())
+		return models.Webhook{}, err
+	}
+	webhook.ID = id
+
+	return webhook, nil
+}
+
+func (w *WebhookService) GetWebhooks(orgId int) (models.Webhooks, error) {
+	webhooks, err := w.repo.GetWebhooks(orgId)
+	if err != nil {
+		log.Println("failed to get the list of webhooks. Error is: ", err.Error())
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookService) GetWebhook(orgId, id int) (models.Webhook, error) {
+	webhook, err := w.repo.GetWebhook(orgId, id)
+	if err != nil {
+		log.Println("failed to get the webhook by id. Error is: ", err.Error())
+		return models.Webhook{}, err
+	}
+	webhook.Secret = ""
+
+	return webhook, nil
+}
+
+func (w *WebhookService) UpdateWebhook(webhook models.Webhook) error {
+	if err := w.checkWebhook(&webhook); err != nil {
+		return err
+	}
+
+	err := w.repo.UpdateWebhook(webhook)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("webhook doesn't exist")
+	}
+	if err != nil {
+		log.Println("failed to update the webhook. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (w *WebhookService) DeleteWebhook(orgId, id int) error {
+	err := w.repo.DeleteWebhook(orgId, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("webhook doesn't exist")
+	}
+	if err != nil {
+		log.Println("failed to delete the webhook. Error is: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the delivery log of the webhook, newest first.
+func (w *WebhookService) GetDeliveries(orgId, id, beforeId, limit int) (models.WebhookDeliveryPage, error) {
+	if _, err := w.repo.GetWebhook(orgId, id); err != nil {
+		return models.WebhookDeliveryPage{}, errors.New("webhook doesn't exist")
+	}
+
+	limit = pageLimit(limit)
+	deliveries, err := w.repo.GetDeliveries(id, beforeId, limit)
+	if err != nil {
+		log.Println("failed to get the webhook deliveries. Error is: ", err.Error())
+		return models.WebhookDeliveryPage{}, err
+	}
+
+	page := models.WebhookDeliveryPage{Deliveries: deliveries}
+	if len(deliveries) == limit {
+		page.NextBeforeId = &deliveries[len(deliveries)-1].ID
+	}
+
+	return page, nil
+}
+
+// Redeliver sends a past delivery of the webhook again as a new one, and
+// returns its id.
+func (w *WebhookService) Redeliver(orgId, id, deliveryId int) (int, error) {
+	if _, err := w.repo.GetWebhook(orgId, id); err != nil {
+		return -1, errors.New("webhook doesn't exist")
+	}
+
+	newId, err := w.repo.Redeliver(id, deliveryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return -1, errors.New("delivery doesn't exist")
+	}
+	if err != nil {
+		log.Println("failed to redeliver the webhook delivery. Error is: ", err.Error())
+		return -1, err
+	}
+
+	return newId, nil
+}
+
+// send posts the delivery to its webhook and returns it as it is to be
+// saved: delivered, put off for webhookBackoff, or failed once it has been
+// tried maxWebhookAttempts times.
+func (w *WebhookService) send(delivery models.DueDelivery, now time.Time) models.WebhookDelivery {
+	result := delivery.WebhookDelivery
+	result.Attempts++
+	result.LastStatusCode = nil
+
+	err := w.post(delivery, now, &result)
+	if err == nil {
+		result.Status = models.DeliveryDelivered
+		result.LastError = ""
+		result.DeliveredAt = &now
+		return result
+	}
+
+	result.LastError = err.Error()
+	if result.Attempts >= maxWebhookAttempts {
+		result.Status = models.DeliveryFailed
+	} else {
+		result.Status = models.DeliveryPending
+		result.NextAttemptAt = now.Add(webhookBackoff(result.Attempts))
+	}
+
+	return result
+}
+
+func (w *WebhookService) post(delivery models.DueDelivery, now time.Time, result *models.WebhookDelivery) error {
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "project-management-system-webhooks")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.Itoa(delivery.ID))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", signWebhook(delivery.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	code := resp.StatusCode
+	result.LastStatusCode = &code
+	if code < 200 || code > 299 {
+		return fmt.Errorf("receiver answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Deliver sends the deliveries that are due by now, all at once, so that
+// they are sent and saved before the lease on them runs out.
+func (w *WebhookService) Deliver(now time.Time) (int, error) {
+	due, err := w.repo.ClaimDeliveries(now, 2*webhookTimeout, webhookBatch)
+	if err != nil {
+		log.Println("failed to get the due webhook deliveries. Error is: ", err.Error())
+		return 0, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+	)
+	for _, delivery := range due {
+		wg.Add(1)
+		go func(delivery models.DueDelivery) {
+			defer wg.Done()
+
+			result := w.send(delivery, time.Now())
+			if result.Status == models.DeliveryDelivered {
+				mu.Lock()
+				delivered++
+				mu.Unlock()
+			}
+
+			if err := w.repo.SaveAttempt(result); err != nil {
+				log.Println("failed to save the webhook delivery attempt. Error is: ", err.Error())
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return delivered, nil
+}
+
+// Run sends the due webhook deliveries every interval until ctx is done.
+func (w *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if count, err := w.Deliver(now); err == nil && count > 0 {
+				log.Println("webhook deliveries sent:", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/golang/mock/gomock"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	mock_repository "github.com/sharifsharifzoda/project-management-system/pkg/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	testTable := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: 30 * time.Second},
+		{attempts: 2, expected: time.Minute},
+		{attempts: 5, expected: 8 * time.Minute},
+		{attempts: 9, expected: 128 * time.Minute},
+		{attempts: 10, expected: 256 * time.Minute},
+		{attempts: 11, expected: 6 * time.Hour},
+		{attempts: 40, expected: 6 * time.Hour},
+	}
+
+	for _, test := range testTable {
+		assert.Equal(t, test.expected, webhookBackoff(test.attempts), "after %d attempts", test.attempts)
+	}
+}
+
+// testWebhookService sends webhooks to any address, so that they can reach
+// test servers.
+func testWebhookService(repo repository.Webhook) *WebhookService {
+	w := NewWebhookService(repo, nil)
+	w.client = newWebhookClient(func(net.IP) bool { return true })
+	return w
+}
+
+func TestPublicAddress(t *testing.T) {
+	testTable := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "93.184.216.34", expected: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "0.0.0.0"},
+		{ip: "224.0.0.1"},
+	}
+
+	for _, test := range testTable {
+		assert.Equal(t, test.expected, publicAddress(net.ParseIP(test.ip)), test.ip)
+	}
+}
+
+func TestWebhookService_CheckWebhook(t *testing.T) {
+	testTable := []struct {
+		url string
+		err error
+	}{
+		{url: "https://hooks.example.com/pms"},
+		{url: "http://localhost:8080/hook", err: errWebhookAddress},
+		{url: "http://127.0.0.1/hook", err: errWebhookAddress},
+		{url: "http://[::1]:9000/hook", err: errWebhookAddress},
+		{url: "http://169.254.169.254/latest/meta-data", err: errWebhookAddress},
+		{url: "http://10.0.0.5/hook", err: errWebhookAddress},
+	}
+
+	for _, test := range testTable {
+		err := NewWebhookService(nil, nil).checkWebhook(&models.Webhook{URL: test.url})
+		assert.Equal(t, test.err, err, test.url)
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	delivery := models.DueDelivery{
+		WebhookDelivery: models.WebhookDelivery{ID: 7, Event: models.ActivityTaskCreated, Attempts: 2,
+			Payload: models.JSONMap{"event": models.ActivityTaskCreated, "task_id": 3}},
+		Secret: "whsec_test",
+	}
+
+	t.Run("signed and delivered", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			mac := hmac.New(sha256.New, []byte("whsec_test"))
+			mac.Write([]byte(r.Header.Get("X-Webhook-Timestamp") + "." + string(body)))
+
+			assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Webhook-Signature"))
+			assert.Equal(t, "1709546400", r.Header.Get("X-Webhook-Timestamp"))
+			assert.Equal(t, "7", r.Header.Get("X-Webhook-Delivery"))
+			assert.Equal(t, models.ActivityTaskCreated, r.Header.Get("X-Webhook-Event"))
+			assert.JSONEq(t, `{"event": "task_created", "task_id": 3}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		d := delivery
+		d.URL = server.URL
+		result := testWebhookService(nil).send(d, now)
+
+		assert.Equal(t, models.DeliveryDelivered, result.Status)
+		assert.Equal(t, 3, result.Attempts)
+		assert.Equal(t, http.StatusNoContent, *result.LastStatusCode)
+		assert.Equal(t, now, *result.DeliveredAt)
+	})
+
+	t.Run("retried with backoff", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		d := delivery
+		d.URL = server.URL
+		result := testWebhookService(nil).send(d, now)
+
+		assert.Equal(t, models.DeliveryPending, result.Status)
+		assert.Equal(t, now.Add(2*time.Minute), result.NextAttemptAt)
+		assert.Equal(t, http.StatusServiceUnavailable, *result.LastStatusCode)
+		assert.Equal(t, "receiver answered 503 Service Unavailable", result.LastError)
+	})
+
+	t.Run("failed after the last attempt", func(t *testing.T) {
+		d := delivery
+		d.URL = "http://127.0.0.1:1"
+		d.Attempts = maxWebhookAttempts - 1
+		result := testWebhookService(nil).send(d, now)
+
+		assert.Equal(t, models.DeliveryFailed, result.Status)
+		assert.Equal(t, maxWebhookAttempts, result.Attempts)
+		assert.Nil(t, result.LastStatusCode)
+		assert.NotEmpty(t, result.LastError)
+	})
+
+	t.Run("local addresses are refused", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("the webhook reached a local address")
+		}))
+		defer server.Close()
+
+		d := delivery
+		d.URL = server.URL
+		result := NewWebhookService(nil, nil).send(d, now)
+
+		assert.Equal(t, models.DeliveryPending, result.Status)
+		assert.Nil(t, result.LastStatusCode)
+		assert.Contains(t, result.LastError, errWebhookAddress.Error())
+	})
+
+	t.Run("redirects aren't followed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/hook" {
+				http.Redirect(w, r, "/elsewhere", http.StatusFound)
+				return
+			}
+			t.Error("the redirect was followed")
+		}))
+		defer server.Close()
+
+		d := delivery
+		d.URL = server.URL + "/hook"
+		result := testWebhookService(nil).send(d, now)
+
+		assert.Equal(t, models.DeliveryPending, result.Status)
+		assert.Equal(t, http.StatusFound, *result.LastStatusCode)
+	})
+}
+
+func TestWebhookService_Deliver(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	const wait = 200 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(wait)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	var due []models.DueDelivery
+	for id := 1; id <= 5; id++ {
+		due = append(due, models.DueDelivery{WebhookDelivery: models.WebhookDelivery{ID: id}, URL: server.URL})
+	}
+
+	repo := mock_repository.NewMockWebhook(c)
+	repo.EXPECT().ClaimDeliveries(now, 2*webhookTimeout, webhookBatch).Return(due, nil)
+	repo.EXPECT().SaveAttempt(gomock.Any()).Return(nil).Times(len(due))
+
+	started := time.Now()
+	delivered, err := testWebhookService(repo).Deliver(now)
+	assert.NoError(t, err)
+	assert.Equal(t, len(due), delivered)
+	assert.Less(t, time.Since(started), 2*wait, "the deliveries are sent at once")
+}
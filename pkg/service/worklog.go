@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/sharifsharifzoda/project-management-system/pkg/repository"
+	"log"
+	"time"
+)
+
+// maxTimesheetDays bounds the period of a timesheet.
+const maxTimesheetDays = 366
+
+type WorklogService struct {
+	repo  repository.Worklog
+	feed  *ActivityFeed
+	audit *AuditLog
+}
+
+func NewWorklogService(repo repository.Worklog, feed *ActivityFeed, audit *AuditLog) *WorklogService {
+	return &WorklogService{repo: repo, feed: feed, audit: audit}
+}
+
+// prepareWorklog fills in a worklog given either by its start and end or by
+// its duration in minutes. Without a start, the work is taken to have just
+// finished.
+func prepareWorklog(worklog *models.Worklog, now time.Time) error {
+	if worklog.EndedAt != nil && worklog.Minutes != 0 {
+		return errors.New("give either ended_at or minutes, not both")
+	}
+
+	if worklog.EndedAt != nil {
+		if worklog.StartedAt.IsZero() {
+			return errors.New("started_at is required with ended_at")
+		}
+
+		if !worklog.EndedAt.After(worklog.StartedAt) {
+			return errors.New("worklog must end after it starts")
+		}
+
+		worklog.Minutes = int(worklog.EndedAt.Sub(worklog.StartedAt).Round(time.Minute).Minutes())
+	} else {
+		if worklog.Minutes <= 0 {
+			return errors.New("either ended_at or a positive number of minutes is required")
+		}
+
+		if worklog.StartedAt.IsZero() {
+			worklog.StartedAt = now.Add(-time.Duration(worklog.Minutes) * time.Minute)
+		}
+
+		endedAt := worklog.StartedAt.Add(time.Duration(worklog.Minutes) * time.Minute)
+		worklog.EndedAt = &endedAt
+	}
+
+	if worklog.EndedAt.After(now) {
+		return errors.New("worklog can't end in the future")
+	}
+
+	return nil
+}
+
+// weekStart returns midnight of the Monday of the week t falls in.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+func (w *WorklogService) LogWork(ctx context.Context, orgId, userId int, worklog models.Worklog) (int, error) {
+	if !w.repo.CanLogWork(orgId, userId, worklog.TaskId) {
+		return -1, errors.New("task doesn't exist or you don't work on it")
+	}
+
+	worklog.UserId = userId
+	if err := prepareWorklog(&worklog, time.Now()); err != nil {
+		return -1, err
+	}
+
+	audit := w.audit.track(models.EntityTask, worklog.TaskId)
+	id, err := w.repo.CreateWorklog(worklog)
+	if err != nil {
+		log.Println("failed to create a new worklog. Error is: ", err.Error())
+		return -1, err
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	w.feed.updated(userId, []int{worklog.TaskId}, "worklogs")
+
+	return id, nil
+}
+
+func (w *WorklogService) GetWorklogs(orgId, userId, taskId int) (models.Worklogs, error) {
+	if !w.repo.CanLogWork(orgId, userId, taskId) {
+		return nil, errors.New("task doesn't exist or you don't work on it")
+	}
+
+	worklogs, err := w.repo.GetWorklogs(taskId)
+	if err != nil {
+		log.Println("failed to get the list of worklogs. Error is: ", err.Error())
+		return nil, err
+	}
+
+	return worklogs, nil
+}
+
+func (w *WorklogService) DeleteWorklog(ctx context.Context, orgId, userId, taskId, id int) error {
+	if !w.repo.CanLogWork(orgId, userId, taskId) {
+		return errors.New("task doesn't exist or you don't work on it")
+	}
+
+	audit := w.audit.track(models.EntityTask, taskId)
+	if err := w.repo.DeleteWorklog(userId, taskId, id); err != nil {
+		log.Println("failed to delete the worklog. Error is: ", err.Error())
+		return errors.New("worklog doesn't exist")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	w.feed.updated(userId, []int{taskId}, "worklogs")
+
+	return nil
+}
+
+func (w *WorklogService) StartTimer(orgId, userId, taskId int) (int, error) {
+	if !w.repo.CanLogWork(orgId, userId, taskId) {
+		return -1, errors.New("task doesn't exist or you don't work on it")
+	}
+
+	id, err := w.repo.StartTimer(models.Worklog{TaskId: taskId, UserId: userId, StartedAt: time.Now()})
+	if err != nil {
+		log.Println("failed to start a timer. Error is: ", err.Error())
+		return -1, err
+	}
+
+	w.feed.updated(userId, []int{taskId}, "worklogs")
+
+	return id, nil
+}
+
+func (w *WorklogService) GetRunningTimer(userId int) (models.Worklog, error) {
+	worklog, err := w.repo.GetRunningTimer(userId)
+	if err != nil {
+		return models.Worklog{}, errors.New("you have no running timer")
+	}
+
+	return worklog, nil
+}
+
+func (w *WorklogService) StopTimer(ctx context.Context, userId int) (models.Worklog, error) {
+	running, err := w.repo.GetRunningTimer(userId)
+	if err != nil {
+		return models.Worklog{}, errors.New("you have no running timer")
+	}
+
+	audit := w.audit.track(models.EntityTask, running.TaskId)
+	worklog, err := w.repo.StopTimer(userId, time.Now())
+	if err != nil {
+		log.Println("failed to stop the timer. Error is: ", err.Error())
+		return models.Worklog{}, errors.New("you have no running timer")
+	}
+	audit.record(ctx, models.AuditUpdate)
+
+	w.feed.updated(userId, []int{worklog.TaskId}, "worklogs")
+
+	return worklog, nil
+}
+
+// GetTimesheet aggregates logged time over [from, to). Without a period it
+// covers the current week.
+func (w *WorklogService) GetTimesheet(filter models.TimesheetFilter) (models.Timesheet, error) {
+	if filter.From.IsZero() {
+		filter.From = weekStart(time.Now())
+	}
+
+	if filter.To.IsZero() {
+		filter.To = weekStart(filter.From).AddDate(0, 0, 7)
+	}
+
+	if !filter.To.After(filter.From) {
+		return models.Timesheet{}, errors.New("period must end after it starts")
+	}
+
+	if filter.To.Sub(filter.From) > maxTimesheetDays*24*time.Hour {
+		return models.Timesheet{}, errors.New("period can't be longer than a year")
+	}
+
+	entries, err := w.repo.GetTimesheet(filter)
+	if err != nil {
+		log.Println("failed to get the timesheet. Error is: ", err.Error())
+		return models.Timesheet{}, err
+	}
+
+	timesheet := models.Timesheet{From: filter.From, To: filter.To, Entries: entries}
+	for _, e := range entries {
+		timesheet.Total += e.Minutes
+	}
+
+	return timesheet, nil
+}
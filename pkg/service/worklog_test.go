@@ -0,0 +1,98 @@
+package service
+
+import (
+	"github.com/sharifsharifzoda/project-management-system/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPrepareWorklog(t *testing.T) {
+	now := time.Date(2024, 3, 14, 18, 0, 0, 0, time.UTC)
+	at := func(hour, min int) *time.Time {
+		t := time.Date(2024, 3, 14, hour, min, 0, 0, time.UTC)
+		return &t
+	}
+
+	testTable := []struct {
+		name            string
+		worklog         models.Worklog
+		expectedStart   time.Time
+		expectedEnd     time.Time
+		expectedMinutes int
+		expectedError   string
+	}{
+		{
+			name:            "start and end",
+			worklog:         models.Worklog{StartedAt: *at(9, 0), EndedAt: at(10, 30)},
+			expectedStart:   *at(9, 0),
+			expectedEnd:     *at(10, 30),
+			expectedMinutes: 90,
+		},
+		{
+			name:            "start and minutes",
+			worklog:         models.Worklog{StartedAt: *at(13, 0), Minutes: 45},
+			expectedStart:   *at(13, 0),
+			expectedEnd:     *at(13, 45),
+			expectedMinutes: 45,
+		},
+		{
+			name:            "minutes only end now",
+			worklog:         models.Worklog{Minutes: 120},
+			expectedStart:   *at(16, 0),
+			expectedEnd:     now,
+			expectedMinutes: 120,
+		},
+		{
+			name:          "both end and minutes",
+			worklog:       models.Worklog{StartedAt: *at(9, 0), EndedAt: at(10, 0), Minutes: 60},
+			expectedError: "give either ended_at or minutes, not both",
+		},
+		{
+			name:          "end without start",
+			worklog:       models.Worklog{EndedAt: at(10, 0)},
+			expectedError: "started_at is required with ended_at",
+		},
+		{
+			name:          "end before start",
+			worklog:       models.Worklog{StartedAt: *at(11, 0), EndedAt: at(10, 0)},
+			expectedError: "worklog must end after it starts",
+		},
+		{
+			name:          "no duration",
+			worklog:       models.Worklog{StartedAt: *at(11, 0)},
+			expectedError: "either ended_at or a positive number of minutes is required",
+		},
+		{
+			name:          "ends in the future",
+			worklog:       models.Worklog{StartedAt: *at(17, 30), Minutes: 60},
+			expectedError: "worklog can't end in the future",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			worklog := test.worklog
+			err := prepareWorklog(&worklog, now)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStart, worklog.StartedAt)
+			require.NotNil(t, worklog.EndedAt)
+			assert.Equal(t, test.expectedEnd, *worklog.EndedAt)
+			assert.Equal(t, test.expectedMinutes, worklog.Minutes)
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, monday, weekStart(time.Date(2024, 3, 11, 9, 30, 0, 0, time.UTC)))
+	assert.Equal(t, monday, weekStart(time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, monday, weekStart(time.Date(2024, 3, 17, 23, 59, 0, 0, time.UTC)))
+}
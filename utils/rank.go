@@ -0,0 +1,74 @@
+package utils
+
+import "strings"
+
+// Ranks order items the way lexorank does: each rank is a base-36 fraction
+// written without the leading "0.", so plain string comparison sorts them and
+// there is always room for a new rank between two others. Ranks never end
+// with '0', otherwise nothing would fit right before them.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankDigits)
+
+func rankDigit(c byte) int {
+	return strings.IndexByte(rankDigits, c)
+}
+
+// RankBetween returns a rank that sorts after prev and before next. An empty
+// prev means the beginning of the list, an empty next its end.
+func RankBetween(prev, next string) string {
+	if next != "" && prev >= next {
+		next = ""
+	}
+
+	var rank []byte
+	prevBound, nextBound := true, next != ""
+	for i := 0; ; i++ {
+		lo := 0
+		if prevBound && i < len(prev) {
+			lo = rankDigit(prev[i])
+		}
+
+		hi := rankBase
+		if nextBound && i < len(next) {
+			hi = rankDigit(next[i])
+		}
+
+		if hi-lo > 1 {
+			return string(append(rank, rankDigits[(lo+hi)/2]))
+		}
+
+		rank = append(rank, rankDigits[lo])
+		if lo < hi {
+			nextBound = false
+		}
+		if i >= len(prev) {
+			prevBound = false
+		}
+	}
+}
+
+// SpreadRanks returns n ascending ranks spread evenly over the whole range,
+// so later inserts anywhere stay short.
+func SpreadRanks(n int) []string {
+	width, size := 1, rankBase
+	for size <= n {
+		width++
+		size *= rankBase
+	}
+
+	ranks := make([]string, 0, n)
+	for k := 1; k <= n; k++ {
+		value := k * size / (n + 1)
+
+		digits := make([]byte, width)
+		for i := width - 1; i >= 0; i-- {
+			digits[i] = rankDigits[value%rankBase]
+			value /= rankBase
+		}
+
+		ranks = append(ranks, strings.TrimRight(string(digits), "0"))
+	}
+
+	return ranks
+}
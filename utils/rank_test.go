@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	testTable := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{name: "Empty list"},
+		{name: "Top", next: "i"},
+		{name: "Bottom", prev: "i"},
+		{name: "Adjacent digits", prev: "a", next: "b"},
+		{name: "Prefix", prev: "a", next: "a1"},
+		{name: "Last digit", prev: "z", next: ""},
+		{name: "Long", prev: "azzz", next: "b"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rank := RankBetween(testCase.prev, testCase.next)
+			assert.Greater(t, rank, testCase.prev)
+			if testCase.next != "" {
+				assert.Less(t, rank, testCase.next)
+			}
+			assert.NotEqual(t, byte('0'), rank[len(rank)-1])
+		})
+	}
+}
+
+func TestRankBetween_RepeatedInserts(t *testing.T) {
+	prev, next := "a", "b"
+	for i := 0; i < 200; i++ {
+		rank := RankBetween(prev, next)
+		require.True(t, prev < rank && rank < next, "%s < %s < %s", prev, rank, next)
+		if i%2 == 0 {
+			prev = rank
+		} else {
+			next = rank
+		}
+	}
+}
+
+func TestSpreadRanks(t *testing.T) {
+	for _, n := range []int{1, 2, 35, 36, 1000} {
+		ranks := SpreadRanks(n)
+		require.Len(t, ranks, n)
+		assert.True(t, sort.StringsAreSorted(ranks))
+
+		for i := 1; i < n; i++ {
+			assert.NotEqual(t, ranks[i-1], ranks[i])
+		}
+		for _, rank := range ranks {
+			assert.NotEmpty(t, rank)
+			assert.NotEqual(t, byte('0'), rank[len(rank)-1])
+		}
+	}
+}
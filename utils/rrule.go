@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule is the subset of RFC 5545 recurrence rules tasks support: FREQ,
+// INTERVAL, BYDAY (weekly rules only), BYMONTHDAY (monthly rules only, -1
+// meaning the last day), COUNT and UNTIL. Weeks start on Monday.
+type RRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+	Count      int
+	Until      time.Time
+}
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// maxPeriods bounds the search for the next occurrence, so a rule that can't
+// produce one, such as every February 30th, doesn't loop forever.
+const maxPeriods = 10000
+
+func ParseRRule(rule string) (RRule, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	r := RRule{Interval: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return RRule{}, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = value
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return RRule{}, fmt.Errorf("invalid BYDAY value %q", day)
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = strconv.Atoi(value)
+			if err == nil && (r.ByMonthDay == 0 || r.ByMonthDay < -1 || r.ByMonthDay > 31) {
+				err = errors.New("must be between 1 and 31 or -1")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		default:
+			return RRule{}, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return RRule{}, fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		return RRule{}, errors.New("FREQ is required")
+	default:
+		return RRule{}, fmt.Errorf("unsupported FREQ %s", r.Freq)
+	}
+
+	if len(r.ByDay) > 0 && r.Freq != FreqWeekly {
+		return RRule{}, errors.New("BYDAY is supported only with FREQ=WEEKLY")
+	}
+
+	if r.ByMonthDay != 0 && r.Freq != FreqMonthly {
+		return RRule{}, errors.New("BYMONTHDAY is supported only with FREQ=MONTHLY")
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return RRule{}, errors.New("COUNT and UNTIL can't be used together")
+	}
+
+	sort.Slice(r.ByDay, func(i, j int) bool {
+		return mondayOffset(r.ByDay[i]) < mondayOffset(r.ByDay[j])
+	})
+
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// A date includes the whole day.
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("expected YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// Next returns the first occurrence of the series starting at start that
+// comes after after. It returns false once the series is past UNTIL. COUNT is
+// left to the caller, which knows how many occurrences it has made.
+func (r RRule) Next(start, after time.Time) (time.Time, bool) {
+	for period := 0; period < maxPeriods; period++ {
+		for _, candidate := range r.period(start, period) {
+			if candidate.Before(start) || !candidate.After(after) {
+				continue
+			}
+
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return time.Time{}, false
+			}
+
+			return candidate, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// period returns the occurrences of the n-th period of the series in order.
+func (r RRule) period(start time.Time, n int) []time.Time {
+	step := n * r.Interval
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+
+	switch r.Freq {
+	case FreqDaily:
+		return []time.Time{start.AddDate(0, 0, step)}
+	case FreqWeekly:
+		anchor := start.AddDate(0, 0, 7*step)
+		if len(r.ByDay) == 0 {
+			return []time.Time{anchor}
+		}
+
+		monday := anchor.AddDate(0, 0, -mondayOffset(anchor.Weekday()))
+		days := make([]time.Time, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, monday.AddDate(0, 0, mondayOffset(day)))
+		}
+		return days
+	case FreqMonthly:
+		first := at(start.Year(), start.Month()+time.Month(step), 1)
+		lastDay := first.AddDate(0, 1, -1).Day()
+
+		day := start.Day()
+		if r.ByMonthDay == -1 {
+			day = lastDay
+		} else if r.ByMonthDay > 0 {
+			day = r.ByMonthDay
+		}
+
+		// Months without the day are skipped, as RFC 5545 requires.
+		if day > lastDay {
+			return nil
+		}
+		return []time.Time{at(first.Year(), first.Month(), day)}
+	case FreqYearly:
+		candidate := at(start.Year()+step, start.Month(), start.Day())
+		if candidate.Day() != start.Day() {
+			return nil
+		}
+		return []time.Time{candidate}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	testTable := []struct {
+		name          string
+		rule          string
+		expected      RRule
+		expectedError string
+	}{
+		{
+			name:     "Daily",
+			rule:     "FREQ=DAILY",
+			expected: RRule{Freq: FreqDaily, Interval: 1},
+		},
+		{
+			name:     "Prefix and lower case",
+			rule:     "rrule:freq=weekly;interval=2;byday=fr,mo",
+			expected: RRule{Freq: FreqWeekly, Interval: 2, ByDay: []time.Weekday{time.Monday, time.Friday}},
+		},
+		{
+			name:     "Last day of the month",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=12",
+			expected: RRule{Freq: FreqMonthly, Interval: 1, ByMonthDay: -1, Count: 12},
+		},
+		{
+			name: "Until date",
+			rule: "FREQ=YEARLY;UNTIL=20301231",
+			expected: RRule{Freq: FreqYearly, Interval: 1,
+				Until: time.Date(2030, 12, 31, 23, 59, 59, 0, time.UTC)},
+		},
+		{
+			name:          "No frequency",
+			rule:          "INTERVAL=2",
+			expectedError: "FREQ is required",
+		},
+		{
+			name:          "Unsupported frequency",
+			rule:          "FREQ=HOURLY",
+			expectedError: "unsupported FREQ HOURLY",
+		},
+		{
+			name:          "Unsupported part",
+			rule:          "FREQ=DAILY;BYHOUR=9",
+			expectedError: "unsupported rule part BYHOUR",
+		},
+		{
+			name:          "Bad interval",
+			rule:          "FREQ=DAILY;INTERVAL=0",
+			expectedError: "invalid INTERVAL: must be positive",
+		},
+		{
+			name:          "Bad day",
+			rule:          "FREQ=WEEKLY;BYDAY=XX",
+			expectedError: "invalid BYDAY value \"XX\"",
+		},
+		{
+			name:          "BYDAY outside weekly rules",
+			rule:          "FREQ=MONTHLY;BYDAY=MO",
+			expectedError: "BYDAY is supported only with FREQ=WEEKLY",
+		},
+		{
+			name:          "COUNT with UNTIL",
+			rule:          "FREQ=DAILY;COUNT=3;UNTIL=20300101",
+			expectedError: "COUNT and UNTIL can't be used together",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := ParseRRule(testCase.rule)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, rule)
+		})
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 17, 0, 0, 0, time.UTC)
+	}
+
+	testTable := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		after    time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "Every other day",
+			rule:     "FREQ=DAILY;INTERVAL=2",
+			start:    date(2024, 3, 1),
+			after:    date(2024, 3, 1),
+			expected: []time.Time{date(2024, 3, 3), date(2024, 3, 5)},
+		},
+		{
+			name:     "Mondays and Fridays",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,FR",
+			start:    date(2024, 3, 6), // Wednesday
+			after:    date(2024, 3, 6),
+			expected: []time.Time{date(2024, 3, 8), date(2024, 3, 11), date(2024, 3, 15)},
+		},
+		{
+			name:     "Every two weeks",
+			rule:     "FREQ=WEEKLY;INTERVAL=2",
+			start:    date(2024, 3, 4),
+			after:    date(2024, 3, 20),
+			expected: []time.Time{date(2024, 4, 1)},
+		},
+		{
+			name:     "Monthly on the 31st skips short months",
+			rule:     "FREQ=MONTHLY",
+			start:    date(2024, 1, 31),
+			after:    date(2024, 1, 31),
+			expected: []time.Time{date(2024, 3, 31), date(2024, 5, 31)},
+		},
+		{
+			name:     "Last day of the month",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:    date(2024, 1, 31),
+			after:    date(2024, 1, 31),
+			expected: []time.Time{date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30)},
+		},
+		{
+			name:     "Leap day",
+			rule:     "FREQ=YEARLY",
+			start:    date(2024, 2, 29),
+			after:    date(2024, 2, 29),
+			expected: []time.Time{date(2028, 2, 29)},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := ParseRRule(testCase.rule)
+			require.NoError(t, err)
+
+			after := testCase.after
+			for _, expected := range testCase.expected {
+				next, ok := rule.Next(testCase.start, after)
+				require.True(t, ok)
+				assert.Equal(t, expected, next)
+				after = next
+			}
+		})
+	}
+}
+
+func TestRRuleNextUntil(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;UNTIL=20240315")
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.Next(start, start)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC), next)
+
+	next, ok = rule.Next(start, next)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC), next)
+
+	_, ok = rule.Next(start, next)
+	assert.False(t, ok)
+}
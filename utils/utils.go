@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 )
 
 func GenFilenameWithDir(filename string) (string, error) {
@@ -25,3 +26,28 @@ func GenFilenameWithDir(filename string) (string, error) {
 
 	return "", errors.New("unsupported media type")
 }
+
+// NormalizeName reduces a free-text name to a comparison key, so "IT", "it"
+// and "I.T." all map to "it".
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// ParseDeadline reads a task deadline, given either as sent by clients or as
+// read back from the timestamp column.
+func ParseDeadline(deadline string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, deadline); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid deadline format")
+}
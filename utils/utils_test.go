@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	testTable := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Lower case", input: "it", expected: "it"},
+		{name: "Upper case", input: "IT", expected: "it"},
+		{name: "Punctuation", input: "I.T.", expected: "it"},
+		{name: "Spaces", input: "  Human   Resources ", expected: "humanresources"},
+		{name: "Digits", input: "Team-42", expected: "team42"},
+		{name: "Non-Latin letters", input: "Бухгалтерия!", expected: "бухгалтерия"},
+		{name: "Only punctuation", input: " .-/ ", expected: ""},
+		{name: "Empty", input: "", expected: ""},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, NormalizeName(testCase.input))
+		})
+	}
+}